@@ -0,0 +1,94 @@
+// Package blobstore stores files, such as profile pictures, for usersvc.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned by Get when no blob is stored under a key.
+	ErrNotFound = errors.New("blobstore: not found")
+	// ErrInvalidKey is returned for keys that are not clean, relative, slash-separated paths.
+	ErrInvalidKey = errors.New("blobstore: invalid key")
+)
+
+// BlobStore stores blobs under slash-separated keys, such as "avatars/<user ID>/512".
+type BlobStore interface {
+	// Put stores the contents of r under key, replacing any blob already there.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get opens the blob stored under key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. Deleting a blob that doesn't exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewDir returns a BlobStore that keeps each blob in its own file under dir, creating directories as needed. Every
+// replica of usersvc must see the same dir, such as a shared volume.
+func NewDir(dir string) BlobStore {
+	return dirStore(dir)
+}
+
+type dirStore string
+
+func (d dirStore) Put(ctx context.Context, key string, r io.Reader) error {
+	name, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), os.FileMode(0755)); err != nil {
+		return err
+	}
+	// Write to a temporary file and rename it into place, so readers never see a partial blob.
+	f, err := ioutil.TempFile(filepath.Dir(name), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+func (d dirStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := d.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (d dirStore) Delete(ctx context.Context, key string) error {
+	name, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path returns the file a key is stored in, refusing keys that could escape the directory.
+func (d dirStore) path(key string) (string, error) {
+	if key == "" || path.Clean(key) != key || path.IsAbs(key) || key == ".." || strings.HasPrefix(key, "../") ||
+		strings.Contains(key, `\`) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(string(d), filepath.FromSlash(key)), nil
+}
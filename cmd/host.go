@@ -21,17 +21,21 @@
 package cmd
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/metrics"
 	"github.com/go-kit/kit/metrics/prometheus"
+	"github.com/nats-io/go-nats"
 	"github.com/ory/hydra/sdk"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -39,16 +43,28 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/studiously/classsvc/classsvc"
+	"github.com/studiously/usersvc/blobstore"
 	"github.com/studiously/usersvc/ddl"
-	"github.com/studiously/usersvc/usersvc"
+	"github.com/studiously/usersvc/hydra"
+	"github.com/studiously/usersvc/mailer"
 	"github.com/studiously/usersvc/middleware"
+	"github.com/studiously/usersvc/oidc"
+	"github.com/studiously/usersvc/pb"
+	"github.com/studiously/usersvc/usersvc"
+	"github.com/studiously/usersvc/webauthn"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
 )
 
 var (
 	addr      string
+	grpcAddr  string
 	debugAddr string
 )
 
+// purgeInterval is how often deleted users are checked for any that can be purged.
+const purgeInterval = time.Hour
+
 // hostCmd represents the host command
 var hostCmd = &cobra.Command{
 	Use:   "host",
@@ -62,19 +78,83 @@ Core Controls
 - DATABASE_DRIVER: The driver to use with the database. Only 'postgres' is currently supported.
 - DATABASE_CONFIG: A URL to a persistent backend.
 - CLASSSVC_URL: A URL to an instance of classsvc.
+- PUBLIC_URL: The externally reachable base URL of this service, used in links sent by email.
+- DELETION_RETENTION: How long users who delete their accounts can restore them before they are purged, e.g. "168h". Defaults to 30 days.
+
+Password Reset Controls
+=======================
+- RESET_SECRET: Key used to sign password reset links, and from which the keys for data export links and for email addresses in the audit log are derived. If unset, a random key is generated, so links only work on the instance that sent them, and stop working when it restarts.
+- RESET_TTL: How long password reset links remain valid, e.g. "30m". Defaults to one hour.
+
+Password Controls
+=================
+Passwords hashed with other algorithms or costs are upgraded the next time their owner logs in.
+- PASSWORD_HASHER: Algorithm for new password hashes, either "argon2id" (default) or "bcrypt".
+- PASSWORD_BCRYPT_COST: bcrypt cost factor. Defaults to 10.
+- PASSWORD_ARGON2ID_TIME: Argon2id iterations. Defaults to 3.
+- PASSWORD_ARGON2ID_MEMORY: Argon2id memory in KiB. Defaults to 65536.
+- PASSWORD_ARGON2ID_THREADS: Argon2id parallelism. Defaults to 4.
+
+Lockout Controls
+================
+Repeated failed logins for the same email or from the same address are slowed down and eventually locked out for a while.
+- LOCKOUT_STORE: Where failed attempts are tracked, either "postgres" (default, shared by all instances) or "memory".
+- TRUST_PROXY: Whether to take the client address from the X-Forwarded-For header. Only enable this behind a proxy that sets it.
+
+Session Controls
+================
+Users stay logged in to usersvc's pages with a cookie that refers to a session kept by the service, which they can see and revoke.
+- SESSION_STORE: Where sessions are kept, either "postgres" (default, shared by all instances) or "memory", which logs everyone out when the service restarts.
+- SESSION_TTL: How long a session lasts after logging in, however much it is used, e.g. "8h". Defaults to 12 hours.
+- SESSION_IDLE_TTL: How long a session lasts without being used, e.g. "30m". Defaults to one hour.
+- SESSION_REMEMBER_TTL: SESSION_TTL for users who tick "Keep me signed in". Defaults to 30 days.
+- SESSION_REMEMBER_IDLE_TTL: SESSION_IDLE_TTL for users who tick "Keep me signed in". Defaults to 7 days.
+- COOKIE_SECRET: Key used to sign the session cookie. If unset, a random key is generated and everyone is logged out when the service restarts.
+
+Passkey Controls
+================
+- WEBAUTHN_RP_ID: Domain passkeys are registered to. Defaults to the host of PUBLIC_URL. Changing it invalidates every registered passkey.
+- WEBAUTHN_ORIGINS: Comma-separated origins allowed to register and use passkeys, such as "https://studiously.net,https://accounts.studiously.net". Defaults to the origin of PUBLIC_URL.
+
+Login Provider Controls
+=======================
+Users can log in with external OpenID Connect providers, such as Google and Microsoft, as well as with a password. Register usersvc with each provider using the redirect URL PUBLIC_URL/oidc/<ID>/callback.
+- OIDC_PROVIDERS: Comma-separated IDs of the providers to offer, such as "google,microsoft". An ID must not change once users have logged in with it.
+- OIDC_<ID>_CLIENT_ID: Client ID issued by the provider.
+- OIDC_<ID>_CLIENT_SECRET: Client secret issued by the provider.
+- OIDC_<ID>_ISSUER: Issuer URL of the provider. Defaults to Google's for "google", and Microsoft's multi-tenant issuer for "microsoft".
+- OIDC_<ID>_NAME: Name shown on the login page, as in "Continue with Google". Defaults to "Google" and "Microsoft" for those IDs, and to the ID otherwise.
+
+Mail Controls
+=============
+Without a mail server, outbound email is printed to stdout.
+- MAIL_DIR: If set, each outbound email is written to its own file in this directory instead.
+
+Storage Controls
+================
+- BLOB_DIR: Directory uploaded files, such as profile pictures, are stored in. Every instance must share it. Defaults to a directory under the system's temporary directory, which is only fit for development.
+- EXPORT_TTL: How long a data export can be downloaded for once it is ready, e.g. "48h". Defaults to 24 hours, after which the export is deleted.
 
 Hydra Controls
 ==============
-A Hydra server is required. Most endpoints (excepting health and unauthenticated ones) will fail without a valid Hydra server.
+A Hydra server is required. Most endpoints (excepting health and unauthenticated ones) will fail without a valid Hydra server. Logging out also needs the client to be allowed to read Hydra's clients and ID token signing key, and to revoke consent.
 - HYDRA_CLIENT_ID: ID for Hydra client.
 - HYDRA_CLIENT_SECRET: Secret for Hydra client.
 - HYDRA_CLUSTER_URL: URL of Hydra cluster.
 - HYDRA_TLS_VERIFY: Whether the client should verify Hydra's TLS.
+- HYDRA_ISSUER: Issuer of the ID tokens Hydra issues, which the logout tokens sent to clients must match. Defaults to HYDRA_CLUSTER_URL.
+
+Logout Controls
+===============
+Hydra 0.8 doesn't keep where clients want to be sent back to or told about it when a user logs out, so it is configured here. Logging out of a session revokes the consent given to each client used during it, along with the tokens issued under it, and sends the client a logout token naming the session. Revoking an app sends one for all of the user's sessions.
+- LOGOUT_CLIENTS: Comma-separated IDs of the clients configured below.
+- LOGOUT_<ID>_REDIRECT_URIS: Comma-separated URIs the client may be sent back to after logging out, in addition to its redirect URIs.
+- LOGOUT_<ID>_BACKCHANNEL_URI: URI the client is sent logout tokens at.
 
 Messaging Controls
 ==================
 A NATS cluster is required for messaging across services. Without it, stale data pertaining to deleted resources may remain in the database, merely becoming inaccessible.
-- NATS_CLUSTER_URL: URL of NATS cluster.
+- NATS_CLUSTER_URL: URL of NATS cluster. Purged users are announced on the users.delete subject.
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Set up logger
@@ -92,7 +172,7 @@ A NATS cluster is required for messaging across services. Without it, stale data
 				Namespace: "unitsvc",
 				Name:      "request_count",
 				Help:      "Total count of requests to all endpoints.",
-			}, []string{})
+			}, []string{"method", "error"})
 		}
 		var requestLatency metrics.Histogram
 		{
@@ -101,7 +181,15 @@ A NATS cluster is required for messaging across services. Without it, stale data
 				Namespace: "unitsvc",
 				Name:      "request_duration_ns",
 				Help:      "Request duration in nanoseconds.",
-			}, []string{"method", "success"})
+			}, []string{"method", "error"})
+		}
+		var loginFailures metrics.Counter
+		{
+			loginFailures = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: "usersvc",
+				Name:      "login_failures",
+				Help:      "Count of failed login attempts by reason.",
+			}, []string{"reason"})
 		}
 
 		// Connect to Hydra
@@ -127,7 +215,8 @@ A NATS cluster is required for messaging across services. Without it, stale data
 			var driver = viper.GetString("database.driver")
 			var config = viper.GetString("database.config")
 
-			db, err := sql.Open(driver, config)
+			var err error
+			db, err = sql.Open(driver, config)
 			if err != nil {
 				logger.Log("msg", "database connection failed", "error", err)
 				os.Exit(-1)
@@ -152,12 +241,89 @@ A NATS cluster is required for messaging across services. Without it, stale data
 			}
 		}
 
+		var m mailer.Mailer
+		{
+			m = mailer.NewWriter(os.Stdout)
+			if dir := viper.GetString("mail.dir"); dir != "" {
+				var err error
+				m, err = mailer.NewDir(dir)
+				if err != nil {
+					logger.Log("msg", "could not set up mail directory", "error", err, "dir", dir)
+					os.Exit(-1)
+				}
+			}
+		}
+
+		var hasher usersvc.PasswordHasher
+		{
+			var err error
+			hasher, err = passwordHasher()
+			if err != nil {
+				logger.Log("msg", "invalid password hashing configuration", "error", err)
+				os.Exit(-1)
+			}
+		}
+
 		// Initialize service and middleware
 		var service usersvc.Service
 		{
-			service = usersvc.New(db, cs)
+			options := []usersvc.Option{usersvc.Mailer(m), usersvc.Hasher(hasher)}
+			if viper.GetString("lockout.store") == "memory" {
+				options = append(options, usersvc.Attempts(usersvc.NewMemoryAttemptStore()))
+			}
+			if viper.GetString("session.store") == "memory" {
+				options = append(options, usersvc.Sessions(usersvc.NewMemorySessionStore()))
+			}
+			options = append(options, usersvc.Hydra(&hydra.Admin{
+				ClusterURL:   viper.GetString("hydra.cluster_url"),
+				ClientID:     viper.GetString("hydra.client.id"),
+				ClientSecret: viper.GetString("hydra.client.secret"),
+				Issuer:       viper.GetString("hydra.issuer"),
+			}))
+			options = append(options, usersvc.LogoutClients(logoutClients()))
+			options = append(options, usersvc.SessionLifetimes(usersvc.SessionLifetime{
+				Absolute:         viper.GetDuration("session.ttl"),
+				Idle:             viper.GetDuration("session.idle_ttl"),
+				RememberAbsolute: viper.GetDuration("session.remember_ttl"),
+				RememberIdle:     viper.GetDuration("session.remember_idle_ttl"),
+			}))
+			if u := viper.GetString("public_url"); u != "" {
+				options = append(options, usersvc.PublicURL(u))
+			}
+			if secret := viper.GetString("reset.secret"); secret != "" {
+				options = append(options, usersvc.ResetSecret([]byte(secret)))
+			} else {
+				logger.Log("msg", "RESET_SECRET is unset, so reset links only work on this instance until it restarts")
+			}
+			if ttl := viper.GetDuration("reset.ttl"); ttl > 0 {
+				options = append(options, usersvc.ResetTTL(ttl))
+			}
+			if retention := viper.GetDuration("deletion.retention"); retention > 0 {
+				options = append(options, usersvc.DeletionRetention(retention))
+			}
+			if ttl := viper.GetDuration("export.ttl"); ttl > 0 {
+				options = append(options, usersvc.ExportTTL(ttl))
+			}
+			if dir := viper.GetString("blob.dir"); dir != "" {
+				options = append(options, usersvc.Blobs(blobstore.NewDir(dir)))
+			}
+			rp := webauthn.RelyingParty{ID: viper.GetString("webauthn.rp_id")}
+			if origins := viper.GetString("webauthn.origins"); origins != "" {
+				rp.Origins = strings.Split(origins, ",")
+			}
+			options = append(options, usersvc.RelyingParty(rp))
+			options = append(options, usersvc.Providers(oidcProviders()...))
+			service = usersvc.New(db, cs, options...)
+			if u := viper.GetString("nats.cluster_url"); u != "" {
+				nc, err := nats.Connect(u)
+				if err != nil {
+					logger.Log("msg", "could not connect to NATS cluster", "error", err, "cluster_url", u)
+					os.Exit(-1)
+				}
+				service = middleware.Messaging(nc)(service)
+			}
 			service = middleware.Logging(logger)(service)
-			service = middleware.Instrumenting(requestCount, requestLatency)(service)
+			service = middleware.Instrumenting(requestCount, requestLatency, loginFailures)(service)
 		}
 
 		errs := make(chan error)
@@ -173,11 +339,19 @@ A NATS cluster is required for messaging across services. Without it, stale data
 
 		// Handle keyboard interrupts
 		go func() {
-			c := make(chan os.Signal)
+			c := make(chan os.Signal, 1)
 			signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
 			errs <- fmt.Errorf("%s", <-c)
 		}()
 
+		// Purge deleted users once they can no longer be restored
+		go func() {
+			for range time.Tick(purgeInterval) {
+				// Failures are logged by the middleware, and retried next time.
+				service.PurgeDeletedUsers(context.Background())
+			}
+		}()
+
 		// Start HTTP server for main service
 		var h = usersvc.MakeHTTPHandler(service, client, logger)
 		go func(address string) {
@@ -185,6 +359,23 @@ A NATS cluster is required for messaging across services. Without it, stale data
 			errs <- http.ListenAndServe(address, h)
 		}(addr)
 
+		// Start gRPC server for main service
+		if grpcAddr != "" {
+			go func(address string) {
+				logger.Log("transport", "gRPC", "addr", address)
+				ln, err := net.Listen("tcp", address)
+				if err != nil {
+					errs <- err
+					return
+				}
+				srv := grpc.NewServer()
+				pb.RegisterUsersServer(srv, usersvc.MakeGRPCServer(service, client, logger))
+				errs <- srv.Serve(ln)
+			}(grpcAddr)
+		}
+
+		logger.Log("exit", <-errs)
+
 	},
 }
 
@@ -192,6 +383,7 @@ func init() {
 	RootCmd.AddCommand(hostCmd)
 
 	hostCmd.Flags().StringVarP(&addr, "addr", "a", ":8080", "HTTP listen address")
+	hostCmd.Flags().StringVarP(&grpcAddr, "grpc-addr", "g", ":8082", "gRPC listen address, or empty to disable gRPC")
 	hostCmd.Flags().StringVarP(&debugAddr, "debug-addr", "d", ":8081", "Debug and metrics listen address")
 
 }
@@ -216,3 +408,83 @@ func pingDatabase(db *sql.DB) (err error) {
 	}
 	return
 }
+
+func passwordHasher() (usersvc.PasswordHasher, error) {
+	switch algorithm := viper.GetString("password.hasher"); algorithm {
+	case "", "argon2id":
+		h := usersvc.DefaultArgon2idHasher
+		if t := viper.GetInt("password.argon2id.time"); t > 0 {
+			h.Time = uint32(t)
+		}
+		if m := viper.GetInt("password.argon2id.memory"); m > 0 {
+			h.Memory = uint32(m)
+		}
+		if p := viper.GetInt("password.argon2id.threads"); p > 0 {
+			h.Threads = uint8(p)
+		}
+		return h, nil
+	case "bcrypt":
+		h := usersvc.BcryptHasher{Cost: bcrypt.DefaultCost}
+		if cost := viper.GetInt("password.bcrypt.cost"); cost > 0 {
+			if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+				return nil, fmt.Errorf("bcrypt cost %d is out of range", cost)
+			}
+			h.Cost = cost
+		}
+		return h, nil
+	default:
+		return nil, fmt.Errorf("unknown password hasher %q", algorithm)
+	}
+}
+
+// wellKnownProviders holds defaults for providers most schools use.
+var wellKnownProviders = map[string]struct{ name, issuer string }{
+	"google":    {"Google", "https://accounts.google.com"},
+	"microsoft": {"Microsoft", "https://login.microsoftonline.com/common/v2.0"},
+}
+
+func oidcProviders() []*oidc.Provider {
+	var providers []*oidc.Provider
+	for _, id := range strings.Split(viper.GetString("oidc.providers"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		known := wellKnownProviders[id]
+		p := &oidc.Provider{
+			ID:           id,
+			Name:         known.name,
+			Issuer:       known.issuer,
+			ClientID:     viper.GetString("oidc." + id + ".client_id"),
+			ClientSecret: viper.GetString("oidc." + id + ".client_secret"),
+		}
+		if issuer := viper.GetString("oidc." + id + ".issuer"); issuer != "" {
+			p.Issuer = issuer
+		}
+		if name := viper.GetString("oidc." + id + ".name"); name != "" {
+			p.Name = name
+		}
+		if p.Name == "" {
+			p.Name = id
+		}
+		providers = append(providers, p)
+	}
+	return providers
+}
+
+func logoutClients() map[string]usersvc.ClientLogout {
+	clients := make(map[string]usersvc.ClientLogout)
+	for _, id := range strings.Split(viper.GetString("logout.clients"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		var c usersvc.ClientLogout
+		if uris := viper.GetString("logout." + id + ".redirect_uris"); uris != "" {
+			c.PostLogoutRedirectURIs = strings.Split(uris, ",")
+		}
+		c.BackChannelLogoutURI = viper.GetString("logout." + id + ".backchannel_uri")
+		clients[id] = c
+	}
+	return clients
+}
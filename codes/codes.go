@@ -11,4 +11,29 @@ const (
 	NotFound
 	// DeleteOwner indicates that a user cannot be deleted because it is currently the owner of a class.
 	DeleteOwner
+	// InvalidToken indicates that a password reset or email verification token is malformed, expired or has already
+	// been used.
+	InvalidToken
+	// EmailInUse indicates that another user already has the requested email address.
+	EmailInUse
+	// TooManyAttempts indicates that login is temporarily locked after repeated failures.
+	TooManyAttempts
+	// WrongCode indicates that a two-factor authentication or recovery code is wrong or has already been used.
+	WrongCode
+	// TwoFactorEnabled indicates that the user already has two-factor authentication turned on.
+	TwoFactorEnabled
+	// InvalidCredential indicates that a passkey could not be registered or used to log in.
+	InvalidCredential
+	// IdentityInUse indicates that an external account is already linked to a user.
+	IdentityInUse
+	// LastIdentity indicates that removing a way of logging in would leave the user with none.
+	LastIdentity
+	// LinkRequired indicates that logging in with an external account would duplicate an existing user, who has to
+	// log in and link it instead.
+	LinkRequired
+	// AccountInactive indicates that the user's account isn't active, so they can't log in or make changes. The
+	// message says why, such as their email address not being verified yet.
+	AccountInactive
+	// InvalidTransition indicates that the user's account can't change from the state it is in to the one requested.
+	InvalidTransition
 )
@@ -1,10 +1,32 @@
 // Code generated by go-bindata.
 // sources:
+// postgres/10_deferred_deletion.sql
+// postgres/11_user_states.sql
+// postgres/12_audit_events.sql
+// postgres/13_sessions.sql
+// postgres/14_session_lifetime.sql
+// postgres/15_session_clients.sql
+// postgres/16_consent_grants.sql
+// postgres/17_audit_chain_head.sql
 // postgres/1_init.sql
+// postgres/2_email_verification.sql
+// postgres/3_login_attempts.sql
+// postgres/4_two_factor.sql
+// postgres/5_webauthn.sql
+// postgres/6_federated_identities.sql
+// postgres/7_profile.sql
+// postgres/8_user_search.sql
+// postgres/9_data_exports.sql
 // tmpl/consent.html
 // tmpl/error.html
+// tmpl/linked.html
 // tmpl/login.html
+// tmpl/login_2fa.html
+// tmpl/logout.html
 // tmpl/register.html
+// tmpl/reset.html
+// tmpl/reset_confirm.html
+// tmpl/verify.html
 // DO NOT EDIT!
 
 package ddl
@@ -72,6 +94,166 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
+var _postgres10_deferred_deletionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\xcd\x6a\x84\x30\x14\x85\xf7\xf7\x29\xce\xb2\xa5\x38\x2f\xe0\xca\x8e\x81\x0a\xfe\x0c\x1a\x69\xe9\x46\x82\xb9\xd5\xc0\x98\x0c\x31\xd6\xf6\xed\x4b\x67\x84\x19\x68\x99\x6d\x4e\xce\xf7\x71\x6e\x14\xe1\x69\x32\x83\x57\x81\xd1\x9e\x88\xa2\x08\x0d\x07\xac\x23\x5b\x28\x2c\x33\x7b\x68\x3e\x72\xe0\x19\x61\x64\xe3\xa1\xfa\xde\x2d\x36\xec\x20\x47\xfe\x46\xaf\x2c\xfa\x51\xd9\x81\xb7\x78\x32\x56\x63\xb1\xc1\x1c\x7f\x1f\x70\x5a\xfc\xc0\x1e\x9e\x27\xf7\x79\x41\xc0\xbb\x15\x1f\xce\x63\x70\x4e\xef\x28\xc9\xa5\xa8\x21\x93\xe7\x5c\x9c\x6d\x33\x92\x34\xc5\xbe\xca\xdb\xa2\xdc\xcc\xba\x53\x01\x32\x2b\x44\x23\x93\xe2\x20\xdf\x63\xa2\x7d\x2d\x12\x29\x90\x95\xa9\x78\xbb\xd4\xba\xeb\xdf\xce\xe8\x2f\x54\xe5\x86\x7b\xb8\x06\x8f\x78\x7d\x11\xb5\xb8\xc5\x66\x0d\xca\x4a\xa2\x6c\xf3\x3c\x26\xba\x3d\x46\xea\x56\x4b\x94\xd6\xd5\xe1\x8e\x26\xa6\x7f\x06\x9c\x3b\x7f\x16\xc4\xf4\x33\x00\x57\xcb\xe1\x4e\x6b\x01\x00\x00")
+
+func postgres10_deferred_deletionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres10_deferred_deletionSql,
+		"postgres/10_deferred_deletion.sql",
+	)
+}
+
+func postgres10_deferred_deletionSql() (*asset, error) {
+	bytes, err := postgres10_deferred_deletionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/10_deferred_deletion.sql", size: 363, mode: os.FileMode(420), modTime: time.Unix(1792191736, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres11_user_statesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x54\xc1\x92\xda\x38\x14\xbc\xeb\x2b\xfa\x06\xd4\x02\xfb\x01\x54\x0e\x04\x6b\x13\x2a\x8c\x99\x02\xbb\x36\xd9\x8b\x4b\x58\x6f\x6c\x55\x8c\x44\x49\x02\x76\xfe\x7e\x4b\xb2\x0d\x93\x00\xd9\xdd\xc3\x70\xa2\xac\x56\xbf\xd7\xad\xf7\x7a\x32\xc1\x6f\x7b\x55\x59\xe1\x09\xf9\x81\xb1\xc9\x04\x1b\x3a\x34\xa2\x24\x07\x51\x7a\x75\xa2\x29\xb2\x9a\xe0\xbc\xf0\xe1\x93\x96\xf0\x35\xc1\x5b\xa1\x9d\xf2\xca\x68\x87\x1d\xf9\x33\x91\x0e\xdf\xf7\x10\x96\x20\xc9\x95\x56\xed\x48\x42\x69\x1c\x1d\x59\x77\x2a\x7f\x8f\x04\xd3\xca\x4c\xd9\x7c\x95\xf1\x0d\xb2\xf9\xc7\x15\x6f\x4f\x19\x30\x4f\x12\x2c\xd6\xab\xfc\x29\x6d\x2b\x21\xe3\x5f\x33\xa4\xeb\x0c\x69\xbe\x5a\x21\xe1\x7f\xcc\xf3\x55\x86\xc1\x81\xb4\x54\xba\x2a\x4e\x64\xd5\x8b\x2a\x45\xe8\x60\xc0\x80\xc5\x67\xbe\xf8\x82\x61\x7b\x77\x99\x62\x78\x1f\x39\xc6\xa0\x15\x35\x18\x63\xe0\x8e\x2e\x80\x48\x0e\xc6\x57\x62\x49\x0d\x45\xd2\xd1\x68\x16\xdd\xc8\x43\x87\x38\xd7\x06\xa5\x39\x36\x12\x8d\xa9\x82\xac\x1d\xbd\x18\x1b\x6c\x51\x4d\x83\x52\xe8\x31\xce\x35\xf9\x9a\x2c\x8c\x85\x36\x3e\xb8\xf1\x8a\x5a\x9c\x08\x6d\xaf\x14\x8d\x53\x16\xb4\x17\xaa\x81\x90\xd2\x92\x73\x53\x96\x3f\x27\xf3\xac\x33\x02\x5b\x9e\x75\xfa\x3f\x60\x31\xdf\x72\x06\xfc\xf9\x99\xa7\x88\x5d\x91\x2c\x84\xc7\x72\x7b\xb5\x25\x0b\x67\xb7\xad\xf7\xb7\x02\xae\x95\xdb\x21\xaf\x8a\x19\xc0\x57\x5b\x7e\xb1\x83\xf1\x34\x99\x31\x96\x6c\xd6\xcf\x58\xa6\x09\xff\xda\x36\x54\x34\xe6\x4c\xb6\x88\x2d\x17\x4a\xfe\x3d\xbb\x45\x68\xb1\xa7\xc2\xdb\x6a\xdf\x9e\xdf\xbe\x2e\xe2\x95\xee\x71\xdb\x72\x33\xc6\x16\x1b\x1e\x64\x3f\x64\x62\xc0\x3a\xed\x08\xf2\xed\x32\xfd\x84\x4f\xe1\x59\x03\x06\x95\xd2\x2d\xce\x1c\xdc\xa8\x15\xbb\xe1\x17\xdf\x7a\x49\xf7\x8b\xfc\x24\xe8\x6d\x99\x61\x3c\x1b\xc6\xb3\xd1\x2f\x79\x27\x93\xb0\x13\x96\xa0\x1c\xb4\x41\x98\x04\x55\x69\x7c\xa7\x57\x98\x76\xe2\x0b\x25\xc7\x70\x06\xbe\x16\x71\x14\x60\xa9\x34\x56\xc2\xbc\x40\x44\x00\x76\xa4\x74\x85\xc3\xd1\x56\x24\x61\x8e\xbe\x51\x27\x72\x01\xba\x9f\x86\xc5\x33\xb6\x50\x32\xf2\x1f\x9b\x26\x54\x08\xb3\x58\xd6\x42\x57\xe4\xfa\x9d\xc2\x5e\x7c\x27\x17\x4a\x2a\xef\x60\xce\x7a\x0c\x77\x2c\x6b\x08\x17\x79\x03\x7f\x44\xc2\xe8\x92\x02\xf5\x6b\x98\xd4\xd0\x71\x63\x74\x45\x16\x3b\x82\x25\xe7\x8d\x25\x39\xed\xcd\xba\xbe\x5c\x11\x95\x17\x7d\xd1\x21\x03\x94\x44\xff\xcb\xf3\x65\xd2\xff\xbf\x0c\xe4\xf3\x66\xf9\x34\xdf\x7c\xc3\x17\xfe\x6d\xcc\xd0\x3b\xf1\x08\x1e\x20\x2f\xd6\xec\x8b\x37\x2b\x7f\x07\xe2\x4d\x07\xc0\x43\x88\x25\xe1\x8c\x06\x1e\x40\xae\xf9\x31\x08\x35\x2f\xf6\xb6\x6d\x85\x4f\xa5\x25\xd1\x2d\x58\xb6\x7c\xe2\xdb\x6c\xfe\xf4\x9c\xfd\x75\x4b\xa0\xcd\x79\x38\x62\xa3\x7b\xb3\xf5\xa3\x5d\x45\xa7\xfd\xc7\x21\xfb\xd9\xd2\x0e\x34\x7e\x53\xbf\x8b\x9d\x4b\x28\x27\xe6\xac\xbb\xc5\x7c\xf4\x34\xef\xb5\xb8\x6f\x42\xb9\x8b\x91\x8f\xeb\xf5\xea\xd6\x94\x6c\x93\xf3\x19\xbb\x4d\xb2\xee\xd2\x07\xfc\xf7\x58\x1e\xfd\x6b\x82\x44\xae\x77\x09\x90\x5f\x65\xd3\xff\x8f\x0d\x51\x7a\x75\xa2\x19\xfb\x67\x00\x0d\xbc\xe7\xad\x61\x07\x00\x00")
+
+func postgres11_user_statesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres11_user_statesSql,
+		"postgres/11_user_states.sql",
+	)
+}
+
+func postgres11_user_statesSql() (*asset, error) {
+	bytes, err := postgres11_user_statesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/11_user_states.sql", size: 1889, mode: os.FileMode(420), modTime: time.Unix(1792192242, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres12_audit_eventsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\xdf\x6e\xda\x30\x14\xc6\xef\xfd\x14\xdf\x5d\x5b\x0d\xfa\x02\xbd\x82\xe1\x4d\xd9\x20\x20\x9a\x48\xed\x6e\xa2\x43\x7c\x20\xde\xc0\x46\x8e\x93\xae\x9a\xf6\xee\xd3\xc9\x92\xb1\x3f\xd0\xd5\x57\xb6\xce\xef\xfc\x6c\xe5\x3b\x19\x8f\xf1\xe6\x60\x77\x81\x22\x23\x3f\x2a\x35\x1e\x43\xb7\xec\x62\x0d\x0a\x0c\xc7\x2d\x07\x34\x47\x43\x91\x0d\x7c\x80\xe1\x3d\x47\x36\x23\x90\x33\xf0\x4d\xdc\xdb\x96\x11\x2b\x46\x53\x73\xa8\x65\xf7\xdc\x35\xd2\xc6\x37\xf1\x16\x9a\xca\x0a\x15\xd5\x15\x4a\xdf\xf6\x00\x58\xfc\x9d\x40\x4e\x52\x95\x5b\xfd\x56\xba\xe1\x1d\x63\xc3\x5b\x1f\x18\x36\x8e\x50\x7b\xc4\x8a\x22\xca\x8a\xdc\xce\xba\x9d\xbc\x21\xf0\xc1\xb7\xb2\x27\xd7\xbb\x36\x81\xe9\xcb\x4f\x79\x59\x91\x75\xd8\x06\x7f\x90\x63\x60\x78\x77\xab\xde\xae\xf5\x24\xd3\xc8\x26\xd3\xb9\x06\x35\xc6\xc6\xa2\x6b\xac\x71\xad\x00\x6b\x30\xac\x69\xf2\x3e\x49\x33\x74\x2b\x5d\x66\x48\xf3\xf9\x1c\xab\x75\xb2\x98\xac\x1f\xf1\x51\x3f\x8e\x14\x10\x9f\x8f\xdc\xe3\x99\x7e\xe8\xe1\x13\x2e\x08\x95\xd1\x87\xa2\xf3\xe6\x79\x32\xfb\x85\xf4\xe5\xba\xd9\x7c\xe6\x32\x0a\x70\xae\x5c\xee\x2d\xbb\xae\x7a\xf6\x02\xcc\xf4\xbb\x49\x3e\xcf\x70\x75\x25\xb4\x3d\x0e\xc0\x6b\x68\x89\xa9\xa0\x9d\x04\xf0\x0a\xfa\xc0\x91\x0c\x45\x02\xf0\xe1\x7e\x99\x4e\x2f\xd2\xdf\xbe\x77\x7c\x19\x58\x06\xa5\xa0\x88\x2c\x59\xe8\xfb\x6c\xb2\x58\x65\x9f\xfe\xe5\x9d\x7f\xba\xbe\x91\x06\x09\xff\x85\xc7\xab\x9b\x3b\x35\x84\x97\xa4\x33\xfd\xf0\x47\x78\xc5\xf0\x99\x0b\x6b\xbe\x2a\x60\x99\xfe\x95\xed\x50\x1f\xc1\x9a\x97\x4d\xa7\x44\x2e\xb9\x4e\xc4\xff\x6d\x32\x21\x97\x3c\x52\x1b\x0c\xbf\xff\x7b\x33\xff\xe4\x94\x9a\xad\x97\xab\x33\x63\x7a\xa7\x7e\x0c\x00\x63\xb0\x4c\x7d\xa7\x03\x00\x00")
+
+func postgres12_audit_eventsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres12_audit_eventsSql,
+		"postgres/12_audit_events.sql",
+	)
+}
+
+func postgres12_audit_eventsSql() (*asset, error) {
+	bytes, err := postgres12_audit_eventsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/12_audit_events.sql", size: 935, mode: os.FileMode(420), modTime: time.Unix(1792192357, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres13_sessionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x41\x6f\xda\x40\x10\x85\xef\xfb\x2b\x9e\x72\x01\x54\xc8\x1f\xc8\xc9\xc5\x43\x65\x15\x0c\x35\x6b\x29\xe9\xc5\x5a\xf0\x14\xaf\x70\x76\xd1\xce\x46\xb4\xff\xbe\x5a\x17\x42\x95\x2a\x6a\x7c\xb0\xac\xd9\xef\xcd\x78\xdf\x9b\xd9\x0c\x9f\x9e\xed\x21\x98\xc8\xa8\x4f\x4a\xcd\x66\xd0\x1d\x63\x17\xfc\x59\x38\x8c\x04\x7b\xef\x8f\x96\xd1\xf9\xbe\x15\xc4\x8e\x11\xfd\x91\xdd\x14\xc6\xb5\xf0\xae\xff\x05\x1b\x05\x9d\x91\x0e\x56\x70\xe4\x53\x44\xc7\x81\xa7\x10\x8f\xd8\x99\x08\x83\x9e\xcd\x91\x5b\x44\xb3\xeb\x19\x7b\xe3\x46\x11\x3b\xc6\x8b\xa4\x9a\x47\xef\x0f\x69\xa8\x75\xf7\xb0\xa7\xa1\xeb\x8b\x70\x68\xcc\x81\x5d\x84\x09\x8c\xd8\x79\x49\x6f\x86\xb0\x88\xf5\x0e\x67\x23\xe8\x8d\x44\x08\xb3\xc3\x8f\xe0\x9f\xef\xd5\xbc\xa2\x4c\x13\x74\xf6\x79\x49\x57\x50\x30\x56\x80\x6d\x71\x7b\xea\xba\xc8\xaf\xdf\xe5\x5a\xa3\xac\x97\x4b\x6c\xaa\x62\x95\x55\x4f\xf8\x4a\x4f\x53\x85\xf4\x67\xa1\xb1\xed\xfb\x82\x04\x2d\xd6\x15\x15\x5f\xca\xa4\xc1\xf8\xee\x22\xb9\x9b\xa0\xa2\x05\x55\x54\xce\x69\x3b\xf4\x11\x8c\x6d\x3b\xc1\xba\x44\x4e\x4b\xd2\x84\x79\xb6\x9d\x67\x39\xa5\x4a\xbd\xc9\xb3\x5b\x25\x35\x1d\xac\x6d\x06\x33\x01\x4d\x8f\xfa\xed\x64\xd4\x65\xf1\xad\x1e\x58\x7b\xba\x1e\xbe\xc7\xe6\xb4\xc8\xea\xa5\xc6\x68\xf4\x7a\xab\x3f\xae\x7e\x88\xdf\x07\x36\x91\xdb\xc6\x0c\x7c\xb1\xa2\xad\xce\x56\x1b\xfd\xfd\x5f\xde\xf9\xf3\x78\x92\x46\xa4\x4c\x9a\x94\x49\x12\xfd\x5f\xa2\x26\x0f\xea\x9a\x5b\x51\xe6\xf4\xf8\x9a\x5b\x73\xf1\xb3\xb1\xed\x4f\x85\xe4\xd5\x2d\xd1\xcb\x51\xd2\xfe\xbd\xbb\xb9\x3f\x3b\xa5\xf2\x6a\xbd\x79\xb3\x03\x0f\xea\xf7\x00\xcd\x0a\xeb\x8b\xe3\x02\x00\x00")
+
+func postgres13_sessionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres13_sessionsSql,
+		"postgres/13_sessions.sql",
+	)
+}
+
+func postgres13_sessionsSql() (*asset, error) {
+	bytes, err := postgres13_sessionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/13_sessions.sql", size: 739, mode: os.FileMode(420), modTime: time.Unix(1792192705, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres14_session_lifetimeSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xce\xc1\x4e\x03\x21\x18\x04\xe0\x3b\x4f\x31\x77\xa5\x2f\xd0\x13\x15\x7a\xfa\x5d\x4c\x0b\x0f\x40\xdd\xdf\x95\xb8\x80\xe1\x47\xcd\xbe\xbd\xd1\x68\x62\x4c\xaf\x93\xcc\x37\xa3\x35\x6e\x4a\x5e\x7a\x1a\x8c\xf8\xaa\x94\xd6\x38\xb3\x48\x6e\x55\x30\x9e\x19\x6f\xc2\x1d\x49\x5e\x78\xc6\x68\x90\x91\x36\x48\x5e\x2a\xcf\xc8\xf5\x2b\x59\x93\x0c\xac\xad\x2e\xdc\x6f\x91\x04\xc2\x03\x97\xed\xbb\x26\xef\x8f\xbb\x1f\x8b\xf2\x13\x8f\x5c\x78\xa7\x0c\x05\x77\x42\x30\x07\x72\x90\xdf\x21\x63\x2d\xee\x3c\xc5\xfb\x09\x9d\x0b\x97\x0b\x77\x1c\xbc\x27\x4c\x3e\x60\x8a\x44\xb0\xee\x68\x22\x05\x1c\x0d\x9d\xdd\x5e\xa9\xbf\xb7\x6d\xfb\xa8\xea\x3a\x6c\x4f\xfe\xe1\xbf\xbc\x57\x9f\x03\x00\x41\xf0\x7f\x17\xf4\x00\x00\x00")
+
+func postgres14_session_lifetimeSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres14_session_lifetimeSql,
+		"postgres/14_session_lifetime.sql",
+	)
+}
+
+func postgres14_session_lifetimeSql() (*asset, error) {
+	bytes, err := postgres14_session_lifetimeSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/14_session_lifetime.sql", size: 244, mode: os.FileMode(420), modTime: time.Unix(1792192937, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres15_session_clientsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x91\x4d\x6e\xa3\x30\x1c\xc5\xf7\x3e\xc5\x53\x56\xa0\x09\x73\x81\xac\x3c\xf0\xcf\x08\x95\x00\x25\xb6\xd4\x74\x13\x21\x70\xc1\x6a\x62\x57\xd8\x69\xae\x5f\xb9\x29\xa1\x8b\xb0\x42\xcf\xbf\xf7\x21\x3b\x49\xf0\xe7\xac\x87\xa9\xf5\x0a\xf2\x83\xb1\x24\x81\x18\x15\xba\x93\x56\xc6\x3b\xf8\xb1\xf5\xb8\xaa\x49\x61\xd0\x9f\xca\xc0\xdb\x77\x65\x1c\xfa\xcb\xa4\xcd\x00\xd5\x76\x23\x9c\x72\x4e\x5b\xb3\x86\xb3\x37\xfc\x64\x87\x21\x9c\xda\x8b\x87\x7d\x83\x1f\xd5\xcc\xa0\x6b\x0d\x4e\x76\xf8\xd6\x2e\x4e\x4d\x3f\x4c\x68\xf5\xa3\x3a\xc3\x5b\xfb\x97\xa5\x0d\x71\x41\x10\xfc\x5f\x41\xb3\xf3\x38\x0f\x8a\x18\xa0\x7b\xcc\x9f\x94\x79\x36\xff\x97\x95\x40\x29\x8b\x02\x75\x93\xef\x78\x73\xc0\x13\x1d\xd6\x0c\xf7\x08\xdd\x3f\xc4\x03\xb2\xad\x1a\xca\xff\x97\xc1\x81\x68\xb5\x18\x56\x31\x1a\xda\x52\x43\x65\x4a\xfb\x39\xc8\x21\xd2\x7d\x8c\xaa\x44\x46\x05\x09\x42\xca\xf7\x29\xcf\x28\x28\xb2\xce\xf8\xa2\x84\xe8\xdb\xf0\x63\xd8\x2c\xe8\x45\x3c\x6a\xef\x26\xd5\x7a\xd5\x1f\x5b\x0f\x91\xef\x68\x2f\xf8\xae\x16\xaf\x77\x04\x19\x6d\xb9\x2c\x04\x8c\xbd\x46\x71\x30\xc8\x32\x7f\x96\x84\x68\x19\xba\x5e\x7a\x62\x16\x6f\x18\xfb\xfd\xae\x99\xbd\x1a\xc6\xb2\xa6\xaa\x1f\x5f\xea\x86\x7d\x0d\x00\x7c\x56\x4c\xb0\x06\x02\x00\x00")
+
+func postgres15_session_clientsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres15_session_clientsSql,
+		"postgres/15_session_clients.sql",
+	)
+}
+
+func postgres15_session_clientsSql() (*asset, error) {
+	bytes, err := postgres15_session_clientsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/15_session_clients.sql", size: 518, mode: os.FileMode(420), modTime: time.Unix(1792193211, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres16_consent_grantsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x52\x4d\x8f\x9b\x30\x10\xbd\xfb\x57\x3c\xed\x25\xa0\x86\x3d\xf4\xba\x27\x37\x4c\x2a\x54\x02\x94\xd8\x52\xb7\x97\xc8\x82\x51\xb0\xda\x1a\x8a\x8d\x56\xfd\xf7\x95\xd9\xa0\xad\xd4\xf4\x50\x9f\xac\xa7\xf7\xe1\x79\x9e\x2c\xc3\xbb\x1f\xf6\x3a\x9b\xc0\xd0\x93\x10\x59\x06\x35\x30\x7c\x37\x4e\xec\xc1\xa6\x1b\xb0\x78\x9e\x31\x18\x8f\xeb\x6c\x5c\xe0\xfe\x15\xed\xbe\x5b\x76\x61\x0f\x3f\x22\x0c\x26\x20\x0c\xfc\x0b\x66\x66\xb7\x0b\x30\xfe\x1b\xf7\x08\x23\xba\xd1\x79\x76\x01\xe6\x6a\xac\x7b\xdc\x5c\xad\x87\x9f\x4c\xc7\x99\xe7\xc9\xc4\xe4\x7e\x1f\x73\x8d\x87\x75\xa8\xe5\x12\x86\xf7\x98\xf9\xe7\xc2\x3e\xf8\x47\x71\x68\x49\x2a\x82\x92\x1f\x4a\xda\x0c\x2f\xeb\x53\x3c\x12\x01\xd8\x1e\xdb\xd1\xba\xc8\xb7\x7b\x55\x2b\x54\xba\x2c\xd1\xb4\xc5\x49\xb6\xcf\xf8\x44\xcf\x7b\x81\x75\x9a\x8b\xed\xff\x45\x8f\x94\x63\xdd\x52\xf1\xb1\x8a\x0a\x24\x0f\x37\xc1\x43\x8a\x96\x8e\xd4\x52\x75\xa0\xf3\xea\xe2\x91\xd8\x3e\x45\x5d\x21\xa7\x92\x14\xe1\x20\xcf\x07\x99\x53\x44\x74\x93\xcb\x37\x24\x9a\xbe\xf6\xb5\x26\x2b\xfa\xa2\xee\xe5\xde\xea\x01\xee\x53\x90\xd3\x51\xea\x52\x61\xb7\x8b\xec\x6e\xe6\x58\xdd\xc5\x04\xa8\xe2\x44\x67\x25\x4f\x8d\xfa\xfa\x37\xdb\x8d\x2f\x49\x1a\x05\xcb\xd4\xff\x9f\x40\x57\xc5\x67\x4d\x48\x6e\x0d\xec\xdf\x66\x48\x45\xfa\x24\xc4\x9f\xbb\x93\x8f\x2f\x4e\x88\xbc\xad\x9b\xbb\x3f\xf5\x24\x7e\x0f\x00\xa7\x54\x08\xd9\x69\x02\x00\x00")
+
+func postgres16_consent_grantsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres16_consent_grantsSql,
+		"postgres/16_consent_grants.sql",
+	)
+}
+
+func postgres16_consent_grantsSql() (*asset, error) {
+	bytes, err := postgres16_consent_grantsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/16_consent_grants.sql", size: 617, mode: os.FileMode(420), modTime: time.Unix(1792193412, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres17_audit_chain_headSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x92\x41\x6f\xdb\x20\x18\x86\xef\xfc\x8a\xf7\x56\x5b\x73\xa2\xed\x9c\x93\x63\x7f\xdd\xac\x3a\x76\x45\x88\xb6\x9c\x2a\x14\x7f\x0b\xa8\x2e\x4c\x40\x97\xf6\xdf\x4f\xa4\x59\x33\x69\x1b\x47\x5e\xde\xe7\x7b\x04\x2c\x16\xf8\xf0\x64\x8f\x41\x27\xc6\xee\x87\x10\x8b\x05\x94\x61\xcc\x3a\x26\xf0\x4f\x76\x09\xd6\x21\x19\x86\x7e\x9e\x6c\xc2\xec\x8f\x4b\x7c\x0d\x36\x71\x88\x98\xfd\xe1\x11\xc9\xd8\x88\xe0\x4f\x15\x82\x4e\x86\x03\x92\xd1\x0e\x7a\x9e\xe1\xbf\xbf\x95\x1e\xce\x9c\x58\x21\x79\x24\xfd\xc8\x48\xcf\xc1\x45\xf0\x4b\x62\x37\x59\x77\xcc\xf8\x3c\xf7\x60\xb4\x75\x15\xa2\xcf\x88\x84\xc0\xfa\x77\x9a\xc7\xe2\xa0\x43\xb0\x1c\xe1\x1d\x4e\xc6\xce\x9c\x83\x57\x4c\x7e\x99\x85\x03\xc3\xe6\x68\x7e\xcd\xd6\x59\x82\xe1\x1d\x67\xb1\xa5\x68\x24\xd5\x8a\xa0\xea\x75\x4f\x17\xa5\xf3\xac\x07\xc3\x7a\x42\x21\x80\x68\xdd\x71\xe6\xe4\x1d\xd6\xe3\xd8\x53\x3d\x60\x18\x15\x86\x5d\xdf\xe3\x5e\x76\x9b\x5a\xee\x71\x47\x7b\xb4\x74\x5b\xef\x7a\x05\x25\x77\x84\xe6\x0b\x35\x77\x28\xde\xab\x65\x25\x00\x3b\xe1\xb2\xd6\xdd\xe7\x6e\x50\x78\x07\xe5\xd4\xe8\x68\xce\x21\x14\x7d\x53\xc0\x35\x15\xe5\x4a\x88\x6e\xd8\x92\x54\xe8\x06\x35\xfe\x43\xd3\x4e\xd5\x19\x50\x8a\x2d\xf5\xd4\x28\x34\x63\xdd\xd3\xb6\xa1\xe2\x49\xbf\x14\x76\x2a\x2b\x7c\x2c\xab\xeb\x6e\x71\x39\x96\x3b\xb8\x95\xe3\xe6\xc2\x7c\x7b\x0d\x8c\xb2\x25\x89\xf5\x3e\x2b\xb7\xb4\x6d\xd0\x77\x9b\x4e\xe1\x53\x59\xe1\xe6\xa6\x14\x7f\x15\x56\x42\xfc\xf9\x57\x5a\x7f\x72\x42\xb4\x72\xbc\xff\xcf\xbd\xae\xc4\xaf\x01\x00\xd7\x82\x8d\xcc\x5b\x02\x00\x00")
+
+func postgres17_audit_chain_headSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres17_audit_chain_headSql,
+		"postgres/17_audit_chain_head.sql",
+	)
+}
+
+func postgres17_audit_chain_headSql() (*asset, error) {
+	bytes, err := postgres17_audit_chain_headSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/17_audit_chain_head.sql", size: 603, mode: os.FileMode(420), modTime: time.Unix(1792194408, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _postgres1_initSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x91\x4f\x4f\x02\x31\x10\xc5\xef\xfd\x14\x2f\x9c\x76\xa3\x24\x46\x8f\x9c\x4a\x3b\xe0\xc6\xda\x62\x69\x8d\x9c\x48\xc3\x36\xa6\x09\xff\xb2\xbb\xca\xd7\x37\x5d\x24\xa2\x06\xe6\xd6\xe9\xbc\xce\xfb\xbd\x0e\x87\xb8\xd9\xa4\xf7\x26\x74\x11\x7e\xcf\x98\xb0\xc4\x1d\xc1\xf1\xb1\x22\x7c\xb4\xb1\x69\x51\x30\x20\xd5\xc8\xe5\x7d\x25\xf1\xbf\xb4\x71\xd0\x5e\x29\xcc\x6c\xf5\xcc\xed\x02\x4f\xb4\xb8\x65\xc0\x36\x6c\x22\x00\x47\x6f\xee\x8a\x2a\x4f\xc6\x4d\x48\x6b\x40\x3c\x72\xcb\x85\x23\x8b\x57\x6e\x17\x95\x9e\x16\x0f\xf7\x77\xe5\xaf\xc9\xb0\xea\xd2\x67\xc4\xd8\x18\x75\xcd\x89\xa4\x09\xf7\xca\xc1\x59\x4f\x59\xe6\x75\xf5\xe2\x09\x45\xbf\xa8\x64\xe5\xe8\x0f\xea\x7a\xb7\x0a\xeb\x65\xaa\xe3\xb6\x4b\x5d\x8a\x47\xea\xcc\xbf\xcc\xe8\x3d\xf7\x25\xca\x89\xb1\x54\x4d\x75\x3e\xa2\x18\x7c\x6b\x06\x25\x2c\x4d\xc8\x92\x16\x34\x3f\x05\x99\xea\x12\x46\x43\x92\x22\x47\x10\x7c\x2e\xb8\xa4\xdc\xf1\x33\xc9\x7f\x3a\xf9\xd1\x7d\x68\xdb\xc3\xae\xa9\x8f\xe1\x9d\x56\xf7\xbe\xcf\xbf\x4c\xee\x0e\x5b\xc6\xa4\x35\xb3\x0b\x1c\xa3\xf3\xcb\xde\xc6\xe8\x2b\x00\x00\xff\xff\xba\xb3\xab\xb4\xf3\x01\x00\x00")
 
 func postgres1_initSqlBytes() ([]byte, error) {
@@ -92,7 +274,167 @@ func postgres1_initSql() (*asset, error) {
 	return a, nil
 }
 
-var _tmplConsentHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xac\x56\xdd\x6e\xdb\x38\x13\xbd\xcf\x53\xcc\xa7\xa0\x40\x52\xd8\x92\xec\x38\x6d\xa0\xc8\xee\x17\xa4\x1b\x60\xaf\x1a\xb4\xd9\x8b\xbd\xa4\xa8\x91\x44\x84\xe2\x68\x49\xca\x3f\x31\xfc\xee\x0b\x4a\x72\x62\xcb\x76\xbb\xdd\xad\x9c\x44\x31\x67\x74\xc8\x39\x67\x66\x34\xf1\xff\x3e\x7f\xb9\x7f\xfa\xf3\xf1\x37\x28\x6c\x29\x67\x67\xb1\xbb\x81\x64\x2a\x9f\x7a\xa8\x3c\xb7\x80\x2c\x9d\x9d\x01\x00\xc4\x25\x5a\x06\xbc\x60\xda\xa0\x9d\x7a\x7f\x3c\x3d\x0c\x6f\xbc\xce\x64\x85\x95\x38\x7b\x94\xc8\x0c\x42\x2e\xe6\x08\x2b\xaa\x35\x70\x52\x06\x95\x8d\x83\xd6\xde\xfa\x1a\xbb\xda\xfe\xef\xae\xff\x8b\xb2\x22\x6d\xa1\xd6\xf2\xa2\xb0\xb6\x32\x51\x10\x64\xa4\xac\xf1\x73\xa2\x5c\x22\xab\x84\xf1\x39\x95\x01\x37\xe6\x53\xc6\x4a\x21\x57\xd3\xaf\x94\x90\xa5\xe8\x2a\x0c\x2f\x6f\xcf\x5e\x91\x12\x4a\x57\xb0\x7e\xfd\xda\x2c\x31\xfe\x9c\x6b\xaa\x55\x1a\xc1\xf9\xc7\x0f\xc9\xcd\xf5\xf8\x16\x82\xf7\x90\x31\x29\x9d\x0d\x32\xd2\x40\x32\x85\x44\xd3\xc2\xa0\x36\xf0\x3e\x38\x09\x30\x5c\x60\xf2\x2c\xec\x50\x0a\x85\x4c\x0f\x73\xcd\x52\x81\xca\x5e\x68\x91\x17\x76\xb0\xc5\x1f\xc0\xf9\xcd\xe7\xfb\xf1\x87\x87\xcb\xdb\xd3\x48\x25\xbd\xfc\x0a\x18\xfa\x05\x20\x7d\x04\x4b\x20\x31\xfb\x31\x86\xd3\x68\xd8\xea\x11\x81\xd7\x2a\xe2\x0d\xc0\x30\x65\x86\x06\xb5\xc8\xf6\xdd\x69\x8e\x3a\x93\xb4\x88\xa0\x10\x69\x8a\x6a\xdf\xba\xa5\xb6\x01\x35\x25\x91\x2d\x84\xca\x23\x60\xca\x0a\x26\x05\x33\x98\xf6\x1e\x70\x0c\x92\x59\x1e\x3c\x91\x6b\xb6\x32\x9c\x49\x7c\xf3\xdf\xbc\xa5\x88\xbf\xd0\xac\xaa\x50\xf7\xd2\x64\x21\x52\x5b\x44\x70\xf5\x21\xac\x96\xfb\xfb\x54\x2c\x4d\x1b\xdc\x9b\x77\x10\x42\xb8\x6f\x2c\x99\xce\x85\x8a\x80\xd5\x96\x8e\x6f\x57\x31\x85\xb2\xb7\x59\x45\x46\x58\x41\x2a\x02\x8d\x92\x59\x31\xc7\x7d\xd4\x97\xa1\x50\x29\x2e\x23\x18\x9d\x16\xed\xfc\xa1\xb9\xfa\xc7\x59\x0e\x4f\x47\xb2\x3d\x6c\xd8\x1c\x17\x46\xe1\xe9\x58\x27\xd7\x7d\x53\x42\xcb\xa1\x29\x58\xea\xf4\x0b\x21\x84\x71\x58\x2d\x21\x04\x9d\x27\xec\x22\x1c\x40\xf7\xe3\x8f\x2f\x07\x10\xc2\x75\xb5\x6c\x7e\x8f\xd8\x27\x97\x47\x79\x62\x3d\x8a\x38\x49\xd2\x11\x9c\x4f\xee\xef\x1e\xae\x7b\xa4\x5b\x5c\xda\x61\x8a\x9c\x34\x6b\x59\x54\xa4\x8e\x8b\x9d\x91\x2e\x41\xa8\xaa\xb6\x3d\xf8\x9f\x4d\xdd\xda\xba\x22\x89\xfa\xf2\xef\x09\x92\x8d\xdd\xe7\xf6\x58\x5a\x8d\xc2\xf0\x5d\x9f\x4e\x9d\xa2\x3e\x00\x7c\x93\x28\x84\xd1\x81\x06\xaf\xf2\x1c\x9a\x1a\x79\xc4\x4b\x63\x6d\xb1\x87\x09\x2d\x8f\xd4\xab\x11\x2f\x18\xc1\x68\xb2\x0b\xd0\x67\x2c\xa9\xad\x25\xf5\xdf\x28\x6b\x44\xb2\x9a\x29\xe3\x20\x23\xa8\x5d\xd1\x71\x66\xf0\x5f\x30\x7b\x2c\x09\x7e\x96\xd9\xef\x70\xb7\xcd\xb5\x63\x15\x75\x92\x33\xd8\xe9\x59\x4d\x98\x5d\x45\x33\x29\x21\xf4\xaf\x00\x0f\x42\xfd\x67\x5e\xbc\xd6\xc6\x9d\xa6\x22\xa1\x2c\xea\x1f\x89\x14\x15\xae\xad\x0e\xc0\xdf\x5d\x63\xdc\xf5\x94\xde\x62\x46\xbc\x36\xdf\x7b\x39\x4e\xae\xee\xc2\xc9\xc7\xd3\x1b\xfa\x25\x1a\xc3\x72\xec\x61\x6c\x53\x76\xd4\x16\x7c\x78\x9c\xdb\xe4\xca\x7d\x4e\x73\x3b\xde\xcf\x47\xf7\x37\x0e\xba\x39\x21\x0e\xda\x11\x24\x76\xaf\xf7\xd9\x59\x9c\x8a\x39\x70\xc9\x8c\x99\x7a\x5d\x33\xdf\x0e\x21\x3b\x96\xa6\xef\x7a\x6f\x43\x46\xdc\xc4\xe0\x98\x21\x35\xf5\x82\x6e\x2e\xf9\xc4\x0b\x26\x25\xaa\x1c\xa7\xeb\xb5\x7f\xbf\xfd\xb2\xd9\x78\x50\xa2\x2d\x28\x9d\x7a\x8f\x5f\xbe\x3d\xed\xe0\x34\x58\xc5\x08\x98\x14\xb9\x9a\x7a\xee\x45\xe9\xcd\xee\xb7\x53\x4e\x31\xea\x79\x56\xfb\x8e\x7b\x46\x77\xdd\x29\x60\x55\x25\x05\x6f\x5a\x19\x5c\x88\x34\x82\xf5\xda\xbf\xab\xdd\xab\x98\xe3\x66\x73\x09\x1a\xff\xaa\xd1\x58\x4c\xb7\xc3\x14\x58\x02\xc6\x39\x1a\x03\x1a\x0d\xd5\x9a\xa3\x01\x52\xed\xc0\x95\x60\xc1\x64\xe6\xc3\x53\x81\xbb\xc8\x07\x1b\x2f\x98\xb2\xe6\x60\xb5\x83\xb5\x14\xed\x87\x11\x54\xbd\xb8\x6a\x79\x18\xcb\x7a\xad\x99\xca\x11\xfc\xaf\xdb\x13\x7f\xe3\x54\xa1\xd9\x6c\x0e\x5c\x63\x29\x66\x71\xdb\x98\xed\xaa\xc2\xa9\xc7\x0b\xe4\xcf\x09\x2d\x3d\x50\xac\xc4\xa9\xb7\x5e\xfb\x9b\x8d\x37\x6b\x6e\x71\x20\xc5\xb1\xdd\x50\xa5\x3d\xe8\x38\xe8\x9f\x6b\xbd\xf6\xb9\xd1\xd9\x83\x40\x79\xe0\xdc\xb5\xb9\xf6\x00\xa6\x4e\x4a\x61\xbd\xd9\x5d\x55\x69\x9a\x63\x1c\xb4\xd6\x03\x39\xbb\xf4\xea\x6a\xc1\x9b\xfd\x9e\x39\xde\x21\x25\x50\x64\x41\x23\xa7\x5c\x89\x17\x04\x5b\x08\xb3\x2b\xc0\xa0\x71\xe3\x4c\x81\x61\x19\xca\x15\xe0\x52\xd8\xd6\xab\x62\x39\xfa\x7b\x14\xc7\x81\xcb\xd6\x2e\xa9\x83\x54\xcc\x5d\x11\x74\xb7\xae\x08\x82\x66\x5c\xff\x3b\x00\x00\xff\xff\xb5\x4c\x66\xb6\xbe\x0b\x00\x00")
+var _postgres2_email_verificationSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x52\x4d\x8f\x9b\x30\x10\xbd\xfb\x57\x3c\xe5\x04\x6a\x23\x55\xed\x31\x27\x07\x0f\x09\xaa\xb1\x91\x31\x6d\xd2\x0b\x42\xc5\x6d\xac\x36\x1f\x02\x76\x37\x3f\x7f\x65\xc4\x26\xd1\x8a\xf5\xcd\xa3\xf7\xde\xbc\x79\x33\xcb\x25\x3e\x1d\xfd\xdf\xae\x19\x1c\xaa\x0b\x63\x5c\x5a\x32\xb0\x7c\x2d\x09\x4f\xbd\xeb\x7a\x06\x70\x21\x90\x68\x59\xe5\x0a\xee\xd8\xf8\xff\xf5\xb3\xeb\xfc\x1f\xef\x5a\xac\xb5\x96\x50\xda\x42\x55\x52\x42\x50\xca\x2b\x69\x91\x72\x59\xd2\x8a\xb1\xc4\x10\xb7\x34\x89\x3d\x32\x7f\x37\x83\x3f\x9f\x7a\x44\x0c\x18\xce\xff\xdc\xa9\x3e\x34\xfd\x01\x96\x76\x16\x73\xef\xd6\xa1\x30\x59\xce\xcd\x1e\xdf\x69\xff\x99\x61\x34\x58\xfb\x36\x40\xaa\x2a\x13\x6f\xf0\x59\x6e\xc0\xa7\xda\x50\xb6\x51\x81\x8e\x68\x31\xb1\x17\x31\x0c\xa5\x64\x48\x25\x54\x8e\x92\x3d\x22\xdf\xc6\xd0\x0a\x82\x24\x59\x42\xc2\xcb\x84\x0b\x0a\x95\xaa\x10\xfc\x5e\x09\xa2\xe3\x60\x63\x2b\x24\x5b\x6e\x78\x12\x02\xfc\xc1\xcd\x3e\x53\x9b\xe8\xdb\xd7\x2f\xf1\x3b\x13\xee\x7a\xf1\x9d\xeb\xeb\x66\x80\xcd\x72\x2a\x2d\xcf\x0b\xfc\xcc\xec\x76\xfc\xe2\x97\x56\x74\xc3\xb3\xf8\x1e\x63\xa6\x04\xed\xe6\x62\xac\xa7\x41\x6a\xdf\x5e\x19\x82\xc9\xd9\xac\x27\x54\x50\x7c\x5c\xba\x38\xbf\x9c\x18\x13\x46\x17\x1f\x2f\x6a\x35\x7b\x16\x23\x67\xf6\x2e\x56\xec\x75\x00\xc9\x8f\x2f\x8d\x57\x02\x00\x00")
+
+func postgres2_email_verificationSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres2_email_verificationSql,
+		"postgres/2_email_verification.sql",
+	)
+}
+
+func postgres2_email_verificationSql() (*asset, error) {
+	bytes, err := postgres2_email_verificationSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/2_email_verification.sql", size: 599, mode: os.FileMode(420), modTime: time.Unix(1792189238, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres3_login_attemptsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xcf\xcd\x4e\x84\x40\x10\x04\xe0\x7b\x3f\x45\x1d\x35\xca\x13\x70\x1a\xa5\xa3\x13\x61\x20\x63\x13\xc5\x0b\x99\xc3\x48\x88\xfc\x05\xc6\x18\xdf\x7e\xb3\x64\x21\xbb\xd9\xad\x53\x77\x52\xdf\xa1\xa2\x08\x0f\x7d\xdb\xcc\x2e\x78\x94\x13\xd1\xb3\x65\x25\x0c\x51\x4f\x29\xa3\x1b\x9b\x76\xa8\x5d\x08\xbe\x9f\xc2\x82\x3b\x02\x7e\xfc\x3f\xf6\x08\x7f\xca\x76\x5f\xc4\xe4\x02\x53\xa6\x29\x0a\xab\x33\x65\x2b\xbc\x71\xf5\x48\xc0\xb7\x6b\xbb\xdf\xd9\x2f\x6b\x49\x1b\xe1\x17\xb6\x9b\xb9\xd6\x47\xd1\xb9\x25\xd4\x27\x06\xd1\x19\xbf\x8b\xca\x0a\x7c\x68\x79\x5d\x5f\x7c\xe5\x86\x77\x41\xf7\x31\xd1\xf9\xa4\x64\xfc\x1b\x88\x12\x9b\x17\x37\x27\xc5\x74\x18\x00\xb5\xa1\x9d\x50\x00\x01\x00\x00")
+
+func postgres3_login_attemptsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres3_login_attemptsSql,
+		"postgres/3_login_attempts.sql",
+	)
+}
+
+func postgres3_login_attemptsSql() (*asset, error) {
+	bytes, err := postgres3_login_attemptsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/3_login_attempts.sql", size: 256, mode: os.FileMode(420), modTime: time.Unix(1792189469, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres4_two_factorSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x92\xcd\x6e\xc2\x30\x10\x84\xef\x7e\x8a\x11\x27\x50\x8b\xd4\x3b\x27\x13\x6f\x50\x54\xd7\x41\xc6\x91\xe0\x64\xa5\x89\x55\x22\x51\x82\x6c\xf7\xef\xed\x2b\x17\xaa\xa6\x11\x3d\xf6\x66\xef\x8e\x77\xc6\x9f\x76\x3e\xc7\xcd\x73\xf7\xe4\xeb\xe8\x50\x9d\x18\xcb\x34\x71\x43\x30\x7c\x29\x09\xb1\x8f\x27\xdb\x78\xd7\xba\x63\xec\xea\x43\xc0\x94\x01\x2f\xc1\x79\xdb\xb5\x00\xaa\xaa\x10\x00\xa0\x4a\x03\x55\x49\x89\xb5\x2e\x1e\xb8\xde\xe1\x9e\x76\xb7\x0c\xc8\x4b\x4d\xc5\x4a\xa5\x2b\xa6\x93\xcb\xbb\xc9\x0c\x9a\x72\xd2\xa4\x32\xda\x7c\x0d\x0b\x98\x76\xed\x0c\xa5\x82\x20\x49\x86\x90\xf1\x4d\xc6\x05\xa5\x4a\xb5\x16\xfc\xa7\x92\x86\x06\xd7\x78\x17\x93\xad\xa1\xad\x19\xda\xa7\xae\x3b\xd6\x8f\x07\x97\xc2\x2d\xcb\x52\xfe\x0a\x27\x28\xe7\x95\x34\xc8\xb9\xdc\x50\xd2\x1e\xea\x10\x6d\x88\xee\x84\x65\xb1\x2a\x94\xb9\xa2\xbd\x63\xb3\xc5\x88\x89\x77\x4d\xff\xea\xfc\x87\x6d\xfa\xd6\x9d\x89\xa4\x93\xdd\xd7\x61\x7f\x8e\xf4\x17\x8e\x11\xb8\x6f\xd9\xbf\x90\x1a\xe6\x2e\x94\xa0\xed\x28\xb7\xbd\x78\xd8\xae\x7d\x67\x48\xa4\xc7\xff\xba\x08\xd2\x9c\xe1\x8e\x88\xfe\xed\xc8\x98\xd0\xe5\xfa\x2a\x8f\xc5\xb0\x35\x5e\x9f\x05\xfb\x1c\x00\xc0\xfb\xc0\xf6\x6e\x02\x00\x00")
+
+func postgres4_two_factorSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres4_two_factorSql,
+		"postgres/4_two_factor.sql",
+	)
+}
+
+func postgres4_two_factorSql() (*asset, error) {
+	bytes, err := postgres4_two_factorSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/4_two_factor.sql", size: 622, mode: os.FileMode(420), modTime: time.Unix(1792189846, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres5_webauthnSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x53\xc1\x6e\x9b\x40\x14\xbc\xef\x57\x8c\x72\xb2\xd5\x3a\xea\xdd\x27\x6c\x9e\x23\x54\x8c\x2d\xbc\x48\x71\x2f\x68\x6d\x5e\x97\x55\xc9\xae\xb5\xac\xeb\xe4\xef\xab\x4d\x88\xad\xb6\xa4\xb7\x72\x82\x7d\x33\x03\x6f\x66\x98\xcd\xf0\xe9\xc9\x68\xaf\x02\xa3\x3a\x09\xb1\x2c\x29\x91\x04\x99\x2c\x72\xc2\x85\x0f\xea\x1c\x5a\x5b\x1f\x3d\x37\x6c\x83\x51\x5d\x8f\x89\x00\x4c\x83\xf7\x6b\xb1\x97\x94\x0c\xf7\xc5\x46\xa2\xa8\xf2\x1c\xdb\x32\x5b\x27\xe5\x1e\x5f\x69\xff\x59\x00\xe7\x9e\x7d\xfd\xc6\xa9\xaa\x2c\x1d\xd0\x57\x78\x84\xac\x36\x25\x65\x0f\x45\x64\x60\x72\x37\x10\xee\xa6\x28\x69\x45\x25\x15\x4b\xda\xbd\xaa\xf4\x98\x98\x66\x8a\x4d\x81\x94\x72\x92\x84\x65\xb2\x5b\x26\x29\xc5\x93\x6a\x9b\x26\xb7\x93\x28\x7a\x3a\x1f\x3a\x73\xac\x7f\xf0\xcb\xe8\x67\x46\x48\x6f\xb4\xad\x8f\xee\x6c\x03\x16\xd9\x43\x56\xc8\xdf\x21\x48\x69\x95\x54\xb9\xc4\x97\x08\xb6\xea\x89\x07\x0d\x49\x8f\x12\x23\x7a\x47\xcf\x2a\x70\x53\xab\x00\x99\xad\x69\x27\x93\xf5\x56\x7e\xfb\x5b\xcf\xba\xcb\x64\x2a\xa6\xf3\xab\xe3\x59\x91\xd2\xe3\xa8\xe3\xf5\xe0\x46\x6d\x9a\x67\x81\xb8\xe9\x78\x2e\x03\x2c\x6a\xce\x66\x58\xb6\xaa\xeb\xd8\x6a\xee\xf1\xdd\x79\x78\xd6\xa6\x0f\x5e\x05\xe3\x2c\x0e\xdc\x39\xab\x11\x1c\x42\xcb\xaf\xbe\x0e\x73\xf6\xc6\xea\xfb\x3f\xb9\x9d\xd3\xda\x58\x0d\x63\xd1\xaa\x9f\x0c\xeb\xde\x38\x2f\x1c\xee\x3f\x2a\xcc\x4d\x21\xf6\xe5\xfa\x58\xb7\xaa\x6f\x47\xcd\xfb\x47\x67\xde\x7b\xf3\xdf\x7a\xc2\xcf\x27\xe3\xb9\x8f\xa1\xc5\x77\x8d\x05\x27\x06\x5b\xaf\x3f\x4b\xea\x2e\x56\x88\xb4\xdc\x6c\x3f\xde\x7d\x3e\x3e\xbf\x85\x36\x17\xbf\x06\x00\x3f\x58\x5a\x5d\x80\x03\x00\x00")
+
+func postgres5_webauthnSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres5_webauthnSql,
+		"postgres/5_webauthn.sql",
+	)
+}
+
+func postgres5_webauthnSql() (*asset, error) {
+	bytes, err := postgres5_webauthnSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/5_webauthn.sql", size: 896, mode: os.FileMode(420), modTime: time.Unix(1792190051, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres6_federated_identitiesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x41\x8f\x9b\x30\x10\x85\xef\xfe\x15\x4f\xb9\x04\xd4\xd0\x3f\x90\x93\x8b\x27\x15\x2a\x01\x4a\x6c\x29\xe9\x05\x51\x70\x5a\xb7\x01\x22\x70\x9a\xfe\xfc\x95\xb3\xc0\x1e\x36\x1b\x85\xe3\xe3\xcd\xf8\xcd\x37\x13\x04\xf8\xd4\x98\x5f\x7d\x69\x35\xd4\x99\xb1\x30\x27\x2e\x09\x92\x7f\x89\x09\x47\x5d\x6b\xf7\xa7\x2e\x4c\xad\x5b\x6b\xac\xd1\x03\x3c\x06\x9c\xfb\xee\x9f\xa9\x75\x0f\x40\xd2\x5e\x62\xfc\x92\x54\x22\x51\x71\xbc\x62\xc0\x70\xf9\xf9\x47\x57\x16\x0f\x2c\x97\x41\xf7\x85\xa9\x9d\xac\x54\x24\xee\x59\x36\x69\x4e\xd1\xd7\x04\xdf\xe8\x00\x6f\x31\x16\x2c\x7c\xe4\xb4\xa1\x9c\x92\x90\x76\x70\xe2\x00\xcf\xd4\x3e\xd2\x04\x82\x62\x92\x84\x90\xef\x42\x2e\xc8\x29\x2a\x13\xfc\x4d\x71\x4d\x75\x53\x9a\x13\xf0\x51\x34\x08\xda\x70\x15\x4b\x2c\x97\xce\x5d\xf5\xfa\x86\xa0\xb4\x90\xd1\x96\x76\x92\x6f\x33\xf9\xe3\xbd\xbb\xed\xae\x9e\xef\x0a\xb2\x3c\xda\xf2\xfc\xf0\x9a\x79\x22\xb5\x9a\x80\xf8\xcc\x5f\xcf\x98\xa3\x44\xd0\xfe\x2e\xe6\x62\x9c\xb5\x30\xf5\x7f\x06\x37\xc7\xfd\x65\x8c\x36\xd7\x33\x08\xc0\x6f\x34\x50\x95\x2d\x4e\xa6\xfd\x8b\xd2\xa2\xe9\x06\x8b\xae\xd5\x28\xab\xaa\xbb\xb4\x16\xc7\xbe\x6b\xa0\xcb\xea\xf7\xbc\xc5\xcf\x53\x1c\x95\x44\xdf\xd5\x53\xa9\xa6\xd2\x27\xe3\xad\xe6\xb7\xc6\xa0\xf3\xcd\x89\xee\xda\x32\x26\xf2\x34\x7b\x70\x73\x6b\xf6\x32\x00\x9c\x19\xc3\x31\xa7\x02\x00\x00")
+
+func postgres6_federated_identitiesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres6_federated_identitiesSql,
+		"postgres/6_federated_identities.sql",
+	)
+}
+
+func postgres6_federated_identitiesSql() (*asset, error) {
+	bytes, err := postgres6_federated_identitiesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/6_federated_identities.sql", size: 679, mode: os.FileMode(420), modTime: time.Unix(1792190295, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres7_profileSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\xc1\x6a\xeb\x30\x10\x45\xf7\xfa\x8a\xbb\xcb\xe2\xd9\xef\x07\xb2\x72\x23\x17\x0a\xaa\x5d\x82\x0c\xdd\x15\x61\x8f\x63\x81\x35\x32\x92\x9c\xd0\x7c\x7d\x09\xa6\x90\x3a\xa5\x24\xdb\x7b\x75\x34\xcc\x99\x3c\xc7\x3f\x67\x0f\xc1\x24\x42\x33\x09\x91\xe7\xa8\x8c\xa3\x0e\xa6\x4f\x14\x90\x06\x42\x3d\x11\xbf\x48\xec\x3c\x33\xb5\x09\x31\x19\xee\x4c\xe8\xd0\x8e\xc6\xba\x98\xe1\x34\xd8\x76\xb8\xbc\x8c\x04\x13\x08\x91\xc2\xf1\xf2\x41\xfc\x2f\x0a\xa5\xcb\x3d\x74\xf1\xa4\x4a\xcc\x91\x42\x14\x40\x21\x25\x76\xb5\x6a\x5e\x2b\x1c\xec\x91\xf8\x83\x8d\x23\x40\x97\xef\x1a\x55\xad\x51\x35\x4a\x41\x96\xcf\x45\xa3\x34\x36\x9b\xec\x27\xd2\x1b\x67\xc7\xcf\x85\xb9\x13\x99\x82\x67\x3f\x73\x04\xee\x9e\x32\xd9\x36\xcd\x81\xf0\x00\x32\xfa\xd6\x8c\x84\x47\x90\xb3\x67\xb2\xdc\xfb\xbf\x90\xad\x10\xd7\x27\x92\xfe\xc4\xe2\x57\xad\x72\x5f\xbf\xdd\x7a\xcd\x56\xcd\x95\xbe\x75\xf5\xad\xe9\x26\x5f\x5c\xac\xe3\x65\xdf\x75\x7a\xf6\x4c\x96\x7b\xbf\x15\x5f\x03\x00\x72\x81\xfb\xe0\x59\x02\x00\x00")
+
+func postgres7_profileSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres7_profileSql,
+		"postgres/7_profile.sql",
+	)
+}
+
+func postgres7_profileSql() (*asset, error) {
+	bytes, err := postgres7_profileSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/7_profile.sql", size: 601, mode: os.FileMode(420), modTime: time.Unix(1792190572, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres8_user_searchSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x91\xc1\x6e\xa3\x30\x14\x45\xf7\xfe\x8a\xbb\x4c\x34\x43\x7e\x80\xd5\x68\xe2\xc9\x58\x33\x82\x2a\x10\x35\x3b\xf4\x0a\xaf\x60\x09\x0c\xb2\x1f\x21\xfc\x7d\x05\x69\x2b\xb5\x8d\xba\xb4\xcf\xd1\xd5\xbd\x76\x14\xe1\x47\x67\x6b\x4f\xc2\x38\x0d\x4a\x45\x11\x72\xbf\x9c\x3b\x58\x57\xf1\x95\x03\x02\xfb\x0b\xc3\xfc\x37\xff\x34\x06\x12\x61\xef\x02\xa4\x21\x41\x47\x52\x36\x20\x37\x4f\x0d\x7b\x86\x75\x20\x38\xea\xf8\x27\xc2\xb8\x80\x00\xc2\x33\x75\xb6\x9d\xd7\x6b\xc8\x3c\x70\x85\xde\xc1\x4a\x40\x3f\xb9\x9d\xfa\x7d\xd4\xbf\x72\x0d\x7d\xce\x75\x92\x99\x34\x81\xf9\x83\x24\xcd\xa1\xcf\x26\xcb\x33\x0c\x75\x21\xbe\xee\x62\xf5\x26\x9a\x64\xaf\xcf\x18\x03\xfb\x50\x2c\x91\x2b\x2e\x6c\x75\x55\x40\x9a\xdc\x00\x4e\x99\x49\x0e\x38\x98\x04\x9b\xc5\x41\x6d\xdd\xcd\xeb\x87\xb0\x55\xc0\xe3\x5f\x7d\xd4\xa0\x52\xec\x85\xe3\x75\x72\xc6\xe4\xcb\x86\xc3\xeb\x22\xee\xc8\xb6\xa0\xaa\xf2\x1c\x02\x07\xf0\x95\x4a\x69\x67\x3c\x8d\x82\xc9\x4a\xd3\x8f\x02\xcf\x35\xf9\x0a\xd2\xa3\xa4\xc0\xbb\x7b\x05\xdb\x7e\x62\x5f\xac\x61\x9f\x2b\x6e\x56\xb6\x59\xd9\xf6\x7e\xa7\xf7\x6f\xd9\xf7\x93\x53\x6a\x7f\x4c\x1f\xbe\x4b\x8f\xbf\x1a\x1f\x1e\x28\x56\x2f\x03\x00\x8f\x6e\x3b\xa7\xeb\x01\x00\x00")
+
+func postgres8_user_searchSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres8_user_searchSql,
+		"postgres/8_user_search.sql",
+	)
+}
+
+func postgres8_user_searchSql() (*asset, error) {
+	bytes, err := postgres8_user_searchSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/8_user_search.sql", size: 491, mode: os.FileMode(420), modTime: time.Unix(1792190835, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres9_data_exportsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xcd\x6a\xc3\x30\x10\x84\xef\x7a\x8a\x21\x97\xd8\xb4\x79\x82\x9c\x54\x6b\x53\x4c\x1d\xd9\x28\x12\x24\xbd\x18\x51\x89\xa0\x43\x1d\x63\xcb\x24\x8f\x5f\x54\x9c\xb6\xe9\x0f\x54\x27\xb1\xbb\xdf\x2c\x3b\xb3\x5a\xe1\xee\x35\x1c\x07\x1b\x3d\x4c\xcf\x58\xa1\x88\x6b\x82\xe6\x0f\x15\xc1\xd9\x68\x5b\x7f\xe9\x4f\x43\x1c\x91\x31\x20\x38\x5c\x9f\x31\xa5\xb8\xfe\x65\xad\x21\x4d\x55\xa1\x51\xe5\x96\xab\x03\x9e\xe8\x70\xcf\x80\x69\xf4\x43\x1b\xdc\x5f\xe3\x69\x64\x53\x2b\x2a\x1f\x65\x22\x90\x2d\x66\x60\x91\x43\xd1\x86\x14\xc9\x82\x76\xef\x2a\x23\xb2\xe0\x72\xd4\x12\x82\x2a\xd2\x84\x82\xef\x0a\x2e\x28\x55\x4c\x23\xf8\x67\x25\x89\x8e\xd1\xc6\x69\x4c\xab\xa0\x69\xaf\xbf\xef\x85\xa0\x0d\x37\x95\xc6\xb2\xf7\x9d\x0b\xdd\x71\x99\xa0\x97\xc1\xdb\xe8\x5d\x6b\x23\x74\xb9\xa5\x9d\xe6\xdb\x46\x3f\xff\x84\xba\xd3\x39\xcb\x13\x30\xf5\xee\xff\x00\xcb\xd7\x1f\xe6\x96\x52\xd0\xfe\xc6\xdc\x76\x3e\xbc\x0d\xee\xc2\x90\x8e\xba\xb5\x7e\x6e\x27\x8d\xaf\x81\x89\xd3\xb9\x63\x4c\xa8\xba\xf9\x25\xb0\x35\x7b\x1b\x00\xd5\xe6\xc5\x90\xdc\x01\x00\x00")
+
+func postgres9_data_exportsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres9_data_exportsSql,
+		"postgres/9_data_exports.sql",
+	)
+}
+
+func postgres9_data_exportsSql() (*asset, error) {
+	bytes, err := postgres9_data_exportsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/9_data_exports.sql", size: 476, mode: os.FileMode(420), modTime: time.Unix(1792191530, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _tmplConsentHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x56\xdd\x6e\xdb\x38\x13\xbd\xcf\x53\xcc\xa7\xa0\x40\x52\xd8\x92\x9c\x9f\x36\x50\x64\xf7\x0b\xd2\x0d\xb0\x57\x2d\xda\xec\xc5\x5e\x52\xd4\x48\x22\x4a\x71\xb8\x24\x95\xd8\x11\xfc\xee\x0b\x4a\x56\x6b\xcb\x76\xdb\x45\x6b\x11\x90\xc5\x19\x1d\x92\x67\xce\x8c\x26\xfd\xdf\xfb\x0f\xf7\x8f\x7f\x7f\xfc\x03\x2a\x57\xcb\xc5\x49\xea\x6f\x20\x99\x2a\xe7\x01\xaa\xc0\x4f\x20\xcb\x17\x27\x00\x00\x69\x8d\x8e\x01\xaf\x98\xb1\xe8\xe6\xc1\x5f\x8f\x0f\xd3\x9b\x60\x63\x72\xc2\x49\x5c\x7c\x94\xc8\x2c\x42\x29\x9e\x10\x56\xd4\x18\xe0\xa4\x2c\x2a\x97\x46\xbd\xbd\xf7\xb5\x6e\x35\xfc\xf7\xd7\xff\x45\xad\xc9\x38\x68\x8c\x3c\xab\x9c\xd3\x36\x89\xa2\x82\x94\xb3\x61\x49\x54\x4a\x64\x5a\xd8\x90\x53\x1d\x71\x6b\xdf\x15\xac\x16\x72\x35\xff\x44\x19\x39\x4a\x2e\xe3\xf8\xfc\xf6\xe4\x2b\x52\x46\xf9\x0a\xda\xaf\x8f\x7e\x64\x8c\x7f\x29\x0d\x35\x2a\x4f\xe0\xf4\xed\x9b\xec\xe6\xfa\xe2\x16\xa2\xd7\x50\x30\x29\xbd\x0d\x0a\x32\x40\x32\x87\xcc\xd0\xb3\x45\x63\xe1\x75\x74\x14\x60\xfa\x8c\xd9\x17\xe1\xa6\x52\x28\x64\x66\x5a\x1a\x96\x0b\x54\xee\xcc\x88\xb2\x72\x93\x01\x7f\x02\xa7\x37\xef\xef\x2f\xde\x3c\x9c\xdf\x1e\x47\xaa\xe9\xe5\x77\xc0\xd0\x6f\x00\x19\x23\x38\x02\x89\xc5\x8f\x31\x7c\x8c\xa6\x7d\x3c\x12\x08\xfa\x88\x04\x13\xb0\x4c\xd9\xa9\x45\x23\x8a\x5d\x77\x7a\x42\x53\x48\x7a\x4e\xa0\x12\x79\x8e\x6a\xd7\x3a\x50\xdb\x81\xda\x9a\xc8\x55\x42\x95\x09\x30\xe5\x04\x93\x82\x59\xcc\x47\x2f\x78\x06\xc9\x2e\xf7\xde\x28\x0d\x5b\x59\xce\x24\x7e\xf3\x5f\x7f\x93\x48\xf8\x6c\x98\xd6\x68\x46\x32\x79\x16\xb9\xab\x12\xb8\x7c\x13\xeb\xe5\xee\x3a\x9a\xe5\x79\x87\x7b\xf3\x0a\x62\x88\x77\x8d\x35\x33\xa5\x50\x09\xb0\xc6\xd1\xe1\xe5\x34\x53\x28\x47\x8b\x69\xb2\xc2\x09\x52\x09\x18\x94\xcc\x89\xa7\xad\xad\xfa\xf1\x32\x15\x2a\xc7\x65\x02\xb3\xe3\x41\x3b\x7d\xe8\x7e\xbb\x0e\x35\x5b\x4e\x8f\x9f\x64\xd8\x6c\xdc\x6d\x17\x66\xf1\xf1\xb3\x5e\x5d\x8f\x4d\x19\x2d\xa7\xb6\x62\xb9\x8f\x5f\x0c\x31\x5c\xc4\x7a\x09\x31\x98\x32\x63\x67\xf1\x04\x36\x23\xbc\x38\x9f\x40\x0c\xd7\x7a\x09\xd7\x87\xed\x57\xe7\x07\x79\x62\x23\x8a\x38\x49\x32\x09\x9c\x5e\xdd\xdf\x3d\x5c\x8f\x48\x77\xb8\x74\xd3\x1c\x39\x19\xd6\xb3\xa8\x48\x1d\x0e\x76\x41\xa6\x06\xa1\x74\xe3\xa0\xfd\x25\xe9\x36\xce\x27\x49\x02\xf1\x77\x02\x52\x5c\xf8\xeb\xf6\x90\xac\x66\x71\xfc\x6a\xf4\x26\x99\x1c\x4d\x72\x4c\x4f\x9e\xe1\xd9\xf5\xd1\xf0\xec\x9b\xba\xf0\x88\x97\x4e\xa8\x3d\xf6\x34\xa3\x91\x4f\x9f\x28\xe2\x05\x13\x98\x5d\xe9\xe5\x71\xc6\xb2\xc6\x39\x52\xbf\x46\x59\x17\x24\x67\x98\xb2\x3e\x08\x09\x34\x3e\xe9\x38\xb3\x23\xa9\xff\x14\xb3\x87\x44\xf0\x5f\x99\xfd\x0e\x77\x83\xd6\x0e\x65\xd4\x51\xce\xb6\x6b\x56\x77\xcc\x4d\x46\x33\x29\x21\x0e\x2f\x01\xf7\x8e\xfa\x73\x5e\xbc\x31\xd6\x2b\x5f\x93\x50\x0e\xcd\x8f\x82\x94\x54\xbe\xac\x4e\x20\xdc\x9e\x63\xdc\xd7\x94\xd1\x64\x41\xbc\xb1\xd0\x7e\x87\xe5\xcb\xbb\xf8\xea\xed\xf1\x05\xc3\x1a\xad\x65\x25\x42\x7b\x50\xb2\x5e\x93\xfb\x05\x72\xe0\x36\xbb\xf4\xd7\x71\x6e\x2f\xb6\xb9\x5d\x77\x6e\x69\xb4\xe9\x13\xd2\xa8\x6f\x41\x52\xff\x79\x5f\x9c\xa4\xb9\x78\x02\x2e\x99\xb5\xf3\x60\x53\xcc\x87\x26\x64\xcb\xd2\xd5\xdd\xcd\xbc\x1f\x69\x47\x90\x67\x86\xd4\x3c\x88\x36\x7d\xc9\x3b\x5e\x31\x29\x51\x95\x38\x6f\xdb\xf0\x7e\x78\x58\xaf\x03\xa8\xd1\x55\x94\xcf\x83\x8f\x1f\x3e\x3f\x6e\xe1\xf8\x91\x56\x33\x60\x52\x94\x6a\x1e\xf8\x0f\x65\xb0\xb8\x1f\xba\x9c\x6a\x36\xf2\xd4\xbb\x8e\x3b\x46\x3f\xee\x14\x30\xad\xa5\xe0\x5d\x29\x83\x33\x91\x27\xd0\xb6\xe1\x5d\xe3\x3f\xc5\x1c\xd7\xeb\x73\x30\xf8\x4f\x83\xd6\x61\x3e\x34\x53\xe0\x08\x18\xe7\x68\x2d\x18\xb4\xd4\x18\x8e\x16\x48\xf5\x0d\x57\x86\x15\x93\x45\x08\x8f\x15\x6e\x23\xef\x2d\xfc\xcc\x94\xb3\x7b\xb3\x1b\x58\x47\xc9\x8e\x29\x8d\xf4\xe8\x5c\x8d\xdc\x3f\x4b\xdb\x1a\xa6\x4a\x84\xf0\xd3\xb0\xe3\xcf\x9c\x34\xda\xf5\x7a\xcf\x35\x95\x62\x91\xf6\x85\xd9\xad\x34\xce\x03\x5e\x21\xff\x92\xd1\x32\x00\xc5\x6a\x9c\x07\x6d\x1b\xae\xd7\xc1\xa2\xbb\xa5\x91\x14\x87\x56\x43\x95\x8f\xa0\xd3\x68\xbc\xaf\xb6\x85\xf0\xfe\xf3\xa7\x87\x07\x81\x32\x87\xb1\xfb\xa6\xd0\xf5\x5b\xb0\x4d\x56\x0b\x17\x2c\xee\xb4\x36\xf4\x84\x69\xd4\x5b\x77\xf1\x52\x3d\x08\x6c\x93\x0d\xc1\xe2\xcf\xc2\x33\x0f\x39\x81\x22\x07\x06\x39\x95\x4a\xbc\x20\xb8\x4a\xd8\xed\x10\x4c\x3a\x37\xce\x14\x58\x56\xa0\x5c\x01\x2e\x85\xeb\xbd\x34\x2b\x31\xdc\x21\x39\x8d\xbc\x5e\x37\xb2\x8e\x72\xf1\xb4\x38\xf9\x7a\xdb\xa4\x41\x54\xb9\x5a\x2e\xfe\x1d\x00\xed\xb0\x7f\x25\xc0\x0b\x00\x00")
 
 func tmplConsentHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -107,7 +449,7 @@ func tmplConsentHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "tmpl/consent.html", size: 3006, mode: os.FileMode(420), modTime: time.Unix(1495322613, 0)}
+	info := bindataFileInfo{name: "tmpl/consent.html", size: 3008, mode: os.FileMode(420), modTime: time.Unix(1792194706, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -132,7 +474,27 @@ func tmplErrorHtml() (*asset, error) {
 	return a, nil
 }
 
-var _tmplLoginHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xac\x57\x7b\x77\xda\xb8\x12\xff\x3f\x9f\x62\xea\x9e\x3d\xa7\xdd\xe2\x07\x81\x3c\x4a\x6d\x7a\xd3\x3c\xb6\xb7\xdb\xdb\xa6\xaf\xcd\xb6\xff\x09\x6b\x6c\x0b\x64\xc9\x91\x64\x02\xe4\xe6\xbb\xdf\x23\x1b\x93\x60\xa0\xb7\xbb\x5d\x48\x80\x99\x91\x7f\x9a\xf9\xcd\x68\x3c\x0e\x1f\x9d\xbd\x3f\xfd\xfc\xf5\xf2\x1c\x32\x93\xf3\xe1\x5e\x68\xbf\x80\x13\x91\x46\x0e\x0a\xc7\x2a\x90\xd0\xe1\x1e\x00\x40\xf8\xc8\x75\xe1\x23\x5e\x97\x4c\x21\x85\x1c\x0d\x01\x43\x52\x0d\xae\xbb\xb4\x57\xaa\x38\x23\x4a\xa3\x89\x9c\xd2\x24\xee\xb1\xf3\xd0\x24\x48\x8e\x91\x33\x65\x78\x53\x48\x65\x1c\x88\xa5\x30\x28\x4c\xe4\xdc\x30\x6a\xb2\x88\xe2\x94\xc5\xe8\x56\x42\x07\x98\x60\x86\x11\xee\xea\x98\x70\x8c\xba\x1d\xd0\x99\x62\x62\xe2\x1a\xe9\x26\xcc\x44\x42\x36\xd0\x86\x19\x8e\xc3\xb7\x32\x65\x02\xfe\x0b\x9f\x4c\x49\x99\x2c\x35\x9f\x87\x7e\x6d\xa9\x57\x69\x33\x6f\x7e\xdb\xd7\xbf\x58\x6e\x7d\x80\x52\xf1\x27\x99\x31\x85\x1e\xf8\x7e\x22\x85\xd1\x5e\x2a\x65\xca\x91\x14\x4c\x7b\xb1\xcc\xfd\x58\xeb\x97\x09\xc9\x19\x9f\x47\x1f\xe5\x48\x1a\x39\xe8\x05\xc1\xd3\x17\x7b\x2b\xa4\x91\xa4\x73\xb8\x5d\x89\x95\x8a\xc4\x93\x54\xc9\x52\xd0\x01\x3c\x3e\x3a\x1c\x1d\x1f\xec\xbf\x00\xff\x57\x48\x08\xe7\xd6\x06\x89\x54\x20\x39\x85\x91\x92\x37\x1a\x95\x86\x5f\xfd\x9d\x00\xee\x0d\x8e\x26\xcc\xb8\x9c\x09\x24\xca\x4d\x15\xa1\x0c\x85\x79\xa2\x58\x9a\x99\x4e\x83\xdf\x81\xc7\xc7\x67\xa7\xfb\x87\x17\x4f\x5f\xec\x46\xca\xe5\xe2\x9f\x80\x91\xff\x00\x48\x1b\xc1\x48\xe0\x98\xfc\x7f\x0c\x9b\x23\xb7\xce\xc7\x00\x9c\x3a\x23\x4e\x07\x34\x11\xda\xd5\xa8\x58\xb2\xbe\x5c\x4e\x51\x25\x5c\xde\x0c\x20\x63\x94\xa2\x58\xb7\x36\xd4\x56\xa0\x3a\x97\xd2\x64\x4c\xa4\x03\x20\xc2\x56\x1e\x23\x1a\x69\xeb\x02\xcb\xa0\xd4\xb3\x8d\x2b\x52\x45\xe6\x55\xa1\xde\xaf\xbf\xbb\x2f\x11\xef\x46\x91\xa2\x40\xd5\x2a\x93\xaa\xd0\x07\xd0\x3b\x0c\x8a\xd9\xfa\x3e\x05\xa1\xb4\xc2\x3d\xfe\x05\x02\x08\xd6\x8d\x39\x51\x29\x13\x03\x20\xa5\x91\xdb\xb7\x2b\x88\x40\xde\xda\xac\x90\x9a\x19\x26\xc5\x00\x14\x72\x62\xd8\x14\xd7\x51\x17\x2e\x13\x14\x67\x03\xe8\xee\x4e\xda\xe3\x8b\xea\xd5\x76\x67\xe6\xee\x8e\xa4\x71\x36\xa8\xdc\x85\x6e\xb0\x3b\xd6\xfe\x41\xdb\x64\x70\x66\x5c\xc2\x59\x2a\x06\x10\xa3\x30\xa8\x5a\xbe\xc9\x99\xab\x33\x42\x6d\x7e\x03\x08\x60\x3f\x28\x66\x10\x80\x4a\x47\xe4\x49\xd0\x81\xe5\x9f\xb7\xff\xb4\x03\x01\x1c\x14\xb3\xea\x7f\x8b\xbd\xff\x74\x2b\x8f\x89\x54\x39\x30\x51\x94\xa6\xc5\xe5\x5f\x2d\xc2\xd2\xd8\x72\x1f\xb4\x13\xb9\x46\x6d\xb2\x6f\xdf\x2f\xb6\x15\x48\x37\x08\x7e\x69\x07\xae\x28\xaa\x0d\xc0\x7b\xb2\x03\xe8\x6e\xb0\xb9\x22\x7a\xd3\x54\x11\xc9\x16\x95\xb5\xc6\x76\x47\x72\xb6\xe5\xe4\x69\xb6\xc0\x01\x74\xfb\x0f\x01\xda\x8c\x8d\x4a\x63\xa4\xf8\x39\xca\xaa\xcc\x1b\x45\x84\xb6\x90\x03\x28\xed\xf1\x89\x89\xc6\xbf\xc1\x6c\xff\xf4\xe4\xe2\x20\xf8\x39\x66\xbf\xc3\x5d\x2c\xb9\x54\x3b\xce\xc6\x4e\xce\xe0\x41\xf7\xa9\xc2\x5c\x9e\x4d\xc2\x39\x04\x5e\x0f\x70\x23\xd4\x1f\x5b\x15\x97\x4a\x5b\x6f\x0a\xc9\xd6\x8f\xcb\xf6\x24\x0d\x32\xdb\x20\x3b\xe0\x3d\xd4\x91\xd8\x76\x87\x96\x32\x91\x71\xa9\xbf\x77\x9b\xeb\xf7\x4e\x82\xfe\xd1\xee\x0d\xbd\x1c\xb5\x26\x29\xb6\x30\x9a\x92\xed\xd6\x47\x33\xd8\xce\xed\xa8\x67\xdf\xbb\xb9\xdd\xff\x5e\x3d\xae\x76\x26\xad\xbd\x1b\xf4\x6d\x05\x52\x15\x20\xc5\x58\x2a\x52\x73\x2e\xa4\xd8\xd1\xdc\x51\x29\xd9\x6e\xed\x0d\x74\x92\x04\x41\x10\xc0\xa3\x7a\xda\x20\xc2\x3c\x84\xb0\x9f\xa1\xbf\x1c\x4c\x42\xbf\x1e\xb4\x42\x3b\x4f\x0c\xf7\x42\xca\xa6\x10\x73\xa2\x75\xe4\x2c\xef\x1e\xcd\xbc\xf3\xc0\x52\x35\x7a\xe7\x7e\xaa\x09\xeb\x96\x45\x23\x87\xdb\x71\xc8\x01\x9b\x4b\x29\x22\xc7\xaf\xe4\x97\x71\x46\x38\x47\x91\x62\x74\x7b\x0b\xde\x4a\x82\xbb\x3b\xc7\xce\x73\x99\xa4\x91\x73\xf9\xfe\xd3\xe7\x07\x90\x15\x6c\xd6\x85\xaa\x0d\x47\x8e\xbd\x49\x3b\xf5\xb0\x15\xfa\x59\x77\x7d\xdd\xed\x2d\xb0\xa4\x21\xe4\xee\x6e\x1d\xa3\x58\x83\x68\x22\xa8\xd6\x3a\x43\xeb\x4e\x73\x59\xe8\x17\x1b\xb0\x28\xe8\x06\x60\xdd\x99\xeb\x99\x12\x73\xc2\xb8\x03\x66\x5e\xdc\x0b\x05\x27\x31\x66\x92\x53\x54\x2b\xdd\x94\xf0\x12\x23\xa7\xda\xce\x6a\x6c\xe4\xfe\x70\x37\x6e\x41\xb4\xbe\x91\x8a\x36\xd0\xf7\xf2\x1a\xfa\x4a\xed\x6f\x78\xee\xc5\x5a\x25\x17\x0c\xf9\x66\x00\xcb\x46\x59\x23\xeb\x72\x94\x33\xe3\x0c\x79\x4d\x6d\x6d\x1b\xb6\x29\x5c\xb2\xb6\xac\x68\x67\xf8\x4e\x1a\x50\x98\x32\x6d\x50\x21\x7d\x09\x21\x81\x4c\x61\x12\x39\x7e\xa3\x5d\xcb\xf9\x7d\xca\xef\xee\x9c\xe1\xa9\x42\x62\x10\x88\x00\x12\xc7\xb2\x14\x26\xf4\xc9\x70\x8d\xfd\xd0\xb7\x05\xb5\xac\x3b\x9f\xb2\xa9\xad\xd3\xfa\xcb\x3e\x0b\x8c\x3f\x94\xa8\xe6\x90\x30\xa5\x4d\x07\x4c\x86\x02\x3e\xa3\xc9\x6c\x47\xa9\x84\x57\x52\x1a\x6d\x14\x29\xe0\xcd\x27\xaf\x7a\x4c\x08\x75\xac\x58\x61\x40\xab\x38\x72\x9a\xb9\x3b\x96\x14\xbd\xf1\xb5\xc5\xaa\x46\xee\xfa\xa7\xdb\xf3\xba\x5e\xd7\xd3\x9c\xe5\x5e\xce\x84\x37\xd6\xce\xca\x2f\xdb\xdc\x52\xc5\xcc\x3c\x72\x74\x46\x7a\xc7\x7d\xf7\xe4\xe8\xe2\xdb\xf8\x68\xfa\x8c\xfa\x9a\xe6\xff\xb9\x2e\x7c\xf1\xfe\xc3\x0d\x67\x6f\xa7\x5f\xf4\x9b\xe4\xec\xf5\xd5\xb3\xc9\xf3\xf7\x79\xea\x13\xff\x3c\xc3\x13\x9a\x9a\xc5\x3b\xdd\xcb\x8a\x84\xa4\x87\xe7\xf4\xf9\x41\x20\xee\xb1\x63\x25\xb5\x96\x8a\xa5\x4c\x44\x0e\x11\x52\xcc\x73\x59\x6a\x67\x18\xfa\xb5\xef\xbb\x82\xa0\x62\xac\xbd\x98\xcb\x92\x26\x9c\x28\xac\x22\x21\x63\x32\xf3\x39\x1b\x69\xdf\x54\xbc\xf8\x5d\xaf\xef\x05\xfe\xb8\x91\x7f\x20\xb0\xb3\x85\xa1\x27\x97\xaf\xae\x2e\x3f\xfe\xf9\xe9\xc4\xef\xe1\xd7\xf3\xf3\x2f\x57\xea\xea\x74\x7e\xf4\xdb\xc1\xef\x17\x23\x3c\x4e\x2e\xc6\x93\x83\x37\x27\xff\x9e\x7d\xf9\xfa\xfa\xf7\xc9\xd9\xec\xf0\x03\x13\xdd\xb3\xc9\xd5\xec\xa0\x3b\x7a\xa5\x46\x3f\x1d\x58\x4e\x66\x31\x15\xde\xa8\xc9\xa5\x15\x6c\x6c\x2b\x85\xdf\xf7\x02\x2f\x70\x09\x2f\x32\xe2\x1d\xda\xe0\x56\xa6\x1f\x88\x6f\xfa\xea\xea\x6a\xc1\xbf\xbd\x39\x46\xf2\x9c\x9c\xfe\xd9\x2f\xce\xaf\x7a\xea\x8f\xd7\xe3\x74\x6c\x8e\x16\xc5\xe4\x5d\xf1\x6d\xf2\x2c\xd8\x3f\x7b\x5e\x64\x8b\x39\xfe\x31\x39\x7f\x36\x96\x01\xc3\xdf\xd8\xe2\xfa\xf2\xed\x85\x54\x7f\x29\x71\x7b\xa1\xbf\xec\xae\x7e\xf5\xb4\xfb\xbf\x00\x00\x00\xff\xff\x09\xa6\xc8\x62\xfd\x0e\x00\x00")
+var _tmplLinkedHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x54\x4d\x6f\xeb\x36\x10\xbc\xfb\x57\x6c\x65\x14\x8d\x03\xeb\xc3\x4e\x9c\x1a\x8a\xac\xb6\x48\x6a\xe4\x50\xa0\x45\x9b\xe2\x21\xc7\x95\x48\x49\x84\x29\xae\x40\x52\xb6\x1c\x23\xff\xfd\x41\xfe\x4a\x2c\xc7\x78\x97\x48\x0b\x50\xe2\x2c\x67\xc8\x5d\x62\xa2\x9f\x1e\xff\x7e\x78\x7e\xf9\xe7\x4f\x28\x6c\x29\xe3\x5e\xd4\x0e\x20\x51\xe5\x33\x87\x2b\xa7\x9d\xe0\xc8\xe2\x1e\x00\x40\x54\x72\x8b\x90\x16\xa8\x0d\xb7\x33\xe7\xff\xe7\xb9\x3b\x75\xf6\x90\x15\x56\xf2\xf8\x2f\xa1\x16\x9c\x81\x0b\xff\xd9\x9a\x09\xaa\x8d\x5c\x47\xfe\x0e\xda\xa5\x19\xbb\x3e\x7c\xb7\xef\xef\xa2\xac\x48\x5b\xa8\xb5\xbc\x2a\xac\xad\x4c\xe8\xfb\x19\x29\x6b\xbc\x9c\x28\x97\x1c\x2b\x61\xbc\x94\x4a\x3f\x35\xe6\xb7\x0c\x4b\x21\xd7\xb3\x7f\x29\x21\x4b\xe1\x4d\x10\x0c\xee\x7b\x47\xa6\x84\xd8\x1a\x36\xc7\xdf\x36\x12\x4c\x17\xb9\xa6\x5a\xb1\x10\xfa\xbf\xde\x25\xd3\xc9\xf8\x1e\xfc\x6b\xc8\x50\xca\x16\x83\x8c\x34\x90\x64\x90\x68\x5a\x19\xae\x0d\x5c\xfb\x17\x09\xdc\x15\x4f\x16\xc2\xba\x52\x28\x8e\xda\xcd\x35\x32\xc1\x95\xbd\xd2\x22\x2f\xec\xf0\xc0\x3f\x84\xfe\xf4\xf1\x61\x7c\x37\x1f\xdc\x5f\x66\x2a\xe9\xf5\x2b\x68\xe8\x0b\x48\xba\x0c\x96\x40\xf2\xec\xc7\x1c\x6d\x8f\xdc\x5d\x3f\x42\x70\x76\x1d\x71\x86\x60\x50\x19\xd7\x70\x2d\xb2\xd3\x74\x5a\x72\x9d\x49\x5a\x85\x50\x08\xc6\xb8\x3a\x45\x0f\xa5\xdd\x92\x9a\x92\xc8\x16\x42\xe5\x21\xa0\xb2\x02\xa5\x40\xc3\x59\x67\x41\x5b\x41\x32\xcd\xd9\x8a\x5c\xe3\xda\xa4\x28\xf9\x7b\xfe\xdb\xfb\x15\xf1\x56\x1a\xab\x8a\xeb\xce\x35\x59\x09\x66\x8b\x10\x6e\xee\x82\xaa\x39\xd5\xa9\x90\xb1\x2d\xef\xf4\x67\x08\x20\x38\x05\x4b\xd4\xb9\x50\x21\x60\x6d\xe9\x73\xb9\x0a\x15\x97\x1d\xb1\x8a\x8c\xb0\x82\x54\x08\x9a\x4b\xb4\x62\xf9\x61\xab\x6d\xbc\xba\x42\x31\xde\x84\x30\xba\xdc\xb4\xfe\x7c\xfb\x9c\x26\x94\xd8\xb8\x97\x4f\x72\xd8\x6c\xb0\xdd\x2e\x8c\x82\xcb\x67\xbd\x9d\x74\xa1\x84\x1a\xd7\x14\xc8\xda\xfe\x05\x10\xc0\x38\xa8\x1a\x08\x40\xe7\x09\x5e\x05\x43\xd8\x87\x37\x1e\x0c\x21\x80\x49\xd5\xc0\xe4\x73\xfc\x76\xf0\x69\x9d\xb0\x53\xa2\x94\x24\xe9\x10\xfa\xb7\x0f\x7f\xcc\x27\x9d\xa2\x5b\xde\x58\x97\xf1\x94\x34\xee\xaa\xa8\x48\x9d\x37\x3b\xf2\xf7\x36\x13\xf9\x3b\xf3\x8a\x5a\x77\x88\x7b\x11\x13\x4b\x48\x25\x1a\x33\x73\xf6\x77\xe1\x60\x5f\x1f\x90\x6d\xdb\xf6\xf3\x6d\x6c\x36\x20\x32\xf0\xb8\xd6\xa4\xe1\xed\xed\x38\x1f\x15\xa3\xf8\xa9\x2c\xbd\xc8\x2f\x46\xef\xd9\x51\x15\x7f\xe3\x90\x52\x2d\x99\xfa\xc5\x82\x14\x6a\x01\x6b\xaa\x35\x6c\x36\xe0\x55\x9a\x96\x82\xf1\x96\x06\x30\x4d\xa9\x56\x36\xdc\x02\x07\x72\x2f\xf2\xab\x13\x65\x2e\x0d\xef\x8a\xee\x4c\xf6\x5c\xf7\x85\x6a\x48\x51\x81\xa2\x15\x48\xca\x41\x28\x58\x09\x5b\x74\x95\xcf\x35\x14\x3b\x91\xa8\xe2\x08\xa1\xd0\x3c\x9b\x39\x07\x4f\x36\x47\x3b\xf7\x52\x72\xe2\x27\x2a\x79\xe4\x63\x7c\x64\x8a\x7c\x26\x96\x71\xef\x38\xec\xeb\xed\x17\xb6\x94\xf1\xf7\x01\x00\x18\xc8\xee\xf7\x63\x06\x00\x00")
+
+func tmplLinkedHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_tmplLinkedHtml,
+		"tmpl/linked.html",
+	)
+}
+
+func tmplLinkedHtml() (*asset, error) {
+	bytes, err := tmplLinkedHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "tmpl/linked.html", size: 1635, mode: os.FileMode(420), modTime: time.Unix(1792190295, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _tmplLoginHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x58\x6b\x73\xdb\xb6\xd2\xfe\xee\x5f\xb1\x61\xda\x19\xa9\x96\x48\xfa\x96\x38\x8a\xa8\xbc\x8e\x2f\xcd\xed\x4d\xdc\x38\xa9\xdb\x9e\x9e\xe9\x80\xe4\x92\x84\x05\x02\x0c\x00\xea\x62\x57\xff\xfd\x0c\x48\x51\x12\x25\xca\xf6\x39\xa9\xc9\x19\x0b\xc0\xe2\xd9\xdd\x07\xbb\xcb\x25\xfb\x4f\xce\x3e\x9d\x7e\xf9\xfd\xf2\x1c\x12\x9d\xb2\xc1\x4e\xdf\xfc\x03\x46\x78\xec\x59\xc8\x2d\x33\x81\x24\x1c\xec\x00\x00\xf4\x9f\x74\xbb\xf0\x19\xbf\xe5\x54\x62\x08\x29\x6a\x02\x9a\xc4\x0a\xba\xdd\xf9\x7a\x31\x15\x24\x44\x2a\xd4\x9e\x95\xeb\xa8\x7b\x6c\xad\x2e\x71\x92\xa2\x67\x8d\x28\x8e\x33\x21\xb5\x05\x81\xe0\x1a\xb9\xf6\xac\x31\x0d\x75\xe2\x85\x38\xa2\x01\x76\x8b\x41\x07\x28\xa7\x9a\x12\xd6\x55\x01\x61\xe8\xed\x75\x40\x25\x92\xf2\x61\x57\x8b\x6e\x44\xb5\xc7\x45\x05\xad\xa9\x66\x38\xf8\x20\x62\xca\xe1\x6f\xb8\xd2\x79\x48\x45\xae\xd8\xb4\xef\x94\x2b\xa5\x94\xd2\xd3\xea\xb7\xb9\xfe\x8f\xa6\xc6\x06\xc8\x25\x6b\x25\x5a\x67\xaa\xe7\x38\x91\xe0\x5a\xd9\xb1\x10\x31\x43\x92\x51\x65\x07\x22\x75\x02\xa5\x5e\x45\x24\xa5\x6c\xea\x7d\x16\xbe\xd0\xa2\x77\xe0\xba\xed\x97\x3b\x0b\x24\x5f\x84\x53\xb8\x5b\x0c\xcd\xed\x93\x60\x18\x4b\x91\xf3\xb0\x07\x4f\x9f\x3f\xf3\x8f\x8f\xf6\x5f\x82\xf3\x13\x44\x84\x31\xb3\x06\x91\x90\x20\x58\x08\xbe\x14\x63\x85\x52\xc1\x4f\xce\x56\x80\xee\x18\xfd\x21\xd5\x5d\x46\x39\x12\xd9\x8d\x25\x09\x29\x72\xdd\x92\x34\x4e\x74\xa7\xc2\xef\xc0\xd3\xe3\xb3\xd3\xfd\x67\x17\xed\x97\xdb\x91\x52\x71\xfb\x4f\xc0\x88\x7f\x00\x64\x1d\x41\x0b\x60\x18\x3d\x8c\x61\xce\xa8\x5b\x9e\x47\x0f\xac\xf2\x44\xac\x0e\x28\xc2\x55\x57\xa1\xa4\x51\x5d\x5c\x8c\x50\x46\x4c\x8c\x7b\x90\xd0\x30\x44\x5e\x5f\xad\xa8\x2d\x40\x55\x2a\x84\x4e\x28\x8f\x7b\x40\xb8\x89\x3c\x4a\x14\x86\x6b\x1b\x0c\x83\x42\x4d\x36\x76\xc4\x92\x4c\x8b\x40\x5d\xca\xcf\x96\x21\x62\x8f\x25\xc9\x32\x94\x6b\x61\x52\x04\x7a\x0f\x0e\x9e\xb9\xd9\xa4\xae\x27\x23\x61\x58\xe0\x1e\xff\x08\x2e\xb8\xf5\xc5\x94\xc8\x98\xf2\x1e\x90\x5c\x8b\x66\x75\x19\xe1\xc8\xd6\x94\x65\x42\x51\x4d\x05\xef\x81\x44\x46\x34\x1d\x61\x1d\xf5\xb6\x4b\x79\x88\x93\x1e\xec\x6d\x3f\xb4\xa7\x17\xc5\x5f\x5d\x20\x25\x93\xee\x76\x4f\x2a\x63\xdd\xc2\x5c\xd8\x73\xb7\xfb\x7a\x78\xb4\xbe\xa4\x71\xa2\xbb\x84\xd1\x98\xf7\x20\x40\xae\x51\xd6\xd7\x7d\x31\xe9\xaa\x84\x84\xe6\x7c\x5d\x70\x61\xdf\xcd\x26\xe0\x82\x8c\x7d\xd2\x72\x3b\x30\xbf\xed\xfd\x76\x07\x5c\x38\xca\x26\x70\xd4\xbc\x7e\xd8\x6e\xe4\x31\x12\x32\x05\xca\xb3\x5c\xc3\xdd\x77\x05\x61\xae\x4d\xb8\xf7\xc0\xbd\x87\xda\x68\xdf\x5c\x2f\x9b\x02\x64\xcf\x75\x7f\x5c\xdb\x29\x64\x88\xb2\xb7\x2d\x32\x0c\x17\x7b\x47\x5b\x89\xde\x5c\x2a\x88\xa4\xb7\x45\xc8\x95\xd8\x5d\x5f\xac\xc9\x94\x21\x4f\x6f\xb1\x07\x7b\x87\xd9\x64\x3b\x63\x7e\xae\xb5\xe0\xdf\x47\x59\x71\xf2\x5a\x12\xae\xcc\x21\xf4\x20\x37\xe9\x13\x10\xb5\x16\xb4\x8f\x62\xf6\xf0\xf4\xe4\xe2\xc8\xfd\x3e\x66\xef\xe1\x2e\x10\x4c\xc8\x2d\xb9\xb1\x95\xb3\xd5\xea\x53\xb8\x39\xcf\x4d\xc2\x18\xb8\xf6\x01\xe0\x86\xab\x8f\x93\x0a\x72\xa9\x8c\x35\x99\xa0\xf5\x74\x69\x3e\xa4\x5e\x62\x0a\x64\x07\xec\xd5\x39\x12\x98\xea\xb0\x36\x19\x89\x20\x57\x70\x77\x0f\xcb\x07\x27\xee\xe1\xf3\xed\x0a\xed\x14\x95\x22\x31\xc2\x5d\x63\xc8\x9a\x98\xdc\x2c\x75\x15\xb7\xfe\x81\xb9\xb6\x73\xbb\x9f\x4d\xee\xd1\x2c\x31\xc5\xd4\xdf\x28\xbf\x21\x55\x19\x23\xd3\x1e\xf8\x4c\x04\xc3\x97\x8d\x66\x35\x67\xd2\xff\x64\xd6\x7a\x41\x33\x8f\xbb\xc7\x18\xdd\x54\x80\xe6\xe1\x5b\x7f\x02\xd4\xed\xde\xe0\x73\xeb\x81\x10\xb8\x6b\xf4\xae\x29\x6f\x0a\x07\x42\x0c\x84\x24\x65\x28\x72\xc1\xb1\x51\x87\x8d\x52\x0a\xb9\x05\x3a\x8a\x5c\xd7\x75\xe1\x49\xd9\x84\x11\x5e\x23\xc2\xfc\xeb\x3b\xf3\x7e\xad\xef\x94\xfd\x67\xdf\xb4\x59\x83\x9d\x7e\x48\x47\x10\x30\xa2\x94\x67\xcd\x1f\xaa\x55\x1b\xb8\xb2\x52\x3c\xff\xe6\xf3\xe6\xee\x17\xe1\x4d\x43\xcf\x62\xa6\x4b\xb4\xc0\x84\xb8\xe0\x9e\xe5\x14\xe3\x57\x41\x42\x18\x43\x1e\xa3\x77\x77\x07\xf6\x62\x04\xb3\x99\x65\xda\xdc\x44\x84\x9e\x75\xf9\xe9\xea\xcb\x0a\xa4\xb9\xfb\xc9\x1e\x14\x4f\x27\xcf\x32\x87\x69\x95\x3d\x68\xdf\x49\xf6\xea\x72\x77\x77\x40\xa3\x8a\x90\xd9\xac\x8e\x91\xd5\x20\x2a\x0f\x0a\x59\x6b\x70\x77\xb7\xdc\xd6\x77\xb2\x0d\x58\x64\x0a\x0b\x6c\x89\x24\xd7\xc9\x03\xe0\x83\x4b\x66\x2a\x06\x30\x11\x03\xe5\x40\x62\x42\x39\x68\x51\xb4\xe1\x94\xe7\x68\x37\xaa\xe0\xe1\x06\x6c\x19\x92\x65\x37\x8f\x29\xa1\xcc\x02\x3d\xcd\x96\x83\x8c\x91\x00\x13\xc1\x42\x94\x8b\xb9\x11\x61\x39\x7a\x56\xe1\x91\x91\x32\xe4\x3a\x75\x6d\x35\xdc\x8c\x28\x35\x16\x32\xac\xa0\x97\xe3\x1a\xfa\x62\x7a\x1d\x8b\x11\x1f\x59\x45\x67\x95\x4c\xd6\xa0\xa6\x63\x31\x3d\xd7\x11\x24\x18\x0c\x7d\x31\x59\x58\xab\x65\x8e\xd6\xfc\xf8\x2a\x61\x98\xcd\xa0\x10\xc4\x70\x41\x8f\x33\x78\x8f\x98\x41\x8a\xa0\x68\xcc\x31\x04\x13\x06\x85\x05\x75\xab\x8c\xf7\x81\x92\xd1\x05\x45\xb6\x8d\x56\x13\xa6\xc6\xab\x21\x4e\xad\x15\x2e\x8a\x61\x69\x66\xd9\xca\x6e\xb0\x37\x7f\xee\x96\x32\x2a\xf7\x53\xaa\xad\x41\x11\xe0\x7d\xa7\x5c\xdb\x30\x46\x12\x13\xe6\x76\x26\xc5\x88\x86\xe6\x3d\x64\xdd\xa4\xac\x62\x70\x5e\x2c\xac\x41\x9f\x40\x22\x31\xf2\x2c\x47\xd0\x30\x70\x8c\x47\x6f\xcf\x60\x36\xab\x27\xd1\x0f\xf5\x2c\x1a\x9c\xce\x43\x0c\xc6\x54\x27\x26\xac\xec\x8f\x24\xc5\x22\xaa\xc9\xe0\xb1\x61\xb7\x61\xcc\x2a\x57\x5d\x91\x99\x9c\xb6\xe6\x8d\xfe\xd2\xce\xa7\x75\xb1\x82\x10\x6b\x70\x45\x63\x6e\x92\xa0\xb0\x87\xc0\x7c\xb5\xd1\x9a\x06\x12\x3e\x0a\x0d\x12\x63\xaa\x34\x4a\x0c\x5f\xc1\x92\x94\x6a\xb6\x46\xc7\x92\x8c\x82\x0b\x89\x44\x23\x10\x0e\x24\x08\x44\xce\xf5\x63\xb5\x5e\x08\x19\x0b\x0d\x53\x91\x4b\xa8\xe2\xbe\xae\x5b\xa1\xbe\x47\xf1\x67\x54\xa8\x81\x6e\xea\xeb\x3b\xa6\x40\xce\xeb\xa8\x13\xd2\xd1\x60\xa7\xfa\xa7\x02\x49\x33\x5d\x2e\xb5\xa2\x9c\x17\x85\x13\x5a\xed\x95\xc2\x4e\x23\x68\x3d\x19\x53\x1e\x8a\xb1\x7d\x99\xfb\x8c\x06\xef\x71\x7a\x2a\x31\xc4\xe2\x35\x6a\x55\xd4\x5c\x12\x75\x2e\xf9\x7a\xc1\x37\xf7\x02\xde\x3c\x60\x42\x6c\xa9\xf5\xad\x0a\x3c\x50\xb6\xc4\xa2\x02\xb4\x9c\xae\x13\x77\xc0\xda\xb5\xda\xcb\xa9\xbf\x8a\x29\xc7\x5a\x7b\x6b\x1c\x27\x94\x21\xb4\x94\x6d\xb8\xd0\x09\xfc\x08\x87\xeb\xd8\xe6\x52\xb0\xeb\x81\xe5\x59\xf5\xcd\xf5\x28\x2c\xcd\x87\xaf\x94\xeb\xe3\x13\x29\xc9\xd4\x8e\xa4\x48\x5b\x44\x0b\xbf\xa5\xda\x9d\xa5\x13\xad\xa0\x49\xc5\x7c\x7b\x60\x42\x42\x9e\x8a\x10\x4f\x74\xcb\x5d\xb3\x76\xd6\xbe\x97\x1d\xe4\x05\x3b\xfe\x3a\xfc\x1c\xda\xd7\x82\xb4\xae\xb4\xa4\x3c\x2e\x4c\x3b\x9d\x2b\xb2\x49\x96\xb1\x69\x8b\xe7\x8c\x75\x80\xe3\x78\xc5\x85\x96\xdf\x6e\xb7\x6b\x58\xe6\x5e\xb2\xfa\xe7\x6e\x41\x6b\x77\x95\xe9\x3f\x9d\x62\xee\xaf\xd5\x39\x6f\xf7\x07\xa7\x03\x96\xd5\x68\x7f\x28\x82\x3c\x45\xae\xed\x18\xf5\x39\x43\xf3\xf3\xf5\xf4\x6d\xd8\x5a\x4f\xe0\xb6\x5d\x66\x30\x78\xe6\x7b\xc9\x6a\x83\xfb\x20\x42\x99\xdb\x6d\x9b\x84\xe1\xf9\x08\xb9\xfe\x60\x12\x94\xa3\x6c\x59\x01\xa3\xc1\xd0\x5a\x3d\x1d\x5c\xa7\x0f\xed\x4c\xa2\xd9\x75\x86\x11\xc9\x99\x6e\xad\x78\x61\xee\x08\x75\x90\xb4\xe6\x2d\x83\x33\x57\x69\x75\xe0\x2e\x58\x84\xba\xea\x81\xa5\x48\x8a\x5d\x21\xa9\xb1\x64\xd6\xb6\x75\x82\x7c\x25\x6f\x24\xaa\x7b\xa2\x42\xa2\xb2\x6f\x94\xe0\xeb\xaa\x37\x71\xca\x62\xd7\x88\x35\x5f\xb2\xb3\x2a\x15\x97\x45\x00\xbc\x2a\xb5\xee\x11\x5a\xd3\x6d\xee\x06\x71\xc2\x98\x18\x2f\x93\x5c\xc1\xdf\x7f\xc3\xbf\xfe\xdd\x36\xef\x0c\xe7\x24\x48\x5a\x0f\xa4\x81\xb9\x02\x9b\x86\x4b\x8b\xcc\xa8\x41\xf5\xac\x61\x6e\xce\x16\x27\x23\x1a\x13\x2d\xa4\xbd\x72\x02\x26\x36\x16\xec\x3c\xc4\xa2\xd9\xd7\x64\xdd\x43\x81\x66\xb5\xed\xa2\x41\x00\x0f\xde\x5d\x7d\xfa\x68\xab\x22\xdb\x68\x34\x6d\x35\x7b\x2a\xc9\xf8\x6d\xd8\xab\x12\xd7\x68\xb5\x8b\xa9\x76\xa7\x59\x1c\x55\x26\xb8\xc2\xde\x16\xe2\xcc\x1d\x30\xf3\xe1\xed\x8c\x68\x62\x2c\x58\xc3\x9e\xef\xb7\xeb\x42\x5b\xb4\x99\xdb\x34\x8f\x86\xc0\xc0\xd0\x69\xe4\xb7\x00\x6e\xc8\xdd\x83\x69\x3a\x21\xa2\x73\x89\x5b\xb0\x16\xeb\xf7\x60\xe4\x0a\xe5\x1b\xc2\x43\x86\x3d\xa8\xef\x5e\xae\xc0\xab\x66\xfc\xa5\x44\x1b\x7a\x60\x59\x8d\x4a\x66\x3b\x6b\x13\xb5\xe2\xfb\x60\x3c\x54\x05\xa7\xec\xb6\x1a\xd2\x36\x20\xba\x96\x0c\x4d\xd1\xe6\x38\xf0\x25\x41\x30\xf6\x42\x40\x78\x80\x8c\x61\xd8\x01\x21\x21\x21\x0a\xb8\xa8\x9a\x13\x48\x50\xa2\x5d\xc8\x56\x4f\xff\xf2\x4d\x4e\x69\xca\x18\x8c\x85\x1c\x2a\x7b\x67\x9b\x2f\xd5\xef\x59\xdb\x98\xd9\x77\xaa\x07\x7b\xf1\x51\xff\xe6\x97\x1c\xe5\x14\x22\x2a\x95\xee\x80\x39\x63\xf8\x82\x3a\x31\x9f\x06\x8a\xc1\x6b\x21\xb4\xd2\x92\x64\xf0\xee\xca\x2e\xbe\xf7\xf7\xcb\xfd\xa0\x64\xe0\x59\xd5\x07\x74\x73\xca\xf6\xcd\x37\x83\x55\x7c\x3b\x2f\x7f\x76\x0f\xec\x3d\x7b\xcf\x56\x8c\xa6\x76\x4a\xb9\x7d\xa3\x96\x67\x41\xb9\xc6\x58\x52\x3d\xf5\x2c\x95\x90\x83\xe3\xc3\xee\xc9\xf3\x8b\x3f\x6e\x9e\x8f\x76\x43\x47\x85\xe9\xff\x7f\xcb\x1c\xfe\xe9\x97\x31\xa3\x1f\x46\x5f\xd5\xbb\xe8\xec\xcd\xf5\xee\xf0\xc5\xa7\x34\x76\x88\x73\x9e\xe0\x49\x18\xeb\xdb\x8f\xea\x20\xc9\x22\x12\x3f\x3b\x0f\x5f\x1c\xb9\x7c\x89\x1d\x48\xa1\x54\x59\x89\x3d\x8b\x70\xc1\xa7\xa9\xc8\x95\x35\x58\xf1\xbd\xd1\x89\x90\xdf\x28\x3b\x60\x22\x0f\x23\x46\x24\x16\x9e\x90\x1b\x32\x71\x18\xf5\x95\xa3\x0b\x5e\x9c\x3d\xfb\xd0\x76\x9d\x9b\x6a\xfc\x08\xc7\xce\x6e\x75\x78\x72\xf9\xfa\xfa\xf2\xf3\x6f\x57\x27\xce\x01\xfe\x7e\x7e\xfe\xf5\x5a\x5e\x9f\x4e\x9f\xff\x7c\xf4\xfe\xc2\xc7\xe3\xe8\xe2\x66\x78\xf4\xee\xe4\xed\xe4\xeb\xef\x6f\xde\x0f\xcf\x26\xcf\x7e\xa1\x7c\xef\x6c\x78\x3d\x39\xda\xf3\x5f\x4b\xff\xbb\x1d\x4b\xc9\x24\x08\xb9\xed\x57\x67\x69\x06\xc6\xb7\xc5\x84\x73\x68\xbb\xb6\xdb\x25\x2c\x4b\x88\xfd\xcc\x38\xb7\x58\x7a\x84\x7f\xa3\xd7\xd7\xd7\xb7\xec\x8f\x77\xc7\x48\x5e\x90\xd3\xdf\x0e\xb3\xf3\xeb\x03\xf9\xeb\x9b\x9b\xf8\x46\x3f\xbf\xcd\x86\x1f\xb3\x3f\x86\xbb\xee\xfe\xd9\x8b\x2c\xb9\x9d\xe2\xaf\xc3\xf3\xdd\x1b\xe1\x52\xfc\x99\xde\x7e\xbb\xfc\x70\x21\xe4\x7f\x75\x70\x3b\x7d\x67\xfe\x3d\xc0\x49\x74\xca\x06\xff\x19\x00\x81\xa9\x73\x28\xc6\x1a\x00\x00")
 
 func tmplLoginHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -147,12 +509,52 @@ func tmplLoginHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "tmpl/login.html", size: 3837, mode: os.FileMode(420), modTime: time.Unix(1495035516, 0)}
+	info := bindataFileInfo{name: "tmpl/login.html", size: 6854, mode: os.FileMode(420), modTime: time.Unix(1792193026, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _tmplLogin_2faHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x57\xfb\x77\xd3\xb8\x12\xfe\xbd\x7f\xc5\x60\xce\x9e\x03\x4b\xfc\x48\x93\xb4\x25\xd8\xe1\x96\x3e\x96\xcb\x72\xa1\x50\xd8\x2e\xfc\x26\x5b\x63\x5b\x89\x2c\x19\x49\x4e\xf3\xb8\xfd\xdf\xef\x91\x13\xb7\xcd\x8b\xed\x3d\xa5\xf6\x69\x22\x8d\xf4\x49\xf3\xcd\xa7\xd1\x24\x7c\x72\xfa\xf1\xe4\xcb\xb7\x8b\x33\xc8\x4d\xc1\x07\x7b\xa1\xfd\x00\x4e\x44\x16\x39\x28\x1c\xdb\x81\x84\x0e\xf6\x00\x00\xc2\x27\xae\x0b\x9f\xf1\x47\xc5\x14\x52\x28\xd0\x10\x30\x24\xd3\xe0\xba\x4b\x7b\xdd\x95\xe4\x44\x69\x34\x91\x53\x99\xd4\x3d\x72\xee\x9b\x04\x29\x30\x72\xc6\x0c\xaf\x4b\xa9\x8c\x03\x89\x14\x06\x85\x89\x9c\x6b\x46\x4d\x1e\x51\x1c\xb3\x04\xdd\xba\xd1\x02\x26\x98\x61\x84\xbb\x3a\x21\x1c\xa3\x76\x0b\x74\xae\x98\x18\xb9\x46\xba\x29\x33\x91\x90\x0d\xb4\x61\x86\xe3\xe0\xbd\xcc\x98\x80\xff\xc2\xa5\xa9\x28\x93\x95\xe6\xd3\xd0\x5f\x58\x16\xa3\xb4\x99\x36\xdf\xed\xf3\x2f\x56\xd8\x3d\x40\xa5\xf8\xb3\xdc\x98\x52\xf7\x7d\x3f\x95\xc2\x68\x2f\x93\x32\xe3\x48\x4a\xa6\xbd\x44\x16\x7e\xa2\xf5\xeb\x94\x14\x8c\x4f\xa3\xcf\x32\x96\x46\xf6\x3b\x41\xf0\xfc\xd5\xde\x2d\x52\x2c\xe9\x14\xe6\xb7\x4d\xfb\xc6\x24\x19\x65\x4a\x56\x82\xf6\xe1\xe9\xe1\x41\x7c\xd4\xdb\x7f\x05\xfe\xef\x90\x12\xce\xad\x0d\x52\xa9\x40\x72\x0a\xb1\x92\xd7\x1a\x95\x86\xdf\xfd\x9d\x00\xee\x35\xc6\x23\x66\x5c\xce\x04\x12\xe5\x66\x8a\x50\x86\xc2\x3c\x53\x2c\xcb\x4d\xab\xc1\x6f\xc1\xd3\xa3\xd3\x93\xfd\x83\xf3\xe7\xaf\x76\x23\x15\x72\xf6\x2b\x60\xe4\x2f\x00\x59\x47\x30\x12\x38\xa6\xff\x8c\x61\x63\xe4\x2e\xe2\xd1\x07\x67\x11\x11\xa7\x05\x9a\x08\xed\x6a\x54\x2c\x5d\x1d\x2e\xc7\xa8\x52\x2e\xaf\xfb\x90\x33\x4a\x51\xac\x5a\x1b\x6a\x6b\x50\x5d\x48\x69\x72\x26\xb2\x3e\x10\x61\x95\xc7\x88\x46\xba\x36\xc1\x32\x28\xf5\x64\x63\x46\xa6\xc8\xb4\x16\xea\xdd\xf8\x9b\x3b\x89\x78\xd7\x8a\x94\x25\xaa\x35\x99\xd4\x42\xef\x43\xe7\x20\x28\x27\xab\xeb\x94\x84\xd2\x1a\xf7\xe8\x37\x08\x20\x58\x35\x16\x44\x65\x4c\xf4\x81\x54\x46\x6e\x5f\xae\x24\x02\xf9\xda\x62\xa5\xd4\xcc\x30\x29\xfa\xa0\x90\x13\xc3\xc6\xb8\x8a\x3a\x73\x99\xa0\x38\xe9\x43\x7b\x77\xd0\x9e\x9e\xd7\x7f\xab\x03\x0a\x32\x71\x77\x7b\xd2\x6c\x36\xa8\xb7\x0b\xed\x60\xb7\xaf\xdd\xde\xba\xc9\xe0\xc4\xb8\x84\xb3\x4c\xf4\x21\x41\x61\x50\xad\xda\x63\x39\x71\x75\x4e\xa8\x8d\x6f\x00\x01\xec\x07\xe5\x04\x02\x50\x59\x4c\x9e\x05\x2d\x58\xbe\xde\xfe\xf3\x16\x04\xd0\x2b\x27\xd0\xdb\x6e\xef\x3e\xdf\xca\x63\x2a\x55\x01\x4c\x94\x95\x81\xf9\xa3\x44\x58\x19\x2b\xf7\x3e\x04\x3f\xa1\x36\xdd\xb7\xcf\xab\x6d\x02\x69\x07\xc1\x6f\x6b\x33\xa5\xa2\xa8\xfa\xbb\x94\x61\xb9\x68\xf7\x76\x12\xbd\x69\xaa\x89\x64\xb3\x5a\x72\x0b\x6c\x37\x96\x6b\x63\x16\x92\x67\x33\xec\x43\xbb\x5b\x4e\x76\x33\x16\x57\xc6\x48\xf1\x38\xca\xea\xc8\x1b\x45\x84\xb6\x41\xe8\x43\x65\x8f\x4f\x42\xf4\x9a\x68\x1f\xc4\x6c\xf7\xe4\xf8\xbc\x17\x3c\x8e\xd9\x9f\x70\x97\x48\x2e\xd5\x8e\xb3\xb1\x93\xb3\xfb\xd9\xa7\x76\x73\x79\x36\x09\xe7\x10\x78\x1d\xc0\x0d\x57\x1f\x36\x2a\xa9\x94\xb6\xbb\x29\x25\x5b\x3d\x2e\xdb\x83\xd4\xcf\x6d\x82\x6c\x81\x77\xbf\x8f\x24\x36\x3b\xac\x75\xa6\x32\xa9\x34\xcc\x7f\xc2\x72\xe7\x38\xe8\x1e\xee\x5e\xd0\x2b\x50\x6b\x92\x21\xcc\xb7\x4a\xd6\x6a\x72\x33\xd5\x35\xdc\xc6\x1d\xfb\xec\xe6\x76\xbf\x9c\x3c\x60\x65\x02\xf3\xad\xe8\xdb\x04\x52\x0b\x90\x62\x22\x15\x59\x70\x2e\xa4\xc0\xad\x6b\x78\xa8\x94\x54\x3b\xa0\xd3\x34\x08\x82\x00\x9e\x2c\xaa\x0d\x22\xcc\x7d\x08\xfb\x3f\xf4\x97\x85\x49\xe8\x2f\x0a\xad\xd0\xd6\x13\x83\xbd\x90\xb2\x31\x24\x9c\x68\x1d\x39\xcb\xdb\xa3\xa9\x77\xee\x59\xea\x44\xbf\xec\xb7\x6f\x58\xc7\x91\xd1\xc8\xe1\xb6\x1c\x72\xc0\xc6\x52\x8a\xc8\xf1\xeb\xb6\xbf\x9f\x92\xd7\x49\x4e\x38\x47\x91\x61\x34\x9f\x83\x77\xdb\x82\x9b\x1b\xc7\xd6\x74\xb9\xa4\x91\x73\xf1\xf1\xf2\xcb\x3d\x58\xfb\x86\x79\x1b\xea\x54\x1c\x39\xf6\xa2\x76\x06\x5f\xae\xa5\x9b\x92\xc4\x48\x65\x53\x7b\x8e\xc2\xb0\xa4\xe6\x2a\xf4\xf3\xf6\xea\xdc\xf9\x1c\x58\xda\x10\x75\x73\xb3\x8a\x5b\xae\xc0\x36\x9e\xd5\x63\x9d\xc1\x7c\x7e\x37\x2d\xf4\xcb\x0d\x58\x14\xf4\x1f\x00\x07\x67\xf6\x20\x80\xc9\x11\x12\x49\x11\x52\x25\x0b\x98\xca\x6a\x65\xd7\xd6\x87\xb2\x6c\x81\x2d\xcb\x04\x82\x4c\x17\x23\x14\x26\xf6\x7c\x4c\xeb\x99\xda\xdb\xd8\x40\xb8\xb8\x1c\x16\x65\xad\x1d\xe3\x80\x99\x96\x18\x39\x56\x3d\x0e\x94\x9c\x24\x98\x4b\x4e\x51\x35\x66\x7b\x09\x26\xb2\x28\x39\x1a\x8c\x1c\x29\xd0\x35\xac\x40\xf7\xce\x58\x9f\x33\x7f\xc3\x4f\x2f\xd1\x2a\x3d\x67\xc8\x37\xdd\x5d\xa6\xdb\xc5\xc2\xba\x8a\x0b\x66\x9c\xc1\xd8\xa6\xd4\x69\xe8\x2f\x8c\x6b\xbb\x2e\x1b\x92\x97\x07\xc3\x19\x7c\x90\xc6\x7a\xfc\x1a\x42\x02\xb9\xc2\xb4\x11\xcc\x8a\x58\xee\xb4\x72\x73\xe3\x0c\x2e\x0d\x51\x06\x2c\x3d\xa1\x4f\x06\x2b\xd4\x84\xbe\x95\xe1\x52\xad\x3e\x65\xe3\xc1\x5e\xf3\x61\x7f\x41\x0c\x3f\x55\xa8\xa6\x90\x32\xa5\x4d\xcb\x06\x46\xc0\x17\x34\xb9\xcd\x43\x75\xe3\x8d\x94\x46\x1b\x45\x4a\x78\x77\xe9\xd5\x3f\x2e\x42\x9d\x28\x56\x1a\xd0\x2a\x89\x9c\xa6\x5a\xb7\x9c\x79\xc3\x1f\x16\xab\x2e\xd4\x17\x5f\xdd\x8e\xd7\xf6\xda\x9e\xe6\xac\xf0\x0a\x26\xbc\xa1\x76\x6e\xf7\x65\x53\x62\xa6\x98\x99\x46\x8e\xce\x49\xe7\xa8\xeb\x1e\x1f\x9e\x7f\x1f\x1e\x8e\x5f\x50\x5f\xd3\xe2\x3f\x3f\x4a\x5f\x7c\xfc\x74\xcd\xd9\xfb\xf1\x57\xfd\x2e\x3d\x7d\x7b\xf5\x62\xf4\xf2\x63\x91\xf9\xc4\x3f\xcb\xf1\x98\x66\x66\xf6\x41\x77\xf2\x32\x25\xd9\xc1\x19\x7d\xd9\x0b\xc4\x1d\x76\xa2\xa4\xd6\x52\xb1\x8c\x89\xc8\x21\x42\x8a\x69\x21\x2b\xed\x0c\x42\x7f\xb1\xf7\x5d\x4e\x50\x31\xd4\x5e\xc2\x65\x45\x53\x4e\x14\xd6\x9e\x90\x21\x99\xf8\x9c\xc5\xda\x37\x35\x2f\x7e\xdb\xeb\x7a\x81\x3f\x6c\xda\x0f\x70\xec\x74\x66\xe8\xf1\xc5\x9b\xab\x8b\xcf\x7f\x5f\x1e\xfb\x1d\xfc\x76\x76\xf6\xf5\x4a\x5d\x9d\x4c\x0f\xff\xe8\xfd\x79\x1e\xe3\x51\x7a\x3e\x1c\xf5\xde\x1d\xff\x7b\xf2\xf5\xdb\xdb\x3f\x47\xa7\x93\x83\x4f\x4c\xb4\x4f\x47\x57\x93\x5e\x3b\x7e\xa3\xe2\x47\x3b\x56\x90\x49\x42\x85\x17\x37\xb1\xb4\x0d\xeb\xdb\x6d\x87\xdf\xf5\x02\x2f\x70\x09\x2f\x73\xe2\x1d\x58\xe7\x6e\x4d\x0f\xf0\x6f\xfc\xe6\xea\x6a\xc6\xbf\xbf\x3b\x42\xf2\x92\x9c\xfc\xdd\x2d\xcf\xae\x3a\xea\xaf\xb7\xc3\x6c\x68\x0e\x67\xe5\xe8\x43\xf9\x7d\xf4\x22\xd8\x3f\x7d\x59\xe6\xb3\x29\xfe\x35\x3a\x7b\x31\x94\x01\xc3\x3f\xd8\xec\xc7\xc5\xfb\x73\xa9\xfe\xaf\xc0\xed\x85\xfe\x32\x27\xfb\xb9\x29\xf8\xe0\x7f\x03\x00\x4f\xca\xf9\x88\x33\x0f\x00\x00")
+
+func tmplLogin_2faHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_tmplLogin_2faHtml,
+		"tmpl/login_2fa.html",
+	)
+}
+
+func tmplLogin_2faHtml() (*asset, error) {
+	bytes, err := tmplLogin_2faHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "tmpl/login_2fa.html", size: 3891, mode: os.FileMode(420), modTime: time.Unix(1792189846, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _tmplLogoutHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x54\x5d\x6f\xab\x38\x10\x7d\xcf\xaf\x98\x25\x5a\xa9\xa9\x42\x20\x69\xd3\x8d\x28\x61\x77\xd5\x6e\xb4\x0f\x2b\x75\xb5\xed\x3e\xf4\x71\xc0\x03\x58\x35\x1e\x64\x9b\x84\xf4\xea\xfe\xf7\x2b\xf2\xd5\x26\x6d\x74\x5f\x0a\x23\x19\x7c\xc6\xc7\xf6\x9c\xd1\x89\x7f\xb9\x7f\xb8\x7b\x7a\xfe\xf7\x2f\x28\x5d\xa5\x92\x5e\xdc\x0d\xa0\x50\x17\x73\x8f\xb4\xd7\x4d\x10\x8a\xa4\x07\x00\x10\x57\xe4\x10\xb2\x12\x8d\x25\x37\xf7\xfe\x7f\x5a\xf8\x33\x6f\x07\x39\xe9\x14\x25\xff\x70\x51\x90\x80\x87\xc6\x81\x0f\x8f\xae\x11\x92\x1b\xab\xd6\x71\xb0\x85\xb7\xa9\xd6\xad\xf7\xdf\xdd\xfb\x87\xac\x6a\x36\x0e\x1a\xa3\x2e\x4a\xe7\x6a\x1b\x05\x41\xce\xda\xd9\x51\xc1\x5c\x28\xc2\x5a\xda\x51\xc6\x55\x90\x59\xfb\x7b\x8e\x95\x54\xeb\xf9\x7f\x9c\xb2\xe3\xe8\x2a\x0c\x07\xb7\xbd\x03\x53\xca\x62\x0d\xdf\x0e\xbf\x5d\xa4\x98\xbd\x14\x86\x1b\x2d\x22\xe8\xff\x76\x93\xce\xa6\x93\x5b\x08\x2e\x21\x47\xa5\x3a\x0c\x72\x36\xc0\x4a\x40\x6a\x78\x65\xc9\x58\xb8\x0c\xce\x12\xf8\x2b\x4a\x5f\xa4\xf3\x95\xd4\x84\xc6\x2f\x0c\x0a\x49\xda\x5d\x18\x59\x94\x6e\xb8\xe7\x1f\x42\x7f\x76\x7f\x37\xb9\x59\x0c\x6e\xcf\x33\x55\xfc\xfa\x15\x34\xfc\x05\x24\xa7\x0c\x8e\x41\x51\xfe\x73\x8e\x4e\x23\x7f\xab\x47\x04\xde\x56\x11\x6f\x08\x16\xb5\xf5\x2d\x19\x99\x1f\xa7\xf3\x92\x4c\xae\x78\x15\x41\x29\x85\x20\x7d\x8c\xee\x4b\xbb\x21\xb5\x15\xb3\x2b\xa5\x2e\x22\x40\xed\x24\x2a\x89\x96\xc4\xc9\x82\xae\x82\x6c\xdb\x0f\x2b\x0a\x83\x6b\x9b\xa1\xa2\xb7\xfc\xef\x6f\x2d\x32\x5a\x19\xac\x6b\x32\x27\x6d\xb2\x92\xc2\x95\x11\x5c\xdd\x84\x75\x7b\xbc\x4f\x8d\x42\x6c\x78\x67\xbf\x42\x08\xe1\x31\x58\xa1\x29\xa4\x8e\x00\x1b\xc7\x9f\x6f\x57\xa3\x26\x75\xb2\x59\xcd\x56\x3a\xc9\x3a\x02\x43\x0a\x9d\x5c\xbe\x3b\x6a\x17\xaf\xbe\xd4\x82\xda\x08\xc6\xe7\x45\xeb\x2f\x36\xcf\x71\x42\x85\xad\x7f\xfe\x26\xfb\xc3\x86\x9b\xe3\xc2\x38\x3c\x7f\xd7\xeb\xe9\x29\x94\x72\xeb\xdb\x12\x45\xa7\x5f\x08\x21\x4c\xc2\xba\x85\x10\x4c\x91\xe2\x45\x38\x84\x5d\x8c\x26\x83\x21\x84\x30\xad\x5b\x98\x7e\x8e\x5f\x0f\x3e\xad\x13\x9e\x94\x28\x63\xc5\x26\x82\xfe\xf5\xdd\x9f\x8b\xe9\x49\xd1\x1d\xb5\xce\x17\x94\xb1\xc1\x6d\x15\x35\xeb\x8f\x62\xc7\xc1\xce\x66\xe2\x60\x6b\x60\x71\xe7\x0e\x49\x2f\x16\x72\x09\x99\x42\x6b\xe7\xde\xae\x17\xf6\x16\xf6\x0e\xd9\xc8\xb6\x9b\xef\x22\x2e\xc7\xc9\x23\xd1\x1a\x47\x71\x50\x8e\xdf\xcd\xd7\xc9\x33\x37\x50\xe2\x92\x20\x25\xd2\xa0\xb6\x06\xc8\x8d\x1b\xc5\x41\x7d\x94\x18\x23\x94\x86\xf2\xb9\xb7\x37\x39\x7b\xf0\xc7\x51\xc6\x5e\xf2\x37\x57\x14\x07\x98\x1c\xd6\xc5\x81\x90\xcb\xa4\x77\x18\x76\x17\x08\x4a\x57\xa9\xe4\xc7\x00\x06\xad\xfd\x2f\xb8\x05\x00\x00")
+
+func tmplLogoutHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_tmplLogoutHtml,
+		"tmpl/logout.html",
+	)
+}
+
+func tmplLogoutHtml() (*asset, error) {
+	bytes, err := tmplLogoutHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "tmpl/logout.html", size: 1464, mode: os.FileMode(420), modTime: time.Unix(1792189005, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _tmplRegisterHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xac\x57\x5b\x6f\xdb\x38\x13\x7d\xcf\xaf\x98\xb2\x28\xd0\x14\x96\x45\xe7\xd2\x06\x8a\xe4\x7e\x45\xfa\xe5\x69\x81\x16\x6d\x5f\xf6\x91\x16\x47\x12\x11\x8a\xd4\x92\x54\x6c\xc7\x9b\xff\xbe\xa0\x2e\x89\x25\x5b\xd9\x4b\x2b\x25\x52\x38\x43\x1e\x92\x67\x0e\x47\x93\xf8\xd5\xe7\x2f\x37\x3f\x7e\xff\xfa\x7f\x28\x5c\x29\x97\x27\xb1\x7f\x81\x64\x2a\x4f\x08\x2a\xe2\x0d\xc8\xf8\xf2\x04\x00\x20\x7e\x15\x04\xf0\x0d\xff\xa8\x85\x41\x0e\x25\x3a\x06\x8e\xe5\x16\x82\xa0\xf3\x37\xa6\xb4\x60\xc6\xa2\x4b\x48\xed\xb2\xe0\x8a\xec\xbb\x14\x2b\x31\x21\xf7\x02\xd7\x95\x36\x8e\x40\xaa\x95\x43\xe5\x12\xb2\x16\xdc\x15\x09\xc7\x7b\x91\x62\xd0\x34\x66\x20\x94\x70\x82\xc9\xc0\xa6\x4c\x62\xb2\x98\x81\x2d\x8c\x50\x77\x81\xd3\x41\x26\x5c\xa2\x74\x0f\xed\x84\x93\xb8\xfc\x2e\x72\x05\x75\x05\x7f\xc2\x77\x57\x73\xa1\x6b\x2b\xb7\x71\xd8\xfa\xda\x7e\xd6\x6d\xfb\xbf\xfd\xf5\x3f\x51\xfa\x55\x40\x6d\xe4\xdb\xc2\xb9\xca\x46\x61\x98\x69\xe5\xec\x3c\xd7\x3a\x97\xc8\x2a\x61\xe7\xa9\x2e\xc3\xd4\xda\x8f\x19\x2b\x85\xdc\x26\xdf\xf4\x4a\x3b\x1d\x9d\x53\x7a\x7a\x7d\xf2\x84\xb4\xd2\x7c\x0b\xbb\xa7\x66\x63\x62\xe9\x5d\x6e\x74\xad\x78\x04\xaf\x3f\xbc\x5f\x5d\x5d\x9e\x5d\x43\xf8\x0e\x32\x26\xa5\xf7\x41\xa6\x0d\x68\xc9\x61\x65\xf4\xda\xa2\xb1\xf0\x2e\x9c\x04\x08\xd6\xb8\xba\x13\x2e\x90\x42\x21\x33\x41\x6e\x18\x17\xa8\xdc\x5b\x23\xf2\xc2\xcd\x7a\xfc\x19\xbc\xbe\xfa\x7c\x73\xf6\xfe\xf6\xf4\x7a\x1a\xa9\xd4\x0f\xbf\x02\x46\xff\x02\x90\x31\x82\xd3\x20\x31\xfb\x7b\x0c\x1f\xa3\xa0\x8d\x47\x04\xa4\x8d\x08\x99\x81\x65\xca\x06\x16\x8d\xc8\x86\xdd\xf5\x3d\x9a\x4c\xea\x75\x04\x85\xe0\x1c\xd5\xd0\xdb\x53\xdb\x80\xda\x52\x6b\x57\x08\x95\x47\xc0\x94\xd7\x9e\x60\x16\xf9\x68\x80\x67\x50\xdb\xcd\xc1\x88\xdc\xb0\x6d\x23\xd5\xe7\xfe\x8f\xcf\x12\x99\xaf\x0d\xab\x2a\x34\x23\x99\x34\x52\x8f\xe0\xfc\x3d\xad\x36\xc3\x79\x2a\xc6\x79\x83\x7b\xf5\x06\x28\xd0\xa1\xb3\x64\x26\x17\x2a\x02\x56\x3b\x7d\x7c\xba\x8a\x29\x94\xa3\xc9\x2a\x6d\x85\x13\x5a\x45\x60\x50\x32\x27\xee\x71\x88\xfa\x10\x08\xc5\x71\x13\xc1\x62\x3a\x68\xaf\x6f\x9b\x6b\xbc\x9c\x4d\x30\xbd\x93\x7e\xb1\xb4\x59\x2e\x2c\xe8\xf4\x5e\x2f\x2e\xc7\x2e\x87\x1b\x17\x30\x29\x72\x15\x41\x8a\xca\xa1\x19\xad\x4d\x6f\x02\x5b\x30\xee\xe3\x4b\x81\xc2\x19\xad\x36\x40\xc1\xe4\x2b\xf6\x96\xce\xa0\xfb\x99\x9f\x9d\xce\x80\xc2\x65\xb5\x69\x7e\x8f\xf8\x2f\x4e\x8f\xf2\x98\x69\x53\x82\x50\x55\xed\x46\x5c\xfe\x5b\x11\xd6\xce\xcb\x3d\x1a\x07\x72\x40\x6d\x76\xe6\xef\xeb\x63\x02\x59\x50\xfa\x66\xbc\x71\xc3\xd1\x1c\x00\x3e\x93\x4d\x61\x71\xc0\xe6\x13\xd1\x87\xae\x86\x48\xf1\xd0\x78\x5b\xec\x60\xa5\x37\x47\x4e\x9e\x15\x0f\x18\xc1\xe2\x62\x1f\x60\xcc\xd8\xaa\x76\x4e\xab\x9f\xa3\xac\x89\xbc\x33\x4c\x59\x0f\x19\x41\xed\x8f\x4f\xca\x2c\xfe\x07\x66\x2f\x6e\x3e\xdd\x5e\xd2\x9f\x63\xf6\x05\xee\x52\x2d\xb5\x99\x38\x1b\x93\x9c\xc1\x5e\xf6\x69\xb6\xd9\x9d\x4d\x26\x25\xd0\xf9\x39\xe0\xc1\x56\xff\x59\xaf\xb4\x36\xd6\xaf\xa6\xd2\x62\x78\x5c\x8e\x07\x29\x2a\x7c\x82\x9c\xc1\x7c\xdf\xc6\x52\x9f\x1d\x46\xc6\x4c\xa7\xb5\x7d\xe9\x33\x77\x71\xfe\x89\x5e\x7c\x98\x9e\x70\x5e\xa2\xb5\x2c\xc7\x11\x46\x2f\xd9\x45\x7b\x34\xe9\x71\x6e\x57\xe7\xfe\x9e\xe6\xf6\xec\x25\x3d\x3e\xcd\xcc\x46\x73\xf7\xe8\xc7\x04\xd2\x08\x90\x63\xaa\x0d\x6b\x39\x57\x5a\x4d\x24\x77\x34\x46\x8f\x53\x7b\x0f\x9d\x65\x94\x52\x0a\xaf\xda\x6a\x83\x29\xb7\x0f\xe1\x9f\x71\xd8\x15\x26\x71\xd8\x96\x5a\xb1\xaf\x27\x96\x27\x31\x17\xf7\x90\x4a\x66\x6d\x42\xba\xaf\x47\x5f\xf1\xec\x79\x9a\x44\x4f\x9e\xab\x9a\xb8\x4d\x59\x3c\x21\x06\x73\x61\x1d\x1a\x02\x3e\x9c\x5a\x25\x24\xec\x4d\x1f\xd3\x82\x49\x89\x2a\xc7\x64\xb7\x9b\x3f\x35\x1e\x1f\x89\xaf\xea\x0a\xcd\x13\xf2\xf5\xcb\xf7\x1f\x7b\xb0\x0d\x74\xb1\x80\x26\x15\x27\xc4\x7f\xa8\xc9\xf2\xc6\x20\x73\x08\x4c\x01\x4b\x53\x5d\x2b\x17\x87\xc5\x62\x38\x66\xb7\x03\x91\xf5\x04\x3d\x3e\x0e\xf1\xaa\x01\x5c\xbf\xa3\xa6\x2f\x59\xee\x76\xcf\xc3\xe2\xb0\x3a\x80\x45\xc5\x0f\x00\xdb\x4c\xdd\x56\x99\xfe\x49\xc0\x6d\x2b\x4c\x88\x0f\x25\x81\x4a\xb2\x14\x0b\x2d\x39\x9a\xce\x1d\x2e\xa7\xc7\x57\xcc\xda\xb5\x36\xbc\xc7\x78\x6e\x0f\x70\x9e\xcc\x2f\x61\x61\xc9\x84\xec\x81\xba\xc6\x00\xa5\xb1\x01\xe3\xdc\xa0\xb5\x63\x28\xcf\x44\x6a\x4d\x76\x2b\x50\x1e\xee\xb9\xcb\xb5\x2d\xb6\xad\x57\xa5\x70\x64\x99\x36\x91\x89\xc3\xd6\xb9\x1c\xd3\xde\x31\xdd\x9d\x0a\xb2\xfc\x24\x0d\x32\xbe\x85\x5e\x1f\xc8\x3f\x42\xcc\xa0\x30\x98\x25\x24\x94\x3a\x17\x6a\x5a\x32\xcb\xdf\xbc\x3f\x0e\xd9\x72\x10\xa6\x38\xf4\x4a\xec\x04\x1b\x72\x71\xef\x05\xde\xbc\x4e\xe2\xb0\x53\x78\xd8\xfc\xcf\xf1\x57\x00\x00\x00\xff\xff\x90\x0b\xac\xd5\x83\x0c\x00\x00")
+var _tmplRegisterHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x56\x5b\x6f\xdb\x38\x13\x7d\xcf\xaf\x98\xaa\x5f\x81\xa6\xb0\x2c\x39\x97\x36\x50\x24\xf7\x2b\xd2\x0d\xb0\xc0\x62\x5b\xb4\x7d\xd9\xc7\xb1\x38\x92\x88\x52\xa4\x96\xa4\x7c\x89\xd7\xff\x7d\x41\x5d\x92\xc8\xb6\xb2\x01\xd2\x48\xb0\x43\x0e\x79\x38\x3c\x73\x66\x3c\xf1\xab\xcf\x5f\x6e\x7e\xfc\xf5\xf5\x37\x28\x6c\x29\xe6\x27\xb1\xfb\x02\x81\x32\x4f\x3c\x92\x9e\x9b\x20\x64\xf3\x13\x00\x80\xf8\x95\xef\xc3\x37\xfa\xbb\xe6\x9a\x18\x94\x64\x11\x2c\xe6\x06\x7c\xbf\xb3\x37\x53\x69\x81\xda\x90\x4d\xbc\xda\x66\xfe\x95\xf7\xd8\x24\xb1\xa4\xc4\x5b\x72\x5a\x55\x4a\x5b\x0f\x52\x25\x2d\x49\x9b\x78\x2b\xce\x6c\x91\x30\x5a\xf2\x94\xfc\x66\x30\x01\x2e\xb9\xe5\x28\x7c\x93\xa2\xa0\x64\x36\x01\x53\x68\x2e\x7f\xfa\x56\xf9\x19\xb7\x89\x54\x3d\xb4\xe5\x56\xd0\xfc\x3b\xcf\x25\xd4\x15\xfc\x03\xdf\x6d\xcd\xb8\xaa\x8d\xd8\xc4\x41\x6b\x6b\xd7\x19\xbb\xe9\xff\x77\xcf\xff\x79\xe9\xbc\x80\x5a\x8b\xb7\x85\xb5\x95\x89\x82\x20\x53\xd2\x9a\x69\xae\x54\x2e\x08\x2b\x6e\xa6\xa9\x2a\x83\xd4\x98\x8f\x19\x96\x5c\x6c\x92\x6f\x6a\xa1\xac\x8a\xce\xc3\xf0\xf4\xfa\xe4\x1e\x69\xa1\xd8\x06\xb6\xf7\x43\xf7\x2e\x30\xfd\x99\x6b\x55\x4b\x16\xc1\xeb\x0f\xef\x17\x57\x97\x67\xd7\x10\xbc\x83\x0c\x85\x70\x36\xc8\x94\x06\x25\x18\x2c\xb4\x5a\x19\xd2\x06\xde\x05\xa3\x00\xfe\x8a\x16\x3f\xb9\xf5\x05\x97\x84\xda\xcf\x35\x32\x4e\xd2\xbe\xd5\x3c\x2f\xec\xa4\xc7\x9f\xc0\xeb\xab\xcf\x37\x67\xef\x6f\x4f\xaf\xc7\x91\x4a\x75\xf7\x2b\x60\xd4\x2f\x00\xd9\x47\xb0\x0a\x04\x65\xff\x8d\xe1\x62\xe4\xb7\xf1\x88\xc0\x6b\x23\xe2\x4d\xc0\xa0\x34\xbe\x21\xcd\xb3\xe1\x72\xb5\x24\x9d\x09\xb5\x8a\xa0\xe0\x8c\x91\x1c\x5a\x7b\x6a\x1b\x50\x53\x2a\x65\x0b\x2e\xf3\x08\x50\x3a\xed\x71\x34\xc4\xf6\x36\x38\x06\x95\x59\x1f\xec\xc8\x35\x6e\x1a\xa9\x3e\xac\xdf\x3d\x48\x64\xba\xd2\x58\x55\xa4\xf7\x64\xd2\x48\x3d\x82\xf3\xf7\x61\xb5\x1e\x9e\x53\x21\x63\x0d\xee\xd5\x1b\x08\x21\x1c\x1a\x4b\xd4\x39\x97\x11\x60\x6d\xd5\xf1\xe3\x2a\x94\x24\xf6\x0e\xab\x94\xe1\x96\x2b\x19\x81\x26\x81\x96\x2f\x69\x88\x7a\xe7\x73\xc9\x68\x1d\xc1\x6c\x3c\x68\xaf\x6f\x9b\xbf\xe1\x82\x12\xd7\xfe\xf8\x4d\x7a\x67\xc3\xc6\x5d\x98\x85\xe3\x77\xbd\xb8\xdc\x37\x59\x5a\x5b\x1f\x05\xcf\x65\x04\x29\x49\x4b\x7a\x68\x5f\xa8\xb5\x6f\x0a\x64\x2e\xbe\x21\x84\x70\x16\x56\x6b\x08\x41\xe7\x0b\x7c\x1b\x4e\xa0\x7b\xa7\x67\xa7\x13\x08\xe1\xb2\x5a\xc3\xe5\x71\xfb\xc5\xe9\x51\x1e\x33\xa5\x4b\xe0\xb2\xaa\x2d\x6c\x5f\x24\xc2\xda\x3a\xb9\x47\x10\x3e\x41\x6d\x76\xe6\x9e\xeb\x63\x02\x99\x85\xe1\x9b\xbd\x9d\x4a\x33\xd2\xd1\x98\x32\x1c\x17\xb3\xcb\x51\xa2\x0f\x4d\x0d\x91\xfc\xae\x91\x5c\x8b\xed\x2f\xd4\xde\x9a\x56\xf2\xfc\x8e\x22\x98\x5d\x54\xeb\x71\xc6\x16\xb5\xb5\x4a\xbe\x8c\xb2\x26\xf2\x56\xa3\x34\x2e\x08\x11\xd4\x2e\x7d\x52\x34\x7b\xa2\x7d\x16\xb3\x17\x37\x9f\x6e\x2f\xc3\x97\x31\xfb\x04\x77\xa9\x12\x4a\x8f\xe4\xc6\x28\x67\x8f\xab\x4f\x73\xcd\x2e\x37\x51\x08\x08\xa7\xe7\x40\x07\x57\x7d\xde\xaa\xb4\xd6\xc6\x79\x53\x29\x3e\x4c\x97\xe3\x41\x8a\x0a\x57\x20\x27\x30\x7d\x3c\x87\xa9\xab\x0e\x7b\x93\x99\x4a\x6b\x03\xdb\x27\x58\x3e\xff\x14\x5e\x7c\x18\x3f\x70\x5a\x92\x31\x98\x13\x6c\x8f\x4a\xd6\x69\xf2\xb0\xd4\xf5\xdc\x2e\xce\xdd\x33\xce\xed\x59\xb5\x7e\xc6\xc9\x08\xdb\xa3\xe8\xc7\x04\xd2\x08\x90\x51\xaa\x34\xb6\x9c\x4b\x25\xe9\xe8\x19\x53\xd2\x5a\xe9\x11\xe8\x2c\x0b\xc3\x30\x84\x57\x6d\xb7\x81\xd2\x3e\x86\x70\x9f\x71\xd0\x35\x26\x71\xd0\xb6\x5a\xb1\xeb\x27\xe6\x27\x31\xe3\x4b\x48\x05\x1a\x93\x78\xdd\xaf\x47\xdf\xf1\x3c\xb2\x34\x85\xbe\x9b\x77\x6f\xdc\xc4\x91\xb3\xc4\xd3\x94\x73\x63\x49\x7b\xe0\xc2\xa9\x64\xe2\x05\xfd\xd4\xc7\xb4\x40\x21\x48\xe6\x94\x6c\xb7\xd3\xfb\xc1\x6e\xe7\xb9\xae\xae\x50\x2c\xf1\xbe\x7e\xf9\xfe\xe3\x11\xac\x7b\xe3\x62\x06\x4d\x29\x4e\x3c\xf7\x43\xed\xcd\x6f\x34\xa1\x25\x40\x09\x98\xa6\xaa\x96\x36\x0e\x8a\xd9\x70\xcf\x76\x0b\x3c\xeb\x09\xda\xed\x86\x78\xd5\x00\xae\xbf\x51\xb3\xd6\x9b\x6f\xb7\x0f\xdb\xe2\xa0\x3a\x80\x25\xc9\x0e\x00\xdb\x4a\xdd\x76\x99\xee\xd3\x03\xbb\xa9\x28\xf1\x5c\x28\x3d\xa8\x04\xa6\x54\x28\xc1\x48\x77\xe6\x60\x88\x3a\xd8\x5f\xa1\x31\x2b\xa5\x59\x8f\xf1\x30\x1e\xe0\xdc\x4f\x3f\x85\x45\x25\x72\xd1\x03\x75\x83\x01\x4a\x33\x07\xc8\x98\x26\x63\xf6\xa1\x1c\x13\xa9\xd1\xd9\x2d\x27\x71\x78\xe7\xae\xd6\xb6\xd8\xa6\x5e\x94\xdc\x7a\xf3\xb4\x89\x4c\x1c\xb4\xc6\x03\x38\x8d\x32\x27\x98\x56\x5a\x2d\x39\x73\xcd\xe7\x3e\x68\xd5\x07\xa3\x4b\x1c\x6f\x1e\x23\x14\x9a\xb2\xc4\x0b\x14\x67\x69\xe0\x7c\xfa\xfd\x33\xec\x76\x03\x29\xc1\xff\x1e\xc4\x04\xbb\x9d\x37\xbf\x51\xd2\x72\x59\x13\xac\xb8\x2d\xc0\x6d\xfa\x13\x4b\x6a\x22\x8a\xf3\xe7\x46\xf5\xd0\x99\x4f\x42\x13\xb2\x0d\xf4\x7a\x26\xf6\x11\x1e\x1c\x14\x2a\xe7\x72\x5c\xe2\xf3\x3f\x9c\xfd\xc0\x81\x38\x70\x99\xd3\x25\x58\xc0\xf8\x72\x7e\xd2\x7d\x9d\xc4\x41\x97\x91\x41\x61\x4b\x31\xff\x77\x00\x79\x01\x4d\xab\x33\x0d\x00\x00")
 
 func tmplRegisterHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -167,7 +569,67 @@ func tmplRegisterHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "tmpl/register.html", size: 3203, mode: os.FileMode(420), modTime: time.Unix(1495035516, 0)}
+	info := bindataFileInfo{name: "tmpl/register.html", size: 3379, mode: os.FileMode(420), modTime: time.Unix(1792190295, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _tmplResetHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x57\xdb\x6e\xdb\x38\x10\x7d\xcf\x57\x4c\x55\x14\xdb\x14\x96\x25\xe7\xd2\x06\x8a\xe4\x6e\x91\x36\xc0\x02\x0b\xb4\x68\xfb\xb2\x8f\x94\x38\x92\x88\x50\x1c\x2d\x49\xf9\x12\xaf\xff\x7d\x41\xc9\x8e\x2d\xdb\x6a\x03\xb4\xb1\x10\x87\x1c\xf2\xcc\xf0\xcc\x19\x6a\x12\xbf\xf8\xf8\xf9\xee\xfb\x3f\x5f\x3e\x41\x69\x2b\x39\x3d\x8b\xdd\x17\x48\xa6\x8a\xc4\x43\xe5\xb9\x09\x64\x7c\x7a\x06\x00\x10\xbf\xf0\x7d\xf8\x8a\xff\x36\x42\x23\x87\x0a\x2d\x03\xcb\x0a\x03\xbe\xbf\xb1\xb7\x53\x59\xc9\xb4\x41\x9b\x78\x8d\xcd\xfd\x1b\x6f\xdf\xa4\x58\x85\x89\x37\x13\x38\xaf\x49\x5b\x0f\x32\x52\x16\x95\x4d\xbc\xb9\xe0\xb6\x4c\x38\xce\x44\x86\x7e\x3b\x18\x81\x50\xc2\x0a\x26\x7d\x93\x31\x89\xc9\x64\x04\xa6\xd4\x42\x3d\xf8\x96\xfc\x5c\xd8\x44\xd1\x16\xda\x0a\x2b\x71\xfa\x15\x0d\x5a\xa8\x99\x31\x73\xd2\x1c\xfe\x83\x6f\xb6\xe1\x82\x1a\x23\x97\x71\xd0\x2d\xe9\x96\x1b\xbb\xdc\xfe\xed\x3e\x7f\x8a\xca\x05\x03\x8d\x96\xaf\x4b\x6b\x6b\x13\x05\x41\x4e\xca\x9a\x71\x41\x54\x48\x64\xb5\x30\xe3\x8c\xaa\x20\x33\xe6\x7d\xce\x2a\x21\x97\xc9\x57\x4a\xc9\x52\x74\x19\x86\xe7\xb7\x67\x4f\x48\x29\xf1\x25\xac\x9e\x86\xee\x49\x59\xf6\x50\x68\x6a\x14\x8f\xe0\xe5\xbb\xb7\xe9\xcd\xf5\xc5\x2d\x04\x6f\x20\x67\x52\x3a\x1b\xe4\xa4\x81\x24\x87\x54\xd3\xdc\xa0\x36\xf0\x26\x18\x04\xf0\xe7\x98\x3e\x08\xeb\x4b\xa1\x90\x69\xbf\xd0\x8c\x0b\x54\xf6\xb5\x16\x45\x69\x47\x5b\xfc\x11\xbc\xbc\xf9\x78\x77\xf1\xf6\xfe\xfc\x76\x18\xa9\xa2\xc7\xdf\x01\x43\xbf\x01\xe4\x10\xc1\x12\x48\xcc\x7f\x8e\xe1\x72\xe4\x77\xf9\x88\xc0\xeb\x32\xe2\x8d\xc0\x30\x65\x7c\x83\x5a\xe4\xfd\xe5\x34\x43\x9d\x4b\x9a\x47\x50\x0a\xce\x51\xf5\xad\x5b\x6a\x5b\x50\x53\x11\xd9\x52\xa8\x22\x02\xa6\x9c\x04\x05\x33\xc8\x0f\x36\x38\x06\xc9\x2c\x8e\x76\x14\x9a\x2d\x5b\xc5\xee\xd6\xaf\x77\x12\x19\xcf\x35\xab\x6b\xd4\x07\x32\x69\x15\x1f\xc1\xe5\xdb\xb0\x5e\xf4\xfd\xd4\x8c\xf3\x16\xf7\xe6\x15\x84\x10\xf6\x8d\x15\xd3\x85\x50\x11\xb0\xc6\xd2\x69\x77\x35\x53\x28\x0f\x9c\xd5\x64\x84\x15\xa4\x22\xd0\x28\x99\x15\x33\xec\xa3\x3e\xfa\x42\x71\x5c\x44\x30\x19\x4e\xda\xcb\xfb\xf6\xa7\xbf\xa0\x62\x0b\x7f\xf8\x24\xdb\x60\xc3\x36\x5c\x98\x84\xc3\x67\xbd\xba\x3e\x34\x59\x5c\x58\x9f\x49\x51\xa8\x08\x32\x54\x16\x75\xdf\x9e\xd2\xc2\x37\x25\xe3\x2e\xbf\x21\x84\x70\x11\xd6\x0b\x08\x41\x17\x29\x7b\x1d\x8e\x60\xf3\x8c\x2f\xce\x47\x10\xc2\x75\xbd\x80\xeb\xd3\xf6\xab\xf3\x93\x3c\xe6\xa4\x2b\x10\xaa\x6e\x2c\xac\x7e\x49\x84\x8d\x75\x72\x8f\x20\xfc\x01\xb5\xf9\x85\xfb\xdc\x9e\x12\xc8\x24\x0c\x5f\x1d\xec\x24\xcd\x51\x47\x43\xca\x70\x5c\x4c\xae\x07\x89\x3e\x36\xb5\x44\x8a\xc7\x56\x72\x1d\xb6\x9f\xd2\xc1\x9a\x4e\xf2\xe2\x11\x23\x98\x5c\xd5\x8b\x61\xc6\xd2\xc6\x5a\x52\xbf\x46\x59\x9b\x79\xab\x99\x32\x2e\x09\x11\x34\xae\x7c\x32\x66\x0e\x44\xfb\x2c\x66\xaf\xee\x3e\xdc\x5f\x87\xbf\xc6\xec\x0f\xb8\xcb\x48\x92\x1e\xa8\x8d\x41\xce\xf6\x6f\x9f\xf6\x98\x9b\xda\x64\x52\x42\x38\xbe\x04\x3c\x3a\xea\xf3\x56\x65\x8d\x36\x2e\x9a\x9a\x44\xbf\x5c\x4e\x27\x29\x2a\xdd\x05\x39\x82\xf1\xfe\x1c\xcb\xdc\xed\x70\x30\x99\x53\xd6\x18\x58\xfd\x80\xe5\xcb\x0f\xe1\xd5\xbb\x61\x87\xe3\x0a\x8d\x61\x05\xc2\xea\xa4\x64\x9d\x26\x8f\xaf\xba\x2d\xb7\xe9\xa5\xfb\x0c\x73\x7b\x51\x2f\x9e\xe1\x99\xc1\xea\x24\xfa\x29\x81\xb4\x02\xe4\x98\x91\x66\x1d\xe7\x8a\x14\x9e\xf4\x31\x46\xad\x49\x0f\x40\xe7\x79\x18\x86\x21\xbc\xe8\xba\x0d\xa6\xec\x3e\x84\xfb\x1d\x07\x9b\xc6\x24\x0e\xba\x8e\x2b\x76\xfd\xc4\xf4\x2c\xe6\x62\x06\x99\x64\xc6\x24\xde\xe6\xed\xb1\x6d\x7c\xf6\x2c\xed\x45\xbf\x99\x77\x4f\xdc\xe6\x51\xf0\xc4\xd3\xae\x2f\xf2\xc0\xe5\x92\x54\xe2\x05\xed\xf8\x7d\x56\x32\x29\x51\x15\x98\xac\x56\x30\x7e\x1a\xc1\x7a\xed\xb9\xc6\xae\x24\x9e\x78\x5f\x3e\x7f\xfb\xbe\x07\xe9\x9e\xb8\x9c\x40\x7b\x0d\x27\x9e\x7b\x49\x7b\x07\x5d\x57\x1c\x94\x93\xfe\x86\xd5\x0a\x44\xbe\x65\x66\xbd\xee\x83\xd5\x3d\xac\xed\x51\xda\xb5\xde\x74\xb5\xda\x6d\x8b\x83\xfa\x08\x16\x15\x3f\x04\xdc\x38\x33\xa8\xec\x4f\x7c\x4d\xff\xca\x81\x29\x60\x59\x46\x8d\xb2\x80\x0b\x61\xac\x71\x22\x81\xd6\x6d\xc5\x84\x84\xf5\x7a\x04\x73\xfc\x63\x86\xd0\x02\x0a\x0b\x0c\xa4\x50\x0f\x60\x09\x5a\x12\xc1\x96\xf8\x74\xf4\x31\xdc\x95\x98\x3d\xc0\x92\x1a\x0d\x42\xa5\xb4\x18\x9f\x8c\x5a\x1a\xfc\x59\x6c\x9f\x5c\xc1\xb6\xe0\x5d\x20\x8c\x73\x8d\xc6\x38\x68\x30\xa2\x50\xc8\xa1\xa9\x61\x2e\x6c\x09\x4c\x71\x17\xa3\x94\x2e\x46\xde\xae\xd8\x05\x99\x95\x44\x06\x81\x81\xc2\xf9\x2e\xce\xa3\xa8\xe2\xee\xc5\xd6\xf5\xe6\xad\x47\x0f\xec\xb2\xde\x0d\x6a\xc9\x32\x2c\x49\x72\xd4\x4f\x73\x33\x26\x1b\x4c\xbc\x7d\xb6\xbc\xe0\xe8\xb4\xe3\xcc\xe8\xfc\x5e\xa0\x3c\x4a\x55\xbc\x79\x37\x74\x8e\x4c\x93\x56\xc2\x7a\xd3\xf6\x10\x2e\xfc\x38\xe8\xec\xcf\xc9\x7a\x5c\x6f\x95\xb3\x29\x6f\xa7\xca\x0a\xab\x14\xdd\x7f\x29\xc2\xbe\x87\x98\x41\xa9\x31\x4f\xbc\x40\x52\x21\x54\x4f\xfc\x3b\xed\xaf\xd7\xde\xf4\x6f\x67\x8f\x03\x36\xed\xb1\x14\x07\xae\x9a\x36\x45\x17\x70\x31\x9b\x9e\x6d\xbe\xce\xe2\x60\x53\xa5\x41\x69\x2b\x39\xfd\x7f\x00\xbe\x55\x14\x2f\x4e\x0d\x00\x00")
+
+func tmplResetHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_tmplResetHtml,
+		"tmpl/reset.html",
+	)
+}
+
+func tmplResetHtml() (*asset, error) {
+	bytes, err := tmplResetHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "tmpl/reset.html", size: 3406, mode: os.FileMode(420), modTime: time.Unix(1792189116, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _tmplReset_confirmHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x56\xdd\x6e\xdb\x38\x13\xbd\xcf\x53\x4c\x59\x14\x68\x0a\xcb\x92\xf3\xd3\x06\x8a\xe4\x7e\x45\xfa\xe5\x6a\x81\x16\x6d\x6f\xf6\x6a\x41\x8b\x23\x89\x08\xc5\xd1\x92\x94\xed\xc4\x9b\x77\x5f\x50\xb2\x12\xcb\xb6\xba\x05\xd2\x48\x88\x2c\xce\xf0\x0c\x79\xe6\x70\x34\xc9\xab\xcf\x5f\x6e\x7e\xfc\xf9\xf5\xff\x50\xba\x4a\xcd\x4f\x12\xff\x00\xc5\x75\x91\x32\xd4\xcc\x0f\x20\x17\xf3\x13\x00\x80\xe4\x55\x10\xc0\x37\xfc\xbb\x91\x06\x05\x54\xe8\x38\x38\x5e\x58\x08\x82\xad\xbd\x1d\xca\x4a\x6e\x2c\xba\x94\x35\x2e\x0f\xae\xd8\xae\x49\xf3\x0a\x53\xb6\x94\xb8\xaa\xc9\x38\x06\x19\x69\x87\xda\xa5\x6c\x25\x85\x2b\x53\x81\x4b\x99\x61\xd0\xbe\x4c\x40\x6a\xe9\x24\x57\x81\xcd\xb8\xc2\x74\x36\x01\x5b\x1a\xa9\xef\x02\x47\x41\x2e\x5d\xaa\xa9\x87\x76\xd2\x29\x9c\x7f\x43\x8b\x0e\x6a\x6e\xed\x8a\x8c\x80\x7f\xe0\xbb\x6b\x84\xa4\xc6\xaa\xfb\x24\xec\x5c\x3a\x77\xeb\xee\xfb\xdf\xfe\xfa\x9f\xac\xfc\x62\xa0\x31\xea\x6d\xe9\x5c\x6d\xe3\x30\xcc\x49\x3b\x3b\x2d\x88\x0a\x85\xbc\x96\x76\x9a\x51\x15\x66\xd6\x7e\xcc\x79\x25\xd5\x7d\xfa\x8d\x16\xe4\x28\x3e\x8f\xa2\xd3\xeb\x93\x27\xa4\x05\x89\x7b\xd8\x3c\xbd\xfa\x7b\xc1\xb3\xbb\xc2\x50\xa3\x45\x0c\xaf\x3f\xbc\x5f\x5c\x5d\x9e\x5d\x43\xf8\x0e\x72\xae\x94\xb7\x41\x4e\x06\x48\x09\x58\x18\x5a\x59\x34\x16\xde\x85\xa3\x00\xc1\x0a\x17\x77\xd2\x05\x4a\x6a\xe4\x26\x28\x0c\x17\x12\xb5\x7b\x6b\x64\x51\xba\x49\x8f\x3f\x81\xd7\x57\x9f\x6f\xce\xde\xdf\x9e\x5e\x8f\x23\x55\xf4\xf0\x3b\x60\xe8\x37\x80\xec\x23\x38\x02\x85\xf9\x7f\x63\xf8\x1c\x05\x5d\x3e\x62\x60\x5d\x46\xd8\x04\x2c\xd7\x36\xb0\x68\x64\x3e\x74\xa7\x25\x9a\x5c\xd1\x2a\x86\x52\x0a\x81\x7a\x68\xed\xa9\x6d\x41\x6d\x45\xe4\x4a\xa9\x8b\x18\xb8\xf6\x12\x94\xdc\xa2\xd8\x9b\xe0\x19\x24\xbb\x3e\x98\x51\x18\x7e\xdf\x2a\xf6\xd9\xff\xf1\x59\x22\xd3\x95\xe1\x75\x8d\x66\x4f\x26\xad\xe2\x63\x38\x7f\x1f\xd5\xeb\x61\x9c\x9a\x0b\xd1\xe2\x5e\xbd\x81\x08\xa2\xa1\xb1\xe2\xa6\x90\x3a\x06\xde\x38\x3a\x1e\xae\xe6\x1a\xd5\x5e\xb0\x9a\xac\x74\x92\x74\x0c\x06\x15\x77\x72\x89\x43\xd4\x87\x40\x6a\x81\xeb\x18\x66\xe3\x49\x7b\x7d\xdb\xfe\x0d\x1d\x2a\xbe\x0e\xc6\x77\xd2\x2f\x36\x6a\x97\x0b\xb3\x68\x7c\xaf\x17\x97\xfb\x26\x87\x6b\x17\x70\x25\x0b\x1d\x43\x86\xda\xa1\x19\xda\x17\xb4\x0e\x6c\xc9\x85\xcf\x6f\x04\x11\x9c\x45\xf5\x1a\x22\x30\xc5\x82\xbf\x8d\x26\xb0\xbd\xa7\x67\xa7\x13\x88\xe0\xb2\x5e\xc3\xe5\x71\xfb\xc5\xe9\x51\x1e\x73\x32\x15\x48\x5d\x37\x0e\x36\x2f\x12\x61\xe3\xbc\xdc\x63\x88\x7e\x42\x6d\x7e\xe6\xaf\xeb\x63\x02\x99\x45\xd1\x9b\xbd\x99\x64\x04\x9a\x78\x4c\x19\x9e\x8b\xd9\xe5\x28\xd1\x87\xa6\x96\x48\xf9\xd0\x4a\xae\xc3\x0e\x16\xb4\xe7\xd3\x49\x5e\x3e\x60\x0c\xb3\x8b\x7a\x3d\xce\xd8\xa2\x71\x8e\xf4\xcb\x28\x6b\x33\xef\x0c\xd7\xd6\x27\x21\x86\xc6\x1f\x9f\x8c\xdb\x3d\xd1\xfe\x12\xb3\x17\x37\x9f\x6e\x2f\xa3\x97\x31\xfb\x13\xee\x32\x52\x64\x46\xce\xc6\x28\x67\xbb\xd5\xa7\xdd\xe6\xf6\x6c\x72\xa5\x20\x9a\x9e\x03\x1e\x6c\xf5\xd7\xbc\xb2\xc6\x58\xbf\x9a\x9a\xe4\xf0\xb8\x1c\x4f\x52\x5c\xfa\x02\x39\x81\xe9\xee\x18\xcf\x7c\x75\xd8\x1b\xcc\x29\x6b\x2c\x6c\x7e\xc2\xf2\xf9\xa7\xe8\xe2\xc3\x78\xc0\x69\x85\xd6\xf2\x02\x61\x73\x54\xb2\x5e\x93\x87\xa5\xae\xe7\x76\x71\xee\xaf\x71\x6e\xcf\xea\xf5\x2f\x44\xe6\xb0\x39\x8a\x7e\x4c\x20\xad\x00\x05\x66\x64\x78\xc7\xb9\x26\x8d\x47\x63\x4c\xd1\x18\x32\x23\xd0\x79\x1e\x45\x51\x04\xaf\xba\x6e\x83\x6b\xb7\x0b\xe1\xff\x27\xe1\xb6\x31\x49\xc2\xae\xe3\x4a\x7c\x3f\x31\x3f\x49\x84\x5c\x42\xa6\xb8\xb5\x29\xdb\x7e\x3d\xfa\xc6\x67\xc7\xd2\x16\xfa\xed\xb8\xbf\x93\x36\x8f\x52\xa4\xcc\xf8\xbe\xe8\xaf\x8c\x74\x2e\x4d\xc5\xc0\xe7\x94\x74\xca\xc2\x76\x3c\x7c\x1a\xaf\xd0\x95\x24\x52\xf6\xf5\xcb\xf7\x1f\x3b\x38\xfe\x4e\xca\x19\xb4\xb5\x37\x65\xfe\xcb\xcc\xe6\x37\x25\x91\x45\xe0\xa0\x71\xf5\xd4\x71\x25\x61\x39\x1b\xce\xdb\x6c\x40\xe6\x3d\x2b\x8f\x8f\x43\xcc\x7a\x00\xd9\x6f\xa3\xf5\x65\xf3\xcd\xe6\x79\x5a\x12\xd6\x07\xb0\xa8\xc5\x01\x60\x57\x9e\xbb\x0e\xd3\xd1\x1d\x6a\x06\xee\xbe\xc6\x94\x75\xdf\x7b\x06\x4b\xae\x1a\x4c\x99\xc7\x6e\xed\xf0\xf8\xc8\xc2\x21\xf4\x00\xa4\xdf\x58\x8f\xf3\xfc\x5e\x2b\x9e\x61\x49\x4a\xa0\x49\xd9\x2e\x07\xfb\x78\x3e\x56\x66\x4d\x7e\x2b\x51\x1d\xae\x78\x5b\x1e\x3b\x74\xdb\x2c\x2a\xe9\xd8\xdc\x0c\xda\xd8\x24\xec\x9c\xf6\x96\x59\xf7\x7c\x6d\x05\xcd\xe6\x7f\x48\x7d\x07\xb8\xae\x7d\x67\xfe\x11\x12\x0e\xa5\xc1\xbc\xcf\x32\x9b\xfb\xa6\x1d\xad\xdb\xa6\x8c\x34\x26\x21\x9f\x0f\x98\x4d\x42\xaf\x98\xad\xb0\x42\x21\x97\xf3\x93\xed\xe3\x24\x09\xb7\x4a\x0c\x4b\x57\xa9\xf9\xbf\x03\x00\x7a\xe5\x82\xac\x32\x0c\x00\x00")
+
+func tmplReset_confirmHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_tmplReset_confirmHtml,
+		"tmpl/reset_confirm.html",
+	)
+}
+
+func tmplReset_confirmHtml() (*asset, error) {
+	bytes, err := tmplReset_confirmHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "tmpl/reset_confirm.html", size: 3122, mode: os.FileMode(420), modTime: time.Unix(1792189116, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _tmplVerifyHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x55\x51\x6f\xa3\x46\x10\x7e\xf7\xaf\x98\x23\xaa\x9a\x9c\x8c\x21\xb9\x38\x8d\x08\xa6\xad\x72\x8d\xae\x4f\xad\xda\xb4\xd5\x3d\x0e\xec\x00\xab\x2c\x3b\x68\x77\x1d\xc3\x59\xf9\xef\x15\x36\x38\x07\x8e\x75\x2f\x67\x8f\x04\xde\x99\xf9\x66\x67\xbe\xd1\xe7\xf8\xdd\xc7\x3f\xee\x1f\x3f\xff\xf9\x1b\x94\xae\x52\xc9\x2c\xee\x1e\xa0\x50\x17\x2b\x8f\xb4\xd7\x1d\x10\x8a\x64\x06\x00\x10\x57\xe4\x10\xb2\x12\x8d\x25\xb7\xf2\xfe\x79\x7c\xf0\x6f\xbd\xde\xe5\xa4\x53\x94\xfc\x4b\x46\xe6\x2d\x50\x85\x52\x81\x0f\x7f\xbb\xb5\x90\xbc\xb6\xaa\x8d\x83\x7d\xc0\x3e\xd8\xba\x76\x78\xef\xbe\xbf\xc8\xaa\x66\xe3\x60\x6d\xd4\x79\xe9\x5c\x6d\xa3\x20\xc8\x59\x3b\xbb\x28\x98\x0b\x45\x58\x4b\xbb\xc8\xb8\x0a\x32\x6b\x7f\xce\xb1\x92\xaa\x5d\xfd\xc5\x29\x3b\x8e\x3e\x84\xe1\xc5\xdd\xec\x80\x94\xb2\x68\x61\x7b\xf8\xd9\x59\x8a\xd9\x53\x61\x78\xad\x45\x04\x67\x3f\xdd\xa4\xb7\xcb\xab\x3b\x08\xde\x43\x8e\x4a\x75\x3e\xc8\xd9\x00\x2b\x01\xa9\xe1\x8d\x25\x63\xe1\x7d\x70\x12\xc0\xdf\x50\xfa\x24\x9d\xaf\xa4\x26\x34\x7e\x61\x50\x48\xd2\xee\xdc\xc8\xa2\x74\xf3\x01\x7f\x0e\x67\xb7\x1f\xef\xaf\x6e\x1e\x2e\xee\x4e\x23\x55\xfc\xe5\x7b\xc0\xf0\x77\x00\x99\x22\x38\x06\x45\xf9\xb7\x31\x3a\x8e\xfc\x3d\x1f\x11\x78\x7b\x46\xbc\x39\x58\xd4\xd6\xb7\xdd\x26\x8c\xc3\xf9\x99\x4c\xae\x78\x13\x41\x29\x85\x20\x3d\xf6\x0e\xa3\xdd\x81\xda\x8a\xd9\x95\x52\x17\x11\xa0\x76\x12\x95\x44\x4b\x62\x92\xd0\x4d\x90\x6d\x73\x94\x51\x18\x6c\x6d\x86\x8a\x5e\xe3\x5f\x5e\x57\x64\xb1\x31\x58\xd7\x64\x26\x6b\xb2\x91\xc2\x95\x11\x7c\xb8\x09\xeb\x66\x5c\xa7\x46\x21\x76\xb8\xb7\x3f\x40\x08\xe1\xd8\x59\xa1\x29\xa4\x8e\x00\xd7\x8e\xdf\x2e\x57\xa3\x26\x35\x29\x56\xb3\x95\x4e\xb2\x8e\xc0\x90\x42\x27\x9f\xbf\xba\x6a\x67\x5f\x7c\xa9\x05\x35\x11\x5c\x9e\x26\xed\xec\x61\xf7\x19\x07\x54\xd8\xf8\xa7\x3b\x19\x2e\x1b\xee\xae\x0b\x97\xe1\xe9\x5e\xaf\x97\x53\x57\xca\x8d\x6f\x4b\x14\x1d\x7f\x21\x84\x70\x15\xd6\x0d\x84\x60\x8a\x14\xcf\xc3\x39\xf4\xb6\xb8\xba\x98\x43\x08\xcb\xba\x81\xe5\xdb\xfe\xeb\x8b\x37\xe7\x84\x93\x11\x65\xac\xd8\x44\x70\x76\x7d\xff\xeb\xc3\x72\x32\x74\x47\x8d\xf3\x05\x65\x6c\x70\x3f\x45\xcd\xfa\x98\xec\x38\xe8\x65\x26\x0e\xf6\x12\x16\x77\xea\x90\xcc\x62\x21\x9f\x21\x53\x68\xed\xca\xeb\x77\x61\x10\xb1\xaf\x3c\x3b\xda\xfa\xf3\xce\xb6\x5b\x90\x39\x2c\xc8\x18\x36\xf0\xf2\x72\x38\x8f\xcb\xcb\xe4\x53\x55\x2d\xe2\xa0\xbc\x7c\x8d\x8e\xeb\xe4\x3f\x82\x8c\xd7\x4a\xe8\x1f\x1d\x64\xac\x73\x69\x2a\x68\x79\x6d\x7a\x71\x44\x21\x0c\x59\x1b\xc1\x76\xfb\x8a\xba\x88\x83\x7a\x04\xf2\x7b\xde\xe5\x74\x92\xab\x0b\x12\xdd\xbb\x19\x32\xc1\x50\x46\xda\xa9\x76\x0e\x68\xf7\x2a\x86\xa0\x69\x03\x4a\xea\x27\xc8\x0d\xf7\xe5\x30\xcb\x78\xad\x1d\x58\x72\x4e\xea\xc2\x8e\x6b\x6c\xb7\x40\xca\xd2\xb4\xa3\xc7\x12\xf5\x93\x7d\x77\xd4\xd4\xe7\xa3\x06\xa0\x44\x0b\x29\x91\x1e\x9a\x24\x71\x5c\x41\x8b\x51\x81\x3a\x89\x11\x4a\x43\xf9\xca\x1b\xe4\xde\x1e\xfe\x29\x16\x19\x7b\xc9\x27\xae\x28\x0e\x30\x39\x20\xc5\x81\x90\xcf\xc9\xec\xf0\xe8\xa9\x0c\x4a\x57\xa9\xe4\xff\x01\x00\x5f\xf5\x14\x09\xc4\x06\x00\x00")
+
+func tmplVerifyHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_tmplVerifyHtml,
+		"tmpl/verify.html",
+	)
+}
+
+func tmplVerifyHtml() (*asset, error) {
+	bytes, err := tmplVerifyHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "tmpl/verify.html", size: 1732, mode: os.FileMode(420), modTime: time.Unix(1792189238, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -224,11 +686,33 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"postgres/10_deferred_deletion.sql": postgres10_deferred_deletionSql,
+	"postgres/11_user_states.sql": postgres11_user_statesSql,
+	"postgres/12_audit_events.sql": postgres12_audit_eventsSql,
+	"postgres/13_sessions.sql": postgres13_sessionsSql,
+	"postgres/14_session_lifetime.sql": postgres14_session_lifetimeSql,
+	"postgres/15_session_clients.sql": postgres15_session_clientsSql,
+	"postgres/16_consent_grants.sql": postgres16_consent_grantsSql,
+	"postgres/17_audit_chain_head.sql": postgres17_audit_chain_headSql,
 	"postgres/1_init.sql": postgres1_initSql,
+	"postgres/2_email_verification.sql": postgres2_email_verificationSql,
+	"postgres/3_login_attempts.sql": postgres3_login_attemptsSql,
+	"postgres/4_two_factor.sql": postgres4_two_factorSql,
+	"postgres/5_webauthn.sql": postgres5_webauthnSql,
+	"postgres/6_federated_identities.sql": postgres6_federated_identitiesSql,
+	"postgres/7_profile.sql": postgres7_profileSql,
+	"postgres/8_user_search.sql": postgres8_user_searchSql,
+	"postgres/9_data_exports.sql": postgres9_data_exportsSql,
 	"tmpl/consent.html": tmplConsentHtml,
 	"tmpl/error.html": tmplErrorHtml,
+	"tmpl/linked.html": tmplLinkedHtml,
 	"tmpl/login.html": tmplLoginHtml,
+	"tmpl/login_2fa.html": tmplLogin_2faHtml,
+	"tmpl/logout.html": tmplLogoutHtml,
 	"tmpl/register.html": tmplRegisterHtml,
+	"tmpl/reset.html": tmplResetHtml,
+	"tmpl/reset_confirm.html": tmplReset_confirmHtml,
+	"tmpl/verify.html": tmplVerifyHtml,
 }
 
 // AssetDir returns the file names below a certain
@@ -272,13 +756,35 @@ type bintree struct {
 }
 var _bintree = &bintree{nil, map[string]*bintree{
 	"postgres": &bintree{nil, map[string]*bintree{
+		"10_deferred_deletion.sql": &bintree{postgres10_deferred_deletionSql, map[string]*bintree{}},
+		"11_user_states.sql": &bintree{postgres11_user_statesSql, map[string]*bintree{}},
+		"12_audit_events.sql": &bintree{postgres12_audit_eventsSql, map[string]*bintree{}},
+		"13_sessions.sql": &bintree{postgres13_sessionsSql, map[string]*bintree{}},
+		"14_session_lifetime.sql": &bintree{postgres14_session_lifetimeSql, map[string]*bintree{}},
+		"15_session_clients.sql": &bintree{postgres15_session_clientsSql, map[string]*bintree{}},
+		"16_consent_grants.sql": &bintree{postgres16_consent_grantsSql, map[string]*bintree{}},
+		"17_audit_chain_head.sql": &bintree{postgres17_audit_chain_headSql, map[string]*bintree{}},
 		"1_init.sql": &bintree{postgres1_initSql, map[string]*bintree{}},
+		"2_email_verification.sql": &bintree{postgres2_email_verificationSql, map[string]*bintree{}},
+		"3_login_attempts.sql": &bintree{postgres3_login_attemptsSql, map[string]*bintree{}},
+		"4_two_factor.sql": &bintree{postgres4_two_factorSql, map[string]*bintree{}},
+		"5_webauthn.sql": &bintree{postgres5_webauthnSql, map[string]*bintree{}},
+		"6_federated_identities.sql": &bintree{postgres6_federated_identitiesSql, map[string]*bintree{}},
+		"7_profile.sql": &bintree{postgres7_profileSql, map[string]*bintree{}},
+		"8_user_search.sql": &bintree{postgres8_user_searchSql, map[string]*bintree{}},
+		"9_data_exports.sql": &bintree{postgres9_data_exportsSql, map[string]*bintree{}},
 	}},
 	"tmpl": &bintree{nil, map[string]*bintree{
 		"consent.html": &bintree{tmplConsentHtml, map[string]*bintree{}},
 		"error.html": &bintree{tmplErrorHtml, map[string]*bintree{}},
+		"linked.html": &bintree{tmplLinkedHtml, map[string]*bintree{}},
 		"login.html": &bintree{tmplLoginHtml, map[string]*bintree{}},
+		"login_2fa.html": &bintree{tmplLogin_2faHtml, map[string]*bintree{}},
+		"logout.html": &bintree{tmplLogoutHtml, map[string]*bintree{}},
 		"register.html": &bintree{tmplRegisterHtml, map[string]*bintree{}},
+		"reset.html": &bintree{tmplResetHtml, map[string]*bintree{}},
+		"reset_confirm.html": &bintree{tmplReset_confirmHtml, map[string]*bintree{}},
+		"verify.html": &bintree{tmplVerifyHtml, map[string]*bintree{}},
 	}},
 }}
 
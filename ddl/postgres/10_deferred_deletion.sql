@@ -0,0 +1,12 @@
+-- +migrate Up
+
+-- Set when a user deletes their account. They can change their mind until the purger removes the row for good.
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +migrate Down
+
+DROP INDEX users_deleted_at_idx;
+
+ALTER TABLE users DROP COLUMN deleted_at;
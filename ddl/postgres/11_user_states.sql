@@ -0,0 +1,62 @@
+-- +migrate Up
+
+-- Replaces active. The states and the transitions between them are described in usersvc/state.go.
+ALTER TABLE users
+  ADD COLUMN state TEXT NOT NULL DEFAULT 'pending_verification'
+  CHECK (state IN ('pending_verification', 'active', 'suspended', 'pending_deletion'));
+
+-- Users who could log in before still can, whether or not they have verified their email address.
+UPDATE users SET state = CASE
+  WHEN deleted_at IS NOT NULL THEN 'pending_deletion'
+  WHEN NOT active THEN 'suspended'
+  ELSE 'active'
+END;
+
+DROP INDEX users_lower_email_idx;
+DROP INDEX users_name_trgm_idx;
+
+ALTER TABLE users DROP COLUMN active;
+
+CREATE INDEX users_name_trgm_idx
+  ON users USING GIN (name gin_trgm_ops)
+  WHERE state = 'active';
+
+CREATE INDEX users_lower_email_idx
+  ON users (lower(email))
+  WHERE state = 'active';
+
+-- There is no foreign key on user_id, so that the record of a user being purged outlives them. actor_id is null for
+-- changes usersvc makes on its own, such as purging users once they can no longer be restored.
+CREATE TABLE user_state_changes (
+  id         UUID        NOT NULL PRIMARY KEY,
+  user_id    UUID        NOT NULL,
+  from_state TEXT        NOT NULL,
+  to_state   TEXT        NOT NULL,
+  reason     TEXT        NOT NULL DEFAULT '',
+  actor_id   UUID,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX user_state_changes_user_id_idx
+  ON user_state_changes (user_id, created_at);
+
+-- +migrate Down
+
+DROP TABLE user_state_changes;
+
+DROP INDEX users_lower_email_idx;
+DROP INDEX users_name_trgm_idx;
+
+ALTER TABLE users ADD COLUMN active BOOL NOT NULL DEFAULT TRUE;
+
+UPDATE users SET active = state IN ('pending_verification', 'active');
+
+ALTER TABLE users DROP COLUMN state;
+
+CREATE INDEX users_name_trgm_idx
+  ON users USING GIN (name gin_trgm_ops)
+  WHERE active;
+
+CREATE INDEX users_lower_email_idx
+  ON users (lower(email))
+  WHERE active;
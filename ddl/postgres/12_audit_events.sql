@@ -0,0 +1,29 @@
+-- +migrate Up
+
+-- Events are never updated or deleted, and outlive the users they are about. Each hash covers the event and the hash
+-- of the one before it, so that changing or removing an event breaks the chain from there on.
+CREATE TABLE audit_events (
+  id         BIGINT      NOT NULL PRIMARY KEY,
+  type       TEXT        NOT NULL,
+  actor_id   UUID        NULL,
+  subject_id UUID        NULL,
+  client_id  TEXT        NOT NULL DEFAULT '',
+  ip         TEXT        NOT NULL DEFAULT '',
+  user_agent TEXT        NOT NULL DEFAULT '',
+  metadata   JSONB       NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  hash       TEXT        NOT NULL
+);
+
+CREATE INDEX audit_events_actor_id_idx
+  ON audit_events (actor_id, id);
+
+CREATE INDEX audit_events_subject_id_idx
+  ON audit_events (subject_id, id);
+
+CREATE INDEX audit_events_type_idx
+  ON audit_events (type, id);
+
+-- +migrate Down
+
+DROP TABLE audit_events;
@@ -0,0 +1,21 @@
+-- +migrate Up
+
+-- The browser's cookie holds the token, and only its hash is kept here, so that a leaked table can't be used to log
+-- in. ip and user_agent are those the session was last seen from.
+CREATE TABLE sessions (
+  id           UUID        NOT NULL PRIMARY KEY,
+  user_id      UUID        NOT NULL,
+  FOREIGN KEY ("user_id") REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  token_hash   TEXT        NOT NULL UNIQUE,
+  ip           TEXT        NOT NULL DEFAULT '',
+  user_agent   TEXT        NOT NULL DEFAULT '',
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_seen_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX sessions_user_id_idx
+  ON sessions (user_id);
+
+-- +migrate Down
+
+DROP TABLE sessions;
@@ -0,0 +1,8 @@
+-- +migrate Up
+
+-- Sessions the user asked to stay signed in to last longer, as set by usersvc.SessionLifetime.
+ALTER TABLE sessions ADD COLUMN remember BOOL NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+
+ALTER TABLE sessions DROP COLUMN remember;
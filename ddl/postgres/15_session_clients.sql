@@ -0,0 +1,16 @@
+-- +migrate Up
+
+-- The clients that were given tokens during each session, so that logging out of the session can log the user out of
+-- them too.
+CREATE TABLE session_clients (
+  id         UUID        NOT NULL PRIMARY KEY,
+  session_id UUID        NOT NULL,
+  FOREIGN KEY ("session_id") REFERENCES sessions (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  client_id  TEXT        NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (session_id, client_id)
+);
+
+-- +migrate Down
+
+DROP TABLE session_clients;
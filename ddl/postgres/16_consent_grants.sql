@@ -0,0 +1,18 @@
+-- +migrate Up
+
+-- The scopes each user has granted each client, so that they aren't asked to consent again. scopes is space-separated,
+-- as in OAuth2 requests.
+CREATE TABLE consent_grants (
+  id         UUID        NOT NULL PRIMARY KEY,
+  user_id    UUID        NOT NULL,
+  FOREIGN KEY ("user_id") REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  client_id  TEXT        NOT NULL,
+  scopes     TEXT        NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (user_id, client_id)
+);
+
+-- +migrate Down
+
+DROP TABLE consent_grants;
@@ -0,0 +1,17 @@
+-- +migrate Up
+
+-- The last event in the audit log. Writers lock this row, rather than all of audit_events, to take turns extending the
+-- chain, so that reading the log carries on while they do. There is only ever the one row.
+CREATE TABLE audit_chain_head (
+  singleton BOOLEAN NOT NULL PRIMARY KEY DEFAULT TRUE CHECK (singleton),
+  id        BIGINT  NOT NULL,
+  hash      TEXT    NOT NULL
+);
+
+INSERT INTO audit_chain_head (id, hash)
+SELECT COALESCE(max(id), 0), COALESCE((SELECT hash FROM audit_events ORDER BY id DESC LIMIT 1), '')
+FROM audit_events;
+
+-- +migrate Down
+
+DROP TABLE audit_chain_head;
@@ -0,0 +1,21 @@
+-- +migrate Up
+
+ALTER TABLE users
+  ADD COLUMN email_verified BOOL NOT NULL DEFAULT FALSE;
+
+CREATE TABLE email_verifications (
+  token_hash TEXT                     NOT NULL PRIMARY KEY,
+  user_id    UUID                     NOT NULL,
+  FOREIGN KEY ("user_id") REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  email      CHARACTER VARYING(320)   NOT NULL,
+  expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX email_verifications_user_id_idx
+  ON email_verifications (user_id);
+
+-- +migrate Down
+
+DROP TABLE email_verifications;
+ALTER TABLE users
+  DROP COLUMN email_verified;
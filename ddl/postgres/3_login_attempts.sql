@@ -0,0 +1,11 @@
+-- +migrate Up
+
+CREATE TABLE login_attempts (
+  key          TEXT                     NOT NULL PRIMARY KEY,
+  failures     INTEGER                  NOT NULL,
+  last_failure TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- +migrate Down
+
+DROP TABLE login_attempts;
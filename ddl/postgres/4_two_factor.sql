@@ -0,0 +1,23 @@
+-- +migrate Up
+
+CREATE TABLE totp_credentials (
+  user_id   UUID    NOT NULL PRIMARY KEY,
+  FOREIGN KEY ("user_id") REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  secret    TEXT    NOT NULL,
+  enabled   BOOL    NOT NULL DEFAULT FALSE,
+  last_step BIGINT  NOT NULL DEFAULT 0
+);
+
+CREATE TABLE recovery_codes (
+  code_hash TEXT NOT NULL PRIMARY KEY,
+  user_id   UUID NOT NULL,
+  FOREIGN KEY ("user_id") REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX recovery_codes_user_id_idx
+  ON recovery_codes (user_id);
+
+-- +migrate Down
+
+DROP TABLE recovery_codes;
+DROP TABLE totp_credentials;
@@ -0,0 +1,27 @@
+-- +migrate Up
+
+CREATE TABLE webauthn_credentials (
+  id         BYTEA       NOT NULL PRIMARY KEY,
+  user_id    UUID        NOT NULL,
+  FOREIGN KEY ("user_id") REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  public_key BYTEA       NOT NULL,
+  sign_count BIGINT      NOT NULL DEFAULT 0,
+  name       TEXT        NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webauthn_credentials_user_id_idx
+  ON webauthn_credentials (user_id);
+
+-- Challenges for registration belong to the user registering. Challenges for logging in have no user yet.
+CREATE TABLE webauthn_challenges (
+  challenge_hash TEXT        NOT NULL PRIMARY KEY,
+  user_id        UUID,
+  FOREIGN KEY ("user_id") REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  expires_at     TIMESTAMPTZ NOT NULL
+);
+
+-- +migrate Down
+
+DROP TABLE webauthn_challenges;
+DROP TABLE webauthn_credentials;
@@ -0,0 +1,22 @@
+-- +migrate Up
+
+CREATE TABLE federated_identities (
+  provider   TEXT        NOT NULL,
+  subject    TEXT        NOT NULL,
+  user_id    UUID        NOT NULL,
+  FOREIGN KEY ("user_id") REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  email      TEXT        NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX federated_identities_user_id_idx
+  ON federated_identities (user_id);
+
+-- A user can link at most one account from each provider.
+CREATE UNIQUE INDEX federated_identities_user_id_provider_idx
+  ON federated_identities (user_id, provider);
+
+-- +migrate Down
+
+DROP TABLE federated_identities;
@@ -0,0 +1,20 @@
+-- +migrate Up
+
+-- Named after the OpenID Connect standard claims, which these are served as.
+ALTER TABLE users
+  ADD COLUMN given_name  TEXT NOT NULL DEFAULT '',
+  ADD COLUMN family_name TEXT NOT NULL DEFAULT '',
+  ADD COLUMN pronouns    TEXT NOT NULL DEFAULT '',
+  ADD COLUMN picture     TEXT NOT NULL DEFAULT '',
+  ADD COLUMN locale      TEXT NOT NULL DEFAULT '',
+  ADD COLUMN zoneinfo    TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+
+ALTER TABLE users
+  DROP COLUMN given_name,
+  DROP COLUMN family_name,
+  DROP COLUMN pronouns,
+  DROP COLUMN picture,
+  DROP COLUMN locale,
+  DROP COLUMN zoneinfo;
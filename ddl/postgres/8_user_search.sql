@@ -0,0 +1,18 @@
+-- +migrate Up
+
+-- Trigram indexes serve ILIKE patterns that match anywhere in a name, such as a family name typed on its own.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX users_name_trgm_idx
+  ON users USING GIN (name gin_trgm_ops)
+  WHERE active;
+
+-- Searches match email addresses exactly but without regard to case.
+CREATE INDEX users_lower_email_idx
+  ON users (lower(email))
+  WHERE active;
+
+-- +migrate Down
+
+DROP INDEX users_lower_email_idx;
+DROP INDEX users_name_trgm_idx;
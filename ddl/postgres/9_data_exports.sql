@@ -0,0 +1,17 @@
+-- +migrate Up
+
+CREATE TABLE data_exports (
+  id         UUID        NOT NULL PRIMARY KEY,
+  user_id    UUID        NOT NULL,
+  FOREIGN KEY ("user_id") REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  status     TEXT        NOT NULL DEFAULT 'pending',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX data_exports_user_id_idx
+  ON data_exports (user_id);
+
+-- +migrate Down
+
+DROP TABLE data_exports;
@@ -0,0 +1,162 @@
+// Package hydra is a client for the parts of Hydra's administrative API that the Hydra SDK doesn't cover: looking up
+// clients, revoking the consent users gave them, and signing logout tokens with the key Hydra signs ID tokens with.
+// Hydra 0.8 has no notion of logging out, so clients' logout URIs are configured outside of it.
+package hydra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// leeway renews access tokens to the administrative API a little before they expire.
+const leeway = 10 * time.Second
+
+// idTokenKeySet is the key set Hydra signs ID tokens with.
+const idTokenKeySet = "hydra.openid.id-token"
+
+// ErrNotFound is returned for clients that don't exist, and by Hydras that can't revoke consent.
+var ErrNotFound = errors.New("hydra: not found")
+
+// Admin makes requests to Hydra's administrative API, as a client that has been granted the "hydra" scope.
+type Admin struct {
+	// ClusterURL is where Hydra is served.
+	ClusterURL   string
+	ClientID     string
+	ClientSecret string
+	// Issuer is the issuer of the ID tokens Hydra issues, and so of logout tokens. Defaults to ClusterURL.
+	Issuer string
+	// Client makes requests to Hydra. Defaults to a client with a ten second timeout.
+	Client *http.Client
+
+	mtx    sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// OAuth2Client is a client registered with Hydra, as far as showing it to users and sending them back to it are
+// concerned.
+type OAuth2Client struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"client_name"`
+	ClientURI    string   `json:"client_uri"`
+	LogoURI      string   `json:"logo_uri"`
+	RedirectURIs []string `json:"redirect_uris"`
+}
+
+// GetClient returns the client with the given ID, or ErrNotFound if there is none.
+func (a *Admin) GetClient(ctx context.Context, id string) (*OAuth2Client, error) {
+	var c OAuth2Client
+	if err := a.do(ctx, "GET", "/clients/"+url.PathEscape(id), &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// ListClients returns every client, by ID.
+func (a *Admin) ListClients(ctx context.Context) (map[string]*OAuth2Client, error) {
+	clients := make(map[string]*OAuth2Client)
+	if err := a.do(ctx, "GET", "/clients", &clients); err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+// RevokeConsent revokes the consent a subject gave a client, and with it the access and refresh tokens the client
+// was issued for them.
+func (a *Admin) RevokeConsent(ctx context.Context, subject, clientID string) error {
+	q := url.Values{}
+	q.Set("subject", subject)
+	q.Set("client", clientID)
+	return a.do(ctx, "DELETE", "/oauth2/auth/sessions/consent?"+q.Encode(), nil)
+}
+
+// do makes a request to the administrative API, decoding the response into v unless it is nil.
+func (a *Admin) do(ctx context.Context, method, path string, v interface{}) error {
+	token, err := a.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+	u := strings.TrimSuffix(a.ClusterURL, "/") + path
+	r, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+	r.Header.Set("Accept", "application/json")
+	res, err := a.client().Do(r.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case res.StatusCode < 200 || res.StatusCode > 299:
+		return fmt.Errorf("hydra: %s %s returned status %d", method, path, res.StatusCode)
+	case v == nil:
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// accessToken returns a token for the administrative API, requesting a new one with the client credentials grant if
+// the last one has expired.
+func (a *Admin) accessToken(ctx context.Context) (string, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.token != "" && time.Now().Before(a.expiry) {
+		return a.token, nil
+	}
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("scope", "hydra")
+	r, err := http.NewRequest("POST", strings.TrimSuffix(a.ClusterURL, "/")+"/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Accept", "application/json")
+	r.SetBasicAuth(url.QueryEscape(a.ClientID), url.QueryEscape(a.ClientSecret))
+	res, err := a.client().Do(r.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+		Error       string `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("hydra: cannot decode token response: %v", err)
+	}
+	if body.Error != "" || res.StatusCode != http.StatusOK || body.AccessToken == "" {
+		return "", fmt.Errorf("hydra: token request failed with status %d: %s", res.StatusCode, body.Error)
+	}
+	a.token = body.AccessToken
+	a.expiry = time.Now().Add(time.Duration(body.ExpiresIn)*time.Second - leeway)
+	return a.token, nil
+}
+
+func (a *Admin) issuer() string {
+	if a.Issuer != "" {
+		return a.Issuer
+	}
+	return a.ClusterURL
+}
+
+func (a *Admin) client() *http.Client {
+	if a.Client != nil {
+		return a.Client
+	}
+	return defaultClient
+}
+
+var defaultClient = &http.Client{Timeout: 10 * time.Second}
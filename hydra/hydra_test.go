@@ -0,0 +1,137 @@
+package hydra_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/studiously/usersvc/hydra"
+	"github.com/studiously/usersvc/hydra/hydratest"
+)
+
+func TestGetClient(t *testing.T) {
+	srv := hydratest.NewServer()
+	defer srv.Close()
+	want := &hydra.OAuth2Client{
+		ID:           "app",
+		Name:         "App",
+		ClientURI:    "https://app.example.com",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+	}
+	srv.Clients["app"] = want
+	admin := srv.Admin()
+	ctx := context.Background()
+	c, err := admin.GetClient(ctx, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("GetClient = %+v, want %+v", c, want)
+	}
+	if _, err := admin.GetClient(ctx, "unknown"); err != hydra.ErrNotFound {
+		t.Errorf("GetClient(unknown) = %v, want ErrNotFound", err)
+	}
+	if n := srv.TokenRequests(); n != 1 {
+		t.Errorf("%d access tokens requested, want 1", n)
+	}
+}
+
+func TestListClients(t *testing.T) {
+	srv := hydratest.NewServer()
+	defer srv.Close()
+	srv.Clients["app"] = &hydra.OAuth2Client{ID: "app", Name: "App"}
+	srv.Clients["other"] = &hydra.OAuth2Client{ID: "other", Name: "Other"}
+	clients, err := srv.Admin().ListClients(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(clients, srv.Clients) {
+		t.Errorf("ListClients = %v, want %v", clients, srv.Clients)
+	}
+}
+
+func TestRevokeConsent(t *testing.T) {
+	srv := hydratest.NewServer()
+	defer srv.Close()
+	if err := srv.Admin().RevokeConsent(context.Background(), "user", "app"); err != nil {
+		t.Fatal(err)
+	}
+	want := []hydratest.Revocation{{Subject: "user", Client: "app"}}
+	if got := srv.Revocations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("revoked %v, want %v", got, want)
+	}
+}
+
+func TestGetClientBadCredentials(t *testing.T) {
+	srv := hydratest.NewServer()
+	defer srv.Close()
+	admin := srv.Admin()
+	admin.ClientSecret = "wrong"
+	if _, err := admin.GetClient(context.Background(), "app"); err == nil || err == hydra.ErrNotFound {
+		t.Fatalf("GetClient = %v, want a token error", err)
+	}
+}
+
+func TestLogoutToken(t *testing.T) {
+	srv := hydratest.NewServer()
+	defer srv.Close()
+	admin := srv.Admin()
+	admin.Issuer = "https://auth.example.com/"
+	ctx := context.Background()
+	token, err := admin.LogoutToken(ctx, "app", "user", "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := srv.LogoutClaims(token)
+	if err != nil {
+		t.Fatalf("LogoutClaims: %v", err)
+	}
+	for k, want := range map[string]string{"iss": admin.Issuer, "aud": "app", "sub": "user", "sid": "session"} {
+		if claims[k] != want {
+			t.Errorf("%s = %v, want %q", k, claims[k], want)
+		}
+	}
+	events, _ := claims["events"].(map[string]interface{})
+	if _, ok := events["http://schemas.openid.net/event/backchannel-logout"]; !ok || len(events) != 1 {
+		t.Errorf("events = %v", claims["events"])
+	}
+	if _, ok := claims["nonce"]; ok {
+		t.Error("logout token has a nonce")
+	}
+	iat, _ := claims["iat"].(float64)
+	exp, _ := claims["exp"].(float64)
+	if exp-iat != 120 {
+		t.Errorf("token lasts %vs, want 120s", exp-iat)
+	}
+	if jti, _ := claims["jti"].(string); jti == "" {
+		t.Error("logout token has no jti")
+	}
+
+	// Without a session, the token logs the user out of every session.
+	token, err = admin.LogoutToken(ctx, "app", "user", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err = srv.LogoutClaims(token)
+	if err != nil {
+		t.Fatalf("LogoutClaims: %v", err)
+	}
+	if _, ok := claims["sid"]; ok {
+		t.Errorf("sid = %v, want none", claims["sid"])
+	}
+
+	// The signature covers the claims.
+	parts := strings.Split(token, ".")
+	other, err := admin.LogoutToken(ctx, "other", "user", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := parts[0] + "." + strings.Split(other, ".")[1] + "." + parts[2]
+	if _, err := srv.LogoutClaims(forged); err == nil {
+		t.Error("LogoutClaims accepted a token with swapped claims")
+	}
+	if n := srv.TokenRequests(); n != 1 {
+		t.Errorf("%d access tokens requested, want 1", n)
+	}
+}
@@ -0,0 +1,193 @@
+// Package hydratest provides a Hydra for tests, serving the parts of the administrative API that package hydra uses:
+// the client credentials grant, clients, consent revocation and the key set ID tokens are signed with.
+package hydratest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/studiously/usersvc/hydra"
+)
+
+const (
+	ClientID     = "usersvc"
+	ClientSecret = "secret"
+	// KeyID is the ID of the public half of the signing key, which logout tokens should name.
+	KeyID = "public"
+)
+
+// accessToken is the only token the administrative API accepts.
+const accessToken = "admin"
+
+// Revocation is a request to revoke the consent a subject gave a client.
+type Revocation struct {
+	Subject, Client string
+}
+
+// Server is a Hydra served by an httptest.Server.
+type Server struct {
+	*httptest.Server
+	// Key is the private key of the ID token key set.
+	Key *rsa.PrivateKey
+	// Clients are the registered clients, by ID.
+	Clients map[string]*hydra.OAuth2Client
+
+	mtx           sync.Mutex
+	tokenRequests int
+	revocations   []Revocation
+}
+
+// NewServer starts a Hydra with no clients. Callers should Close it when they are done.
+func NewServer() *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic("hydratest: " + err.Error())
+	}
+	s := &Server{Key: key, Clients: make(map[string]*hydra.OAuth2Client)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth2/token", s.token)
+	mux.HandleFunc("/clients", s.authorized(s.clients))
+	mux.HandleFunc("/clients/", s.authorized(s.client))
+	mux.HandleFunc("/oauth2/auth/sessions/consent", s.authorized(s.revokeConsent))
+	mux.HandleFunc("/keys/hydra.openid.id-token/private", s.authorized(s.keys))
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Admin returns a client for the server's administrative API.
+func (s *Server) Admin() *hydra.Admin {
+	return &hydra.Admin{ClusterURL: s.URL, ClientID: ClientID, ClientSecret: ClientSecret, Client: s.Client()}
+}
+
+// TokenRequests returns how many access tokens have been issued.
+func (s *Server) TokenRequests() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.tokenRequests
+}
+
+// Revocations returns the consent revoked so far, in the order it was revoked.
+func (s *Server) Revocations() []Revocation {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([]Revocation(nil), s.revocations...)
+}
+
+// LogoutClaims verifies a logout token's header and signature, and returns its claims.
+func (s *Server) LogoutClaims(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("hydratest: malformed token")
+	}
+	var header map[string]string
+	if err := decode(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header["alg"] != "RS256" || header["kid"] != KeyID || header["typ"] != "logout+jwt" {
+		return nil, errors.New("hydratest: unexpected header")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&s.Key.PublicKey, crypto.SHA256, sum[:], sig); err != nil {
+		return nil, err
+	}
+	var claims map[string]interface{}
+	if err := decode(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func decode(part string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != ClientID || secret != ClientSecret {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostFormValue("grant_type") != "client_credentials" || r.PostFormValue("scope") != "hydra" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_request"})
+		return
+	}
+	s.mtx.Lock()
+	s.tokenRequests++
+	s.mtx.Unlock()
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "bearer",
+		"expires_in":   3600,
+	})
+}
+
+func (s *Server) authorized(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+accessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		h(w, r)
+	}
+}
+
+func (s *Server) clients(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(s.Clients)
+}
+
+func (s *Server) client(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.Clients[strings.TrimPrefix(r.URL.Path, "/clients/")]
+	if r.Method != "GET" || !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(c)
+}
+
+func (s *Server) revokeConsent(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if r.Method != "DELETE" || q.Get("subject") == "" || q.Get("client") == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mtx.Lock()
+	s.revocations = append(s.revocations, Revocation{Subject: q.Get("subject"), Client: q.Get("client")})
+	s.mtx.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) keys(w http.ResponseWriter, r *http.Request) {
+	b64 := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "private",
+			"n":   b64(s.Key.N),
+			"e":   b64(big.NewInt(int64(s.Key.E))),
+			"d":   b64(s.Key.D),
+			"p":   b64(s.Key.Primes[0]),
+			"q":   b64(s.Key.Primes[1]),
+		}},
+	})
+}
@@ -0,0 +1,126 @@
+package hydra
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// logoutTokenTTL is how long clients should accept a logout token for.
+const logoutTokenTTL = 2 * time.Minute
+
+// backChannelLogoutEvent marks a JWT as a logout token.
+const backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+// jwk is an RSA private key, as served from Hydra's key sets.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	D   string `json:"d"`
+	P   string `json:"p"`
+	Q   string `json:"q"`
+}
+
+func (k jwk) privateKey() (*rsa.PrivateKey, error) {
+	if k.Kty != "RSA" {
+		return nil, fmt.Errorf("hydra: key %s is not an RSA key", k.Kid)
+	}
+	var ints [5]*big.Int
+	for i, v := range []string{k.N, k.E, k.D, k.P, k.Q} {
+		b, err := base64.RawURLEncoding.DecodeString(v)
+		if err != nil || len(b) == 0 {
+			return nil, fmt.Errorf("hydra: key %s is not a private key", k.Kid)
+		}
+		ints[i] = new(big.Int).SetBytes(b)
+	}
+	if !ints[1].IsInt64() || ints[1].Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("hydra: key %s has an invalid exponent", k.Kid)
+	}
+	key := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: ints[0], E: int(ints[1].Int64())},
+		D:         ints[2],
+		Primes:    []*big.Int{ints[3], ints[4]},
+	}
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("hydra: key %s is invalid: %v", k.Kid, err)
+	}
+	key.Precompute()
+	return key, nil
+}
+
+// signingKey returns the private key Hydra signs ID tokens with, and the ID clients know it by.
+func (a *Admin) signingKey(ctx context.Context) (string, *rsa.PrivateKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := a.do(ctx, "GET", "/keys/"+idTokenKeySet+"/private", &set); err != nil {
+		return "", nil, err
+	}
+	if len(set.Keys) == 0 {
+		return "", nil, fmt.Errorf("hydra: key set %s has no private key", idTokenKeySet)
+	}
+	key, err := set.Keys[0].privateKey()
+	if err != nil {
+		return "", nil, err
+	}
+	// Hydra names the halves of a key pair alike, but for "private" and "public", and only the public half is
+	// published for clients to look up.
+	return strings.Replace(set.Keys[0].Kid, "private", "public", 1), key, nil
+}
+
+// LogoutToken returns a back-channel logout token telling a client that a user has logged out. sid identifies the
+// session they logged out of, as the sid claim of the ID tokens issued during it did, and may be "" if it isn't
+// known. The token is signed with the key Hydra signs ID tokens with, so that clients can verify it the same way.
+func (a *Admin) LogoutToken(ctx context.Context, clientID, subject, sid string) (string, error) {
+	kid, key, err := a.signingKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":    a.issuer(),
+		"aud":    clientID,
+		"iat":    now.Unix(),
+		"exp":    now.Add(logoutTokenTTL).Unix(),
+		"jti":    base64.RawURLEncoding.EncodeToString(jti),
+		"sub":    subject,
+		"events": map[string]interface{}{backChannelLogoutEvent: map[string]interface{}{}},
+	}
+	if sid != "" {
+		claims["sid"] = sid
+	}
+	return sign(kid, key, claims)
+}
+
+// sign returns claims as a compact JWS, typed as a logout token and signed with RS256.
+func sign(kid string, key *rsa.PrivateKey, claims interface{}) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": kid, "typ": "logout+jwt"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sum := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
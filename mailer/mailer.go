@@ -0,0 +1,65 @@
+// Package mailer delivers outbound email for usersvc.
+package mailer
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends messages to users.
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// NewWriter returns a Mailer that prints each message to w. It is meant as a stand-in for a real mail server
+// during development, e.g. NewWriter(os.Stdout).
+func NewWriter(w io.Writer) Mailer {
+	return &writerMailer{w: w}
+}
+
+type writerMailer struct {
+	mtx sync.Mutex
+	w   io.Writer
+}
+
+func (m *writerMailer) Send(msg Message) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return write(m.w, msg)
+}
+
+// NewDir returns a Mailer that writes each message to its own file in dir. It is meant for tests, which can read
+// the delivered messages back from disk.
+func NewDir(dir string) (Mailer, error) {
+	if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+		return nil, err
+	}
+	return dirMailer(dir), nil
+}
+
+type dirMailer string
+
+func (m dirMailer) Send(msg Message) error {
+	f, err := ioutil.TempFile(string(m), fmt.Sprintf("%d-", time.Now().UnixNano()))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return write(f, msg)
+}
+
+func write(w io.Writer, msg Message) error {
+	_, err := fmt.Fprintf(w, "To: %s\r\nSubject: %s\r\n\r\n%s\r\n", msg.To, msg.Subject, msg.Body)
+	return err
+}
@@ -20,7 +20,12 @@
 
 package main
 
-import "github.com/studiously/usersvc/cmd"
+import (
+	// The image is built from scratch, so time zones for validating profiles have to be compiled in.
+	_ "time/tzdata"
+
+	"github.com/studiously/usersvc/cmd"
+)
 
 func main() {
 	cmd.Execute()
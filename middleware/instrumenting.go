@@ -3,30 +3,37 @@ package middleware
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/google/uuid"
 	"github.com/studiously/usersvc/models"
+	"github.com/studiously/usersvc/oidc"
 	"github.com/studiously/usersvc/usersvc"
+	"github.com/studiously/usersvc/webauthn"
 )
 
+// Instrumenting records the count and latency of every call. loginFailures additionally counts each failed
+// Authenticate call, labelled with the reason it failed.
 func Instrumenting(
 	requestCount metrics.Counter,
 	requestLatency metrics.Histogram,
+	loginFailures metrics.Counter,
 ) Middleware {
 	return func(next usersvc.Service) usersvc.Service {
-		return instrumentingMiddleware{requestCount, requestLatency, next}
+		return instrumentingMiddleware{requestCount, requestLatency, loginFailures, next}
 	}
 }
 
 type instrumentingMiddleware struct {
 	requestCount   metrics.Counter
 	requestLatency metrics.Histogram
+	loginFailures  metrics.Counter
 	next           usersvc.Service
 }
 
-func (im instrumentingMiddleware) GetProfile(ctx context.Context, userID uuid.UUID) (name string, err error) {
+func (im instrumentingMiddleware) GetProfile(ctx context.Context, userID uuid.UUID) (profile *usersvc.Profile, err error) {
 	defer func(begin time.Time) {
 		lvs := []string{"method", "GetProfile", "error", fmt.Sprint(err != nil)}
 		im.requestCount.With(lvs...).Add(1)
@@ -35,6 +42,24 @@ func (im instrumentingMiddleware) GetProfile(ctx context.Context, userID uuid.UU
 	return im.next.GetProfile(ctx, userID)
 }
 
+func (im instrumentingMiddleware) GetProfiles(ctx context.Context, userIDs []uuid.UUID) (profiles map[uuid.UUID]*usersvc.Profile, missing []uuid.UUID, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "GetProfiles", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.GetProfiles(ctx, userIDs)
+}
+
+func (im instrumentingMiddleware) SearchUsers(ctx context.Context, query string, limit int, cursor string) (profiles []*usersvc.Profile, next string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "SearchUsers", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.SearchUsers(ctx, query, limit, cursor)
+}
+
 func (im instrumentingMiddleware) GetUserInfo(ctx context.Context) (user *models.User, err error) {
 	defer func(begin time.Time) {
 		lvs := []string{"method", "GetUserInfo", "error", fmt.Sprint(err != nil)}
@@ -62,6 +87,42 @@ func (im instrumentingMiddleware) SetName(ctx context.Context, name string) (err
 	return im.next.SetName(ctx, name)
 }
 
+func (im instrumentingMiddleware) SetProfile(ctx context.Context, update usersvc.ProfileUpdate) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "SetProfile", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.SetProfile(ctx, update)
+}
+
+func (im instrumentingMiddleware) SetAvatar(ctx context.Context, contentType string, image io.Reader) (picture string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "SetAvatar", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.SetAvatar(ctx, contentType, image)
+}
+
+func (im instrumentingMiddleware) DeleteAvatar(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "DeleteAvatar", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.DeleteAvatar(ctx)
+}
+
+func (im instrumentingMiddleware) GetAvatar(ctx context.Context, userID uuid.UUID, size int) (avatar io.ReadCloser, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "GetAvatar", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.GetAvatar(ctx, userID, size)
+}
+
 func (im instrumentingMiddleware) SetEmail(ctx context.Context, email string) (err error) {
 	defer func(begin time.Time) {
 		lvs := []string{"method", "SetEmail", "error", fmt.Sprint(err != nil)}
@@ -80,13 +141,21 @@ func (im instrumentingMiddleware) SetPassword(ctx context.Context, password stri
 	return im.next.SetPassword(ctx, password)
 }
 
-func (im instrumentingMiddleware) Authenticate(email string, password string) (userID uuid.UUID, err error) {
+func (im instrumentingMiddleware) Authenticate(ctx context.Context, email string, password string) (userID uuid.UUID, err error) {
 	defer func(begin time.Time) {
 		lvs := []string{"method", "Authenticate", "error", fmt.Sprint(err != nil)}
 		im.requestCount.With(lvs...).Add(1)
 		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+		switch err {
+		case usersvc.ErrWrongEmail:
+			im.loginFailures.With("reason", "wrong_email").Add(1)
+		case usersvc.ErrWrongPassword:
+			im.loginFailures.With("reason", "wrong_password").Add(1)
+		case usersvc.ErrTooManyAttempts:
+			im.loginFailures.With("reason", "too_many_attempts").Add(1)
+		}
 	}(time.Now())
-	return im.next.Authenticate(email, password)
+	return im.next.Authenticate(ctx, email, password)
 }
 
 func (im instrumentingMiddleware) DeleteUser(ctx context.Context) (err error) {
@@ -98,6 +167,15 @@ func (im instrumentingMiddleware) DeleteUser(ctx context.Context) (err error) {
 	return im.next.DeleteUser(ctx)
 }
 
+func (im instrumentingMiddleware) RestoreUser(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "RestoreUser", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.RestoreUser(ctx)
+}
+
 func (im instrumentingMiddleware) ResetPassword(ctx context.Context, email string) (err error) {
 	defer func(begin time.Time) {
 		lvs := []string{"method", "ResetPassword", "error", fmt.Sprint(err != nil)}
@@ -106,3 +184,386 @@ func (im instrumentingMiddleware) ResetPassword(ctx context.Context, email strin
 	}(time.Now())
 	return im.next.ResetPassword(ctx, email)
 }
+
+func (im instrumentingMiddleware) ConfirmPasswordReset(ctx context.Context, token, password string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "ConfirmPasswordReset", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.ConfirmPasswordReset(ctx, token, password)
+}
+
+func (im instrumentingMiddleware) VerifyEmail(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "VerifyEmail", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.VerifyEmail(ctx, token)
+}
+
+func (im instrumentingMiddleware) EnrollTOTP(ctx context.Context) (secret, uri string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "EnrollTOTP", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.EnrollTOTP(ctx)
+}
+
+func (im instrumentingMiddleware) EnableTOTP(ctx context.Context, code string) (recoveryCodes []string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "EnableTOTP", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.EnableTOTP(ctx, code)
+}
+
+func (im instrumentingMiddleware) DisableTOTP(ctx context.Context, code string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "DisableTOTP", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.DisableTOTP(ctx, code)
+}
+
+func (im instrumentingMiddleware) RegenerateRecoveryCodes(ctx context.Context, code string) (recoveryCodes []string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "RegenerateRecoveryCodes", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.RegenerateRecoveryCodes(ctx, code)
+}
+
+func (im instrumentingMiddleware) TwoFactorEnabled(ctx context.Context, userID uuid.UUID) (enabled bool, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "TwoFactorEnabled", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.TwoFactorEnabled(ctx, userID)
+}
+
+func (im instrumentingMiddleware) VerifyTwoFactor(ctx context.Context, userID uuid.UUID, code string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "VerifyTwoFactor", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+		switch err {
+		case usersvc.ErrWrongCode:
+			im.loginFailures.With("reason", "wrong_code").Add(1)
+		case usersvc.ErrTooManyAttempts:
+			im.loginFailures.With("reason", "too_many_attempts").Add(1)
+		}
+	}(time.Now())
+	return im.next.VerifyTwoFactor(ctx, userID, code)
+}
+
+func (im instrumentingMiddleware) BeginPasskeyRegistration(ctx context.Context) (opts *webauthn.CreationOptions, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "BeginPasskeyRegistration", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.BeginPasskeyRegistration(ctx)
+}
+
+func (im instrumentingMiddleware) FinishPasskeyRegistration(ctx context.Context, name string, response webauthn.AttestationResponse) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "FinishPasskeyRegistration", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.FinishPasskeyRegistration(ctx, name, response)
+}
+
+func (im instrumentingMiddleware) BeginPasskeyLogin(ctx context.Context) (opts *webauthn.RequestOptions, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "BeginPasskeyLogin", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.BeginPasskeyLogin(ctx)
+}
+
+func (im instrumentingMiddleware) AuthenticatePasskey(ctx context.Context, response webauthn.AssertionResponse) (userID uuid.UUID, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "AuthenticatePasskey", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+		if err == usersvc.ErrInvalidCredential {
+			im.loginFailures.With("reason", "invalid_passkey").Add(1)
+		}
+	}(time.Now())
+	return im.next.AuthenticatePasskey(ctx, response)
+}
+
+// IdentityProviders only reads configuration, so it isn't worth measuring.
+func (im instrumentingMiddleware) IdentityProviders(ctx context.Context) []usersvc.IdentityProvider {
+	return im.next.IdentityProviders(ctx)
+}
+
+func (im instrumentingMiddleware) BeginFederatedLogin(ctx context.Context, provider string) (req *oidc.AuthRequest, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "BeginFederatedLogin", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.BeginFederatedLogin(ctx, provider)
+}
+
+func (im instrumentingMiddleware) FederatedLogin(ctx context.Context, provider string, req oidc.AuthRequest, code string) (userID uuid.UUID, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "FederatedLogin", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.FederatedLogin(ctx, provider, req, code)
+}
+
+func (im instrumentingMiddleware) LinkIdentity(ctx context.Context, userID uuid.UUID, provider string, req oidc.AuthRequest, code string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "LinkIdentity", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.LinkIdentity(ctx, userID, provider, req, code)
+}
+
+func (im instrumentingMiddleware) ListIdentities(ctx context.Context) (identities []*models.FederatedIdentity, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "ListIdentities", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.ListIdentities(ctx)
+}
+
+func (im instrumentingMiddleware) UnlinkIdentity(ctx context.Context, provider string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "UnlinkIdentity", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.UnlinkIdentity(ctx, provider)
+}
+
+func (im instrumentingMiddleware) StartExport(ctx context.Context) (export *usersvc.Export, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "StartExport", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.StartExport(ctx)
+}
+
+func (im instrumentingMiddleware) GetExport(ctx context.Context, exportID uuid.UUID) (export *usersvc.Export, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "GetExport", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.GetExport(ctx, exportID)
+}
+
+func (im instrumentingMiddleware) DownloadExport(ctx context.Context, exportID uuid.UUID, token string) (export io.ReadCloser, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "DownloadExport", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.DownloadExport(ctx, exportID, token)
+}
+
+func (im instrumentingMiddleware) CreateSession(ctx context.Context, userID uuid.UUID, remember bool) (token string, expiresAt time.Time, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "CreateSession", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.CreateSession(ctx, userID, remember)
+}
+
+func (im instrumentingMiddleware) CheckSession(ctx context.Context, token string) (userID uuid.UUID, authTime time.Time, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "CheckSession", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.CheckSession(ctx, token)
+}
+
+func (im instrumentingMiddleware) EndSession(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "EndSession", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.EndSession(ctx, token)
+}
+
+func (im instrumentingMiddleware) AddSessionClient(ctx context.Context, token, clientID string, scopes []string) (sessionID uuid.UUID, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "AddSessionClient", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.AddSessionClient(ctx, token, clientID, scopes)
+}
+
+func (im instrumentingMiddleware) LogOut(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "LogOut", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.LogOut(ctx, token)
+}
+
+func (im instrumentingMiddleware) CheckLogoutRedirect(ctx context.Context, clientID, redirectURI string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "CheckLogoutRedirect", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.CheckLogoutRedirect(ctx, clientID, redirectURI)
+}
+
+func (im instrumentingMiddleware) RememberedScopes(ctx context.Context, userID uuid.UUID, clientID string) (scopes []string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "RememberedScopes", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.RememberedScopes(ctx, userID, clientID)
+}
+
+func (im instrumentingMiddleware) RememberConsent(ctx context.Context, userID uuid.UUID, clientID string, scopes []string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "RememberConsent", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.RememberConsent(ctx, userID, clientID, scopes)
+}
+
+func (im instrumentingMiddleware) ListApps(ctx context.Context) (apps []*usersvc.App, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "ListApps", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.ListApps(ctx)
+}
+
+func (im instrumentingMiddleware) RevokeApp(ctx context.Context, clientID string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "RevokeApp", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.RevokeApp(ctx, clientID)
+}
+
+func (im instrumentingMiddleware) ListSessions(ctx context.Context) (sessions []*usersvc.Session, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "ListSessions", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.ListSessions(ctx)
+}
+
+func (im instrumentingMiddleware) RevokeSession(ctx context.Context, sessionID uuid.UUID) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "RevokeSession", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.RevokeSession(ctx, sessionID)
+}
+
+func (im instrumentingMiddleware) ListUsers(ctx context.Context, filter usersvc.UserFilter) (users []*models.User, cursor string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "ListUsers", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.ListUsers(ctx, filter)
+}
+
+func (im instrumentingMiddleware) GetUser(ctx context.Context, userID uuid.UUID) (user *models.User, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "GetUser", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.GetUser(ctx, userID)
+}
+
+func (im instrumentingMiddleware) ForcePasswordReset(ctx context.Context, userID uuid.UUID) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "ForcePasswordReset", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.ForcePasswordReset(ctx, userID)
+}
+
+func (im instrumentingMiddleware) SetUserActive(ctx context.Context, userID uuid.UUID, active bool, reason string) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "SetUserActive", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.SetUserActive(ctx, userID, active, reason)
+}
+
+func (im instrumentingMiddleware) PurgeUser(ctx context.Context, userID uuid.UUID) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "PurgeUser", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.PurgeUser(ctx, userID)
+}
+
+func (im instrumentingMiddleware) ListStateChanges(ctx context.Context, userID uuid.UUID) (changes []*models.UserStateChange, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "ListStateChanges", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.ListStateChanges(ctx, userID)
+}
+
+func (im instrumentingMiddleware) ListAuditEvents(ctx context.Context, filter usersvc.AuditFilter) (events []*models.AuditEvent, cursor string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "ListAuditEvents", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.ListAuditEvents(ctx, filter)
+}
+
+func (im instrumentingMiddleware) VerifyAuditLog(ctx context.Context) (brokenAt int64, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "VerifyAuditLog", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.VerifyAuditLog(ctx)
+}
+
+func (im instrumentingMiddleware) PurgeDeletedUsers(ctx context.Context) (purged []uuid.UUID, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "PurgeDeletedUsers", "error", fmt.Sprint(err != nil)}
+		im.requestCount.With(lvs...).Add(1)
+		im.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return im.next.PurgeDeletedUsers(ctx)
+}
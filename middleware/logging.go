@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/go-kit/kit/log"
@@ -9,7 +10,9 @@ import (
 	"github.com/ory/hydra/oauth2"
 	"github.com/studiously/introspector"
 	"github.com/studiously/usersvc/models"
+	"github.com/studiously/usersvc/oidc"
 	"github.com/studiously/usersvc/usersvc"
+	"github.com/studiously/usersvc/webauthn"
 )
 
 func Logging(logger log.Logger) Middleware {
@@ -26,7 +29,7 @@ type loggingMiddleware struct {
 	next   usersvc.Service
 }
 
-func (lm loggingMiddleware) GetProfile(ctx context.Context, userID uuid.UUID) (name string, err error) {
+func (lm loggingMiddleware) GetProfile(ctx context.Context, userID uuid.UUID) (profile *usersvc.Profile, err error) {
 	defer func(begin time.Time) {
 		lm.logger.Log(
 			"action", "GetProfile",
@@ -40,6 +43,35 @@ func (lm loggingMiddleware) GetProfile(ctx context.Context, userID uuid.UUID) (n
 	return lm.next.GetProfile(ctx, userID)
 }
 
+func (lm loggingMiddleware) GetProfiles(ctx context.Context, userIDs []uuid.UUID) (profiles map[uuid.UUID]*usersvc.Profile, missing []uuid.UUID, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "GetProfiles",
+			"user", subj(ctx).String(),
+			"client", cli(ctx),
+			"targets", len(userIDs),
+			"missing", len(missing),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.GetProfiles(ctx, userIDs)
+}
+
+func (lm loggingMiddleware) SearchUsers(ctx context.Context, query string, limit int, cursor string) (profiles []*usersvc.Profile, next string, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "SearchUsers",
+			"user", subj(ctx).String(),
+			"client", cli(ctx),
+			"results", len(profiles),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.SearchUsers(ctx, query, limit, cursor)
+}
+
 func (lm loggingMiddleware) GetUserInfo(ctx context.Context) (user *models.User, err error) {
 	defer func(begin time.Time) {
 		lm.logger.Log(
@@ -77,6 +109,59 @@ func (lm loggingMiddleware) SetName(ctx context.Context, name string) (err error
 	return lm.next.SetName(ctx, name)
 }
 
+func (lm loggingMiddleware) SetProfile(ctx context.Context, update usersvc.ProfileUpdate) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "SetProfile",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.SetProfile(ctx, update)
+}
+
+func (lm loggingMiddleware) SetAvatar(ctx context.Context, contentType string, image io.Reader) (picture string, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "SetAvatar",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"content_type", contentType,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.SetAvatar(ctx, contentType, image)
+}
+
+func (lm loggingMiddleware) DeleteAvatar(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "DeleteAvatar",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.DeleteAvatar(ctx)
+}
+
+func (lm loggingMiddleware) GetAvatar(ctx context.Context, userID uuid.UUID, size int) (avatar io.ReadCloser, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "GetAvatar",
+			"target", userID,
+			"size", size,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.GetAvatar(ctx, userID, size)
+}
+
 func (lm loggingMiddleware) SetEmail(ctx context.Context, email string) (err error) {
 	defer func(begin time.Time) {
 		lm.logger.Log(
@@ -103,15 +188,16 @@ func (lm loggingMiddleware) SetPassword(ctx context.Context, password string) (e
 	return lm.next.SetPassword(ctx, password)
 }
 
-func (lm loggingMiddleware) Authenticate(email string, password string) (user uuid.UUID, err error) {
+func (lm loggingMiddleware) Authenticate(ctx context.Context, email string, password string) (user uuid.UUID, err error) {
 	defer func(begin time.Time) {
 		lm.logger.Log(
 			"action", "Authenticate",
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
 			"duration", time.Since(begin),
 			"error", err,
 		)
 	}(time.Now())
-	return lm.next.Authenticate(email, password)
+	return lm.next.Authenticate(ctx, email, password)
 }
 
 func (lm loggingMiddleware) DeleteUser(ctx context.Context) (err error) {
@@ -126,11 +212,22 @@ func (lm loggingMiddleware) DeleteUser(ctx context.Context) (err error) {
 	return lm.next.DeleteUser(ctx)
 }
 
+func (lm loggingMiddleware) RestoreUser(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "RestoreUser",
+			"user", subj(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.RestoreUser(ctx)
+}
+
 func (lm loggingMiddleware) ResetPassword(ctx context.Context, email string) (err error) {
 	defer func(begin time.Time) {
 		lm.logger.Log(
 			"action", "ResetPassword",
-			"user", subj(ctx),
 			"duration", time.Since(begin),
 			"error", err,
 		)
@@ -138,6 +235,556 @@ func (lm loggingMiddleware) ResetPassword(ctx context.Context, email string) (er
 	return lm.next.ResetPassword(ctx, email)
 }
 
+func (lm loggingMiddleware) ConfirmPasswordReset(ctx context.Context, token, password string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "ConfirmPasswordReset",
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.ConfirmPasswordReset(ctx, token, password)
+}
+
+func (lm loggingMiddleware) VerifyEmail(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "VerifyEmail",
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.VerifyEmail(ctx, token)
+}
+
+func (lm loggingMiddleware) EnrollTOTP(ctx context.Context) (secret, uri string, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "EnrollTOTP",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.EnrollTOTP(ctx)
+}
+
+func (lm loggingMiddleware) EnableTOTP(ctx context.Context, code string) (recoveryCodes []string, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "EnableTOTP",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.EnableTOTP(ctx, code)
+}
+
+func (lm loggingMiddleware) DisableTOTP(ctx context.Context, code string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "DisableTOTP",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.DisableTOTP(ctx, code)
+}
+
+func (lm loggingMiddleware) RegenerateRecoveryCodes(ctx context.Context, code string) (recoveryCodes []string, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "RegenerateRecoveryCodes",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.RegenerateRecoveryCodes(ctx, code)
+}
+
+func (lm loggingMiddleware) TwoFactorEnabled(ctx context.Context, userID uuid.UUID) (enabled bool, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "TwoFactorEnabled",
+			"target", userID.String(),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.TwoFactorEnabled(ctx, userID)
+}
+
+func (lm loggingMiddleware) VerifyTwoFactor(ctx context.Context, userID uuid.UUID, code string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "VerifyTwoFactor",
+			"target", userID.String(),
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.VerifyTwoFactor(ctx, userID, code)
+}
+
+func (lm loggingMiddleware) BeginPasskeyRegistration(ctx context.Context) (opts *webauthn.CreationOptions, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "BeginPasskeyRegistration",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.BeginPasskeyRegistration(ctx)
+}
+
+func (lm loggingMiddleware) FinishPasskeyRegistration(ctx context.Context, name string, response webauthn.AttestationResponse) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "FinishPasskeyRegistration",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.FinishPasskeyRegistration(ctx, name, response)
+}
+
+func (lm loggingMiddleware) BeginPasskeyLogin(ctx context.Context) (opts *webauthn.RequestOptions, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "BeginPasskeyLogin",
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.BeginPasskeyLogin(ctx)
+}
+
+func (lm loggingMiddleware) AuthenticatePasskey(ctx context.Context, response webauthn.AssertionResponse) (userID uuid.UUID, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "AuthenticatePasskey",
+			"user", userID,
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.AuthenticatePasskey(ctx, response)
+}
+
+// IdentityProviders only reads configuration, so it isn't worth logging.
+func (lm loggingMiddleware) IdentityProviders(ctx context.Context) []usersvc.IdentityProvider {
+	return lm.next.IdentityProviders(ctx)
+}
+
+func (lm loggingMiddleware) BeginFederatedLogin(ctx context.Context, provider string) (req *oidc.AuthRequest, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "BeginFederatedLogin",
+			"provider", provider,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.BeginFederatedLogin(ctx, provider)
+}
+
+func (lm loggingMiddleware) FederatedLogin(ctx context.Context, provider string, req oidc.AuthRequest, code string) (userID uuid.UUID, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "FederatedLogin",
+			"provider", provider,
+			"user", userID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.FederatedLogin(ctx, provider, req, code)
+}
+
+func (lm loggingMiddleware) LinkIdentity(ctx context.Context, userID uuid.UUID, provider string, req oidc.AuthRequest, code string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "LinkIdentity",
+			"provider", provider,
+			"user", userID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.LinkIdentity(ctx, userID, provider, req, code)
+}
+
+func (lm loggingMiddleware) ListIdentities(ctx context.Context) (identities []*models.FederatedIdentity, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "ListIdentities",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.ListIdentities(ctx)
+}
+
+func (lm loggingMiddleware) UnlinkIdentity(ctx context.Context, provider string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "UnlinkIdentity",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"provider", provider,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.UnlinkIdentity(ctx, provider)
+}
+
+func (lm loggingMiddleware) StartExport(ctx context.Context) (export *usersvc.Export, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "StartExport",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.StartExport(ctx)
+}
+
+func (lm loggingMiddleware) GetExport(ctx context.Context, exportID uuid.UUID) (export *usersvc.Export, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "GetExport",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"export", exportID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.GetExport(ctx, exportID)
+}
+
+func (lm loggingMiddleware) DownloadExport(ctx context.Context, exportID uuid.UUID, token string) (export io.ReadCloser, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "DownloadExport",
+			"export", exportID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.DownloadExport(ctx, exportID, token)
+}
+
+func (lm loggingMiddleware) CreateSession(ctx context.Context, userID uuid.UUID, remember bool) (token string, expiresAt time.Time, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "CreateSession",
+			"user", userID,
+			"remember", remember,
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.CreateSession(ctx, userID, remember)
+}
+
+func (lm loggingMiddleware) CheckSession(ctx context.Context, token string) (userID uuid.UUID, authTime time.Time, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "CheckSession",
+			"user", userID,
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.CheckSession(ctx, token)
+}
+
+func (lm loggingMiddleware) EndSession(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "EndSession",
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.EndSession(ctx, token)
+}
+
+func (lm loggingMiddleware) AddSessionClient(ctx context.Context, token, clientID string, scopes []string) (sessionID uuid.UUID, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "AddSessionClient",
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
+			"client_id", clientID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.AddSessionClient(ctx, token, clientID, scopes)
+}
+
+func (lm loggingMiddleware) LogOut(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "LogOut",
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.LogOut(ctx, token)
+}
+
+func (lm loggingMiddleware) CheckLogoutRedirect(ctx context.Context, clientID, redirectURI string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "CheckLogoutRedirect",
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
+			"client_id", clientID,
+			"redirect_uri", redirectURI,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.CheckLogoutRedirect(ctx, clientID, redirectURI)
+}
+
+func (lm loggingMiddleware) RememberedScopes(ctx context.Context, userID uuid.UUID, clientID string) (scopes []string, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "RememberedScopes",
+			"user", userID,
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
+			"client_id", clientID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.RememberedScopes(ctx, userID, clientID)
+}
+
+func (lm loggingMiddleware) RememberConsent(ctx context.Context, userID uuid.UUID, clientID string, scopes []string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "RememberConsent",
+			"user", userID,
+			"ip", ctx.Value(usersvc.RemoteAddrContextKey),
+			"client_id", clientID,
+			"scopes", scopes,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.RememberConsent(ctx, userID, clientID, scopes)
+}
+
+func (lm loggingMiddleware) ListApps(ctx context.Context) (apps []*usersvc.App, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "ListApps",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.ListApps(ctx)
+}
+
+func (lm loggingMiddleware) RevokeApp(ctx context.Context, clientID string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "RevokeApp",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"client_id", clientID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.RevokeApp(ctx, clientID)
+}
+
+func (lm loggingMiddleware) ListSessions(ctx context.Context) (sessions []*usersvc.Session, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "ListSessions",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.ListSessions(ctx)
+}
+
+func (lm loggingMiddleware) RevokeSession(ctx context.Context, sessionID uuid.UUID) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "RevokeSession",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"session", sessionID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.RevokeSession(ctx, sessionID)
+}
+
+func (lm loggingMiddleware) ListUsers(ctx context.Context, filter usersvc.UserFilter) (users []*models.User, cursor string, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "ListUsers",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"email", filter.Email,
+			"name", filter.Name,
+			"cursor", filter.Cursor,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.ListUsers(ctx, filter)
+}
+
+func (lm loggingMiddleware) GetUser(ctx context.Context, userID uuid.UUID) (user *models.User, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "GetUser",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"target", userID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.GetUser(ctx, userID)
+}
+
+func (lm loggingMiddleware) ForcePasswordReset(ctx context.Context, userID uuid.UUID) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "ForcePasswordReset",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"target", userID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.ForcePasswordReset(ctx, userID)
+}
+
+func (lm loggingMiddleware) SetUserActive(ctx context.Context, userID uuid.UUID, active bool, reason string) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "SetUserActive",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"target", userID,
+			"active", active,
+			"reason", reason,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.SetUserActive(ctx, userID, active, reason)
+}
+
+func (lm loggingMiddleware) PurgeUser(ctx context.Context, userID uuid.UUID) (err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "PurgeUser",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"target", userID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.PurgeUser(ctx, userID)
+}
+
+func (lm loggingMiddleware) ListStateChanges(ctx context.Context, userID uuid.UUID) (changes []*models.UserStateChange, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "ListStateChanges",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"target", userID,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.ListStateChanges(ctx, userID)
+}
+
+func (lm loggingMiddleware) ListAuditEvents(ctx context.Context, filter usersvc.AuditFilter) (events []*models.AuditEvent, cursor string, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "ListAuditEvents",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"target", filter.UserID,
+			"type", filter.Type,
+			"cursor", filter.Cursor,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.ListAuditEvents(ctx, filter)
+}
+
+func (lm loggingMiddleware) VerifyAuditLog(ctx context.Context) (brokenAt int64, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "VerifyAuditLog",
+			"user", subj(ctx),
+			"client", cli(ctx),
+			"broken_at", brokenAt,
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.VerifyAuditLog(ctx)
+}
+
+func (lm loggingMiddleware) PurgeDeletedUsers(ctx context.Context) (purged []uuid.UUID, err error) {
+	defer func(begin time.Time) {
+		lm.logger.Log(
+			"action", "PurgeDeletedUsers",
+			"purged", len(purged),
+			"duration", time.Since(begin),
+			"error", err,
+		)
+	}(time.Now())
+	return lm.next.PurgeDeletedUsers(ctx)
+}
+
 func cli(ctx context.Context) string {
 	return ctx.Value(introspector.OAuth2IntrospectionContextKey).(oauth2.Introspection).ClientID
 }
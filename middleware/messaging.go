@@ -2,11 +2,15 @@ package middleware
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/nats-io/go-nats"
 	"github.com/studiously/usersvc/models"
+	"github.com/studiously/usersvc/oidc"
 	"github.com/studiously/usersvc/usersvc"
+	"github.com/studiously/usersvc/webauthn"
 )
 
 const (
@@ -24,10 +28,18 @@ type messagingMiddleware struct {
 	next usersvc.Service
 }
 
-func (mm messagingMiddleware) GetProfile(ctx context.Context, userID uuid.UUID) (name string, err error) {
+func (mm messagingMiddleware) GetProfile(ctx context.Context, userID uuid.UUID) (profile *usersvc.Profile, err error) {
 	return mm.next.GetProfile(ctx, userID)
 }
 
+func (mm messagingMiddleware) GetProfiles(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*usersvc.Profile, []uuid.UUID, error) {
+	return mm.next.GetProfiles(ctx, userIDs)
+}
+
+func (mm messagingMiddleware) SearchUsers(ctx context.Context, query string, limit int, cursor string) ([]*usersvc.Profile, string, error) {
+	return mm.next.SearchUsers(ctx, query, limit, cursor)
+}
+
 func (mm messagingMiddleware) GetUserInfo(ctx context.Context) (user *models.User, err error) {
 	return mm.next.GetUserInfo(ctx)
 }
@@ -40,6 +52,22 @@ func (mm messagingMiddleware) SetName(ctx context.Context, name string) error {
 	return mm.next.SetName(ctx, name)
 }
 
+func (mm messagingMiddleware) SetProfile(ctx context.Context, update usersvc.ProfileUpdate) error {
+	return mm.next.SetProfile(ctx, update)
+}
+
+func (mm messagingMiddleware) SetAvatar(ctx context.Context, contentType string, image io.Reader) (string, error) {
+	return mm.next.SetAvatar(ctx, contentType, image)
+}
+
+func (mm messagingMiddleware) DeleteAvatar(ctx context.Context) error {
+	return mm.next.DeleteAvatar(ctx)
+}
+
+func (mm messagingMiddleware) GetAvatar(ctx context.Context, userID uuid.UUID, size int) (io.ReadCloser, error) {
+	return mm.next.GetAvatar(ctx, userID, size)
+}
+
 func (mm messagingMiddleware) SetEmail(ctx context.Context, email string) error {
 	return mm.next.SetEmail(ctx, email)
 }
@@ -48,22 +76,200 @@ func (mm messagingMiddleware) SetPassword(ctx context.Context, password string)
 	return mm.next.SetPassword(ctx, password)
 }
 
-func (mm messagingMiddleware) Authenticate(email string, password string) (uuid.UUID, error) {
-	return mm.next.Authenticate(email, password)
+func (mm messagingMiddleware) Authenticate(ctx context.Context, email string, password string) (uuid.UUID, error) {
+	return mm.next.Authenticate(ctx, email, password)
+}
+
+func (mm messagingMiddleware) DeleteUser(ctx context.Context) error {
+	// Other services keep the user's data until they are purged, in case they restore their account.
+	return mm.next.DeleteUser(ctx)
+}
+
+func (mm messagingMiddleware) RestoreUser(ctx context.Context) error {
+	return mm.next.RestoreUser(ctx)
+}
+
+func (mm messagingMiddleware) ResetPassword(ctx context.Context, email string) error {
+	return mm.next.ResetPassword(ctx, email)
+}
+
+func (mm messagingMiddleware) ConfirmPasswordReset(ctx context.Context, token, password string) error {
+	return mm.next.ConfirmPasswordReset(ctx, token, password)
+}
+
+func (mm messagingMiddleware) VerifyEmail(ctx context.Context, token string) error {
+	return mm.next.VerifyEmail(ctx, token)
+}
+
+func (mm messagingMiddleware) EnrollTOTP(ctx context.Context) (string, string, error) {
+	return mm.next.EnrollTOTP(ctx)
 }
 
-func (mm messagingMiddleware) DeleteUser(ctx context.Context) (err error) {
+func (mm messagingMiddleware) EnableTOTP(ctx context.Context, code string) ([]string, error) {
+	return mm.next.EnableTOTP(ctx, code)
+}
+
+func (mm messagingMiddleware) DisableTOTP(ctx context.Context, code string) error {
+	return mm.next.DisableTOTP(ctx, code)
+}
+
+func (mm messagingMiddleware) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	return mm.next.RegenerateRecoveryCodes(ctx, code)
+}
+
+func (mm messagingMiddleware) TwoFactorEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return mm.next.TwoFactorEnabled(ctx, userID)
+}
+
+func (mm messagingMiddleware) VerifyTwoFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	return mm.next.VerifyTwoFactor(ctx, userID, code)
+}
+
+func (mm messagingMiddleware) BeginPasskeyRegistration(ctx context.Context) (*webauthn.CreationOptions, error) {
+	return mm.next.BeginPasskeyRegistration(ctx)
+}
+
+func (mm messagingMiddleware) FinishPasskeyRegistration(ctx context.Context, name string, response webauthn.AttestationResponse) error {
+	return mm.next.FinishPasskeyRegistration(ctx, name, response)
+}
+
+func (mm messagingMiddleware) BeginPasskeyLogin(ctx context.Context) (*webauthn.RequestOptions, error) {
+	return mm.next.BeginPasskeyLogin(ctx)
+}
+
+func (mm messagingMiddleware) AuthenticatePasskey(ctx context.Context, response webauthn.AssertionResponse) (uuid.UUID, error) {
+	return mm.next.AuthenticatePasskey(ctx, response)
+}
+
+func (mm messagingMiddleware) IdentityProviders(ctx context.Context) []usersvc.IdentityProvider {
+	return mm.next.IdentityProviders(ctx)
+}
+
+func (mm messagingMiddleware) BeginFederatedLogin(ctx context.Context, provider string) (*oidc.AuthRequest, error) {
+	return mm.next.BeginFederatedLogin(ctx, provider)
+}
+
+func (mm messagingMiddleware) FederatedLogin(ctx context.Context, provider string, req oidc.AuthRequest, code string) (uuid.UUID, error) {
+	return mm.next.FederatedLogin(ctx, provider, req, code)
+}
+
+func (mm messagingMiddleware) LinkIdentity(ctx context.Context, userID uuid.UUID, provider string, req oidc.AuthRequest, code string) error {
+	return mm.next.LinkIdentity(ctx, userID, provider, req, code)
+}
+
+func (mm messagingMiddleware) ListIdentities(ctx context.Context) ([]*models.FederatedIdentity, error) {
+	return mm.next.ListIdentities(ctx)
+}
+
+func (mm messagingMiddleware) UnlinkIdentity(ctx context.Context, provider string) error {
+	return mm.next.UnlinkIdentity(ctx, provider)
+}
+
+func (mm messagingMiddleware) StartExport(ctx context.Context) (*usersvc.Export, error) {
+	return mm.next.StartExport(ctx)
+}
+
+func (mm messagingMiddleware) GetExport(ctx context.Context, exportID uuid.UUID) (*usersvc.Export, error) {
+	return mm.next.GetExport(ctx, exportID)
+}
+
+func (mm messagingMiddleware) DownloadExport(ctx context.Context, exportID uuid.UUID, token string) (io.ReadCloser, error) {
+	return mm.next.DownloadExport(ctx, exportID, token)
+}
+
+func (mm messagingMiddleware) CreateSession(ctx context.Context, userID uuid.UUID, remember bool) (string, time.Time, error) {
+	return mm.next.CreateSession(ctx, userID, remember)
+}
+
+func (mm messagingMiddleware) CheckSession(ctx context.Context, token string) (uuid.UUID, time.Time, error) {
+	return mm.next.CheckSession(ctx, token)
+}
+
+func (mm messagingMiddleware) EndSession(ctx context.Context, token string) error {
+	return mm.next.EndSession(ctx, token)
+}
+
+func (mm messagingMiddleware) AddSessionClient(ctx context.Context, token, clientID string, scopes []string) (uuid.UUID, error) {
+	return mm.next.AddSessionClient(ctx, token, clientID, scopes)
+}
+
+func (mm messagingMiddleware) LogOut(ctx context.Context, token string) error {
+	return mm.next.LogOut(ctx, token)
+}
+
+func (mm messagingMiddleware) CheckLogoutRedirect(ctx context.Context, clientID, redirectURI string) error {
+	return mm.next.CheckLogoutRedirect(ctx, clientID, redirectURI)
+}
+
+func (mm messagingMiddleware) RememberedScopes(ctx context.Context, userID uuid.UUID, clientID string) ([]string, error) {
+	return mm.next.RememberedScopes(ctx, userID, clientID)
+}
+
+func (mm messagingMiddleware) RememberConsent(ctx context.Context, userID uuid.UUID, clientID string, scopes []string) error {
+	return mm.next.RememberConsent(ctx, userID, clientID, scopes)
+}
+
+func (mm messagingMiddleware) ListApps(ctx context.Context) ([]*usersvc.App, error) {
+	return mm.next.ListApps(ctx)
+}
+
+func (mm messagingMiddleware) RevokeApp(ctx context.Context, clientID string) error {
+	return mm.next.RevokeApp(ctx, clientID)
+}
+
+func (mm messagingMiddleware) ListSessions(ctx context.Context) ([]*usersvc.Session, error) {
+	return mm.next.ListSessions(ctx)
+}
+
+func (mm messagingMiddleware) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	return mm.next.RevokeSession(ctx, sessionID)
+}
+
+func (mm messagingMiddleware) ListUsers(ctx context.Context, filter usersvc.UserFilter) ([]*models.User, string, error) {
+	return mm.next.ListUsers(ctx, filter)
+}
+
+func (mm messagingMiddleware) GetUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	return mm.next.GetUser(ctx, userID)
+}
+
+func (mm messagingMiddleware) ForcePasswordReset(ctx context.Context, userID uuid.UUID) error {
+	return mm.next.ForcePasswordReset(ctx, userID)
+}
+
+func (mm messagingMiddleware) SetUserActive(ctx context.Context, userID uuid.UUID, active bool, reason string) error {
+	return mm.next.SetUserActive(ctx, userID, active, reason)
+}
+
+func (mm messagingMiddleware) PurgeUser(ctx context.Context, userID uuid.UUID) (err error) {
 	defer func() {
 		if err == nil {
-			// Don't care about errors because worst-case scenario, some data doesn't get deleted.
-			// It's inaccessible and thus irrelevant.
-			userID, _ := subj(ctx).MarshalText()
-			mm.nc.Publish(SubjDeleteUser, userID)
+			id, _ := userID.MarshalText()
+			mm.nc.Publish(SubjDeleteUser, id)
 		}
 	}()
-	return mm.next.DeleteUser(ctx)
+	return mm.next.PurgeUser(ctx, userID)
 }
 
-func (mm messagingMiddleware) ResetPassword(ctx context.Context, email string) error {
-	return mm.next.ResetPassword(ctx, email)
+func (mm messagingMiddleware) ListStateChanges(ctx context.Context, userID uuid.UUID) ([]*models.UserStateChange, error) {
+	return mm.next.ListStateChanges(ctx, userID)
+}
+
+func (mm messagingMiddleware) ListAuditEvents(ctx context.Context, filter usersvc.AuditFilter) ([]*models.AuditEvent, string, error) {
+	return mm.next.ListAuditEvents(ctx, filter)
+}
+
+func (mm messagingMiddleware) VerifyAuditLog(ctx context.Context) (int64, error) {
+	return mm.next.VerifyAuditLog(ctx)
+}
+
+func (mm messagingMiddleware) PurgeDeletedUsers(ctx context.Context) (purged []uuid.UUID, err error) {
+	defer func() {
+		// Even on failure, the users that were purged are gone for good.
+		for _, userID := range purged {
+			id, _ := userID.MarshalText()
+			mm.nc.Publish(SubjDeleteUser, id)
+		}
+	}()
+	return mm.next.PurgeDeletedUsers(ctx)
 }
@@ -5,16 +5,25 @@ package models
 
 import (
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // User represents a row from 'public.users'.
 type User struct {
-	ID     uuid.UUID `json:"id"`     // id
-	Name   string    `json:"name"`   // name
-	Email  string    `json:"email"`  // email
-	Active bool      `json:"active"` // active
+	ID            uuid.UUID  `json:"id"`             // id
+	Name          string     `json:"name"`           // name
+	Email         string     `json:"email"`          // email
+	EmailVerified bool       `json:"email_verified"` // email_verified
+	GivenName     string     `json:"given_name"`     // given_name
+	FamilyName    string     `json:"family_name"`    // family_name
+	Pronouns      string     `json:"pronouns"`       // pronouns
+	Picture       string     `json:"picture"`        // picture
+	Locale        string     `json:"locale"`         // locale
+	Zoneinfo      string     `json:"zoneinfo"`       // zoneinfo
+	DeletedAt     *time.Time `json:"deleted_at"`     // deleted_at
+	State         string     `json:"state"`          // state
 
 	// xo fields
 	_exists, _deleted bool
@@ -41,14 +50,14 @@ func (u *User) Insert(db XODB) error {
 
 	// sql insert query, primary key must be provided
 	const sqlstr = `INSERT INTO public.users (` +
-		`id, name, email, active` +
+		`id, name, email, email_verified, given_name, family_name, pronouns, picture, locale, zoneinfo, deleted_at, state` +
 		`) VALUES (` +
-		`$1, $2, $3, $4` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12` +
 		`)`
 
 	// run query
-	XOLog(sqlstr, u.ID, u.Name, u.Email, u.Active)
-	_, err = db.Exec(sqlstr, u.ID, u.Name, u.Email, u.Active)
+	XOLog(sqlstr, u.ID, u.Name, u.Email, u.EmailVerified, u.GivenName, u.FamilyName, u.Pronouns, u.Picture, u.Locale, u.Zoneinfo, u.DeletedAt, u.State)
+	_, err = db.Exec(sqlstr, u.ID, u.Name, u.Email, u.EmailVerified, u.GivenName, u.FamilyName, u.Pronouns, u.Picture, u.Locale, u.Zoneinfo, u.DeletedAt, u.State)
 	if err != nil {
 		return err
 	}
@@ -75,14 +84,14 @@ func (u *User) Update(db XODB) error {
 
 	// sql query
 	const sqlstr = `UPDATE public.users SET (` +
-		`name, email, active` +
+		`name, email, email_verified, given_name, family_name, pronouns, picture, locale, zoneinfo, deleted_at, state` +
 		`) = ( ` +
-		`$1, $2, $3` +
-		`) WHERE id = $4`
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11` +
+		`) WHERE id = $12`
 
 	// run query
-	XOLog(sqlstr, u.Name, u.Email, u.Active, u.ID)
-	_, err = db.Exec(sqlstr, u.Name, u.Email, u.Active, u.ID)
+	XOLog(sqlstr, u.Name, u.Email, u.EmailVerified, u.GivenName, u.FamilyName, u.Pronouns, u.Picture, u.Locale, u.Zoneinfo, u.DeletedAt, u.State, u.ID)
+	_, err = db.Exec(sqlstr, u.Name, u.Email, u.EmailVerified, u.GivenName, u.FamilyName, u.Pronouns, u.Picture, u.Locale, u.Zoneinfo, u.DeletedAt, u.State, u.ID)
 	return err
 }
 
@@ -108,18 +117,18 @@ func (u *User) Upsert(db XODB) error {
 
 	// sql query
 	const sqlstr = `INSERT INTO public.users (` +
-		`id, name, email, active` +
+		`id, name, email, email_verified, given_name, family_name, pronouns, picture, locale, zoneinfo, deleted_at, state` +
 		`) VALUES (` +
-		`$1, $2, $3, $4` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12` +
 		`) ON CONFLICT (id) DO UPDATE SET (` +
-		`id, name, email, active` +
+		`id, name, email, email_verified, given_name, family_name, pronouns, picture, locale, zoneinfo, deleted_at, state` +
 		`) = (` +
-		`EXCLUDED.id, EXCLUDED.name, EXCLUDED.email, EXCLUDED.active` +
+		`EXCLUDED.id, EXCLUDED.name, EXCLUDED.email, EXCLUDED.email_verified, EXCLUDED.given_name, EXCLUDED.family_name, EXCLUDED.pronouns, EXCLUDED.picture, EXCLUDED.locale, EXCLUDED.zoneinfo, EXCLUDED.deleted_at, EXCLUDED.state` +
 		`)`
 
 	// run query
-	XOLog(sqlstr, u.ID, u.Name, u.Email, u.Active)
-	_, err = db.Exec(sqlstr, u.ID, u.Name, u.Email, u.Active)
+	XOLog(sqlstr, u.ID, u.Name, u.Email, u.EmailVerified, u.GivenName, u.FamilyName, u.Pronouns, u.Picture, u.Locale, u.Zoneinfo, u.DeletedAt, u.State)
+	_, err = db.Exec(sqlstr, u.ID, u.Name, u.Email, u.EmailVerified, u.GivenName, u.FamilyName, u.Pronouns, u.Picture, u.Locale, u.Zoneinfo, u.DeletedAt, u.State)
 	if err != nil {
 		return err
 	}
@@ -168,7 +177,7 @@ func UserByEmail(db XODB, email string) (*User, error) {
 
 	// sql query
 	const sqlstr = `SELECT ` +
-		`id, name, email, active ` +
+		`id, name, email, email_verified, given_name, family_name, pronouns, picture, locale, zoneinfo, deleted_at, state ` +
 		`FROM public.users ` +
 		`WHERE email = $1`
 
@@ -178,7 +187,7 @@ func UserByEmail(db XODB, email string) (*User, error) {
 		_exists: true,
 	}
 
-	err = db.QueryRow(sqlstr, email).Scan(&u.ID, &u.Name, &u.Email, &u.Active)
+	err = db.QueryRow(sqlstr, email).Scan(&u.ID, &u.Name, &u.Email, &u.EmailVerified, &u.GivenName, &u.FamilyName, &u.Pronouns, &u.Picture, &u.Locale, &u.Zoneinfo, &u.DeletedAt, &u.State)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +203,7 @@ func UserByID(db XODB, id uuid.UUID) (*User, error) {
 
 	// sql query
 	const sqlstr = `SELECT ` +
-		`id, name, email, active ` +
+		`id, name, email, email_verified, given_name, family_name, pronouns, picture, locale, zoneinfo, deleted_at, state ` +
 		`FROM public.users ` +
 		`WHERE id = $1`
 
@@ -204,7 +213,7 @@ func UserByID(db XODB, id uuid.UUID) (*User, error) {
 		_exists: true,
 	}
 
-	err = db.QueryRow(sqlstr, id).Scan(&u.ID, &u.Name, &u.Email, &u.Active)
+	err = db.QueryRow(sqlstr, id).Scan(&u.ID, &u.Name, &u.Email, &u.EmailVerified, &u.GivenName, &u.FamilyName, &u.Pronouns, &u.Picture, &u.Locale, &u.Zoneinfo, &u.DeletedAt, &u.State)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,165 @@
+// Package oidctest provides an OpenID Connect provider for tests, serving discovery, keys and a token endpoint that
+// redeems codes for ID tokens with whatever claims the test chose.
+package oidctest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/studiously/usersvc/oidc"
+)
+
+const (
+	ClientID     = "client"
+	ClientSecret = "secret"
+	RedirectURL  = "https://accounts.example.com/federated/callback"
+)
+
+// Issuer is a provider served by an httptest.Server.
+type Issuer struct {
+	*httptest.Server
+	// Key signs ID tokens. Replacing it makes them fail verification, as only the original is published.
+	Key *rsa.PrivateKey
+	// DiscoveredIssuer is the issuer the discovery document names. Defaults to URL. Multi-tenant issuers name a
+	// template containing "{tenantid}".
+	DiscoveredIssuer string
+
+	key   *rsa.PrivateKey
+	mtx   sync.Mutex
+	codes map[string]code
+}
+
+type code struct {
+	claims   map[string]interface{}
+	verifier string
+}
+
+// NewIssuer starts a provider. Callers should Close it when they are done.
+func NewIssuer() *Issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic("oidctest: " + err.Error())
+	}
+	i := &Issuer{Key: key, key: key, codes: make(map[string]code)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", i.discovery)
+	mux.HandleFunc("/keys", i.keys)
+	mux.HandleFunc("/token", i.token)
+	i.Server = httptest.NewServer(mux)
+	return i
+}
+
+// Provider returns a provider with the given ID that logs in with the issuer.
+func (i *Issuer) Provider(id string) *oidc.Provider {
+	return &oidc.Provider{
+		ID:           id,
+		Name:         id,
+		Issuer:       i.URL,
+		ClientID:     ClientID,
+		ClientSecret: ClientSecret,
+		RedirectURL:  RedirectURL,
+		Client:       i.Client(),
+	}
+}
+
+// Claims returns the claims of a valid ID token for subject, issued in answer to req.
+func (i *Issuer) Claims(req oidc.AuthRequest, subject string) map[string]interface{} {
+	now := time.Now()
+	return map[string]interface{}{
+		"iss":   i.URL,
+		"sub":   subject,
+		"aud":   ClientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": req.Nonce,
+	}
+}
+
+// Code returns an authorization code that the token endpoint redeems, with req's verifier, for an ID token with the
+// given claims.
+func (i *Issuer) Code(req oidc.AuthRequest, claims map[string]interface{}) string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	c := base64.RawURLEncoding.EncodeToString(b)
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+	i.codes[c] = code{claims: claims, verifier: req.Verifier}
+	return c
+}
+
+// IDToken returns an RS256 ID token with the given claims, signed with Key.
+func (i *Issuer) IDToken(claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "test"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sum := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, i.Key, crypto.SHA256, sum[:])
+	if err != nil {
+		panic("oidctest: " + err.Error())
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func (i *Issuer) discovery(w http.ResponseWriter, r *http.Request) {
+	issuer := i.DiscoveredIssuer
+	if issuer == "" {
+		issuer = i.URL
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"issuer":                                issuer,
+		"authorization_endpoint":                i.URL + "/authorize",
+		"token_endpoint":                        i.URL + "/token",
+		"jwks_uri":                              i.URL + "/keys",
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic"},
+	})
+}
+
+func (i *Issuer) keys(w http.ResponseWriter, r *http.Request) {
+	e := big.NewInt(int64(i.key.E)).Bytes()
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(i.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(e),
+		}},
+	})
+}
+
+func (i *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != ClientID || secret != ClientSecret {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+	i.mtx.Lock()
+	c, ok := i.codes[r.PostFormValue("code")]
+	delete(i.codes, r.PostFormValue("code"))
+	i.mtx.Unlock()
+	if !ok ||
+		r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("redirect_uri") != RedirectURL ||
+		r.PostFormValue("code_verifier") != c.verifier {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"id_token":     i.IDToken(c.claims),
+	})
+}
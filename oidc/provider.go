@@ -0,0 +1,339 @@
+// Package oidc is a client for OpenID Connect providers such as Google and Microsoft. It supports the authorization
+// code flow with PKCE, discovering each provider's endpoints and keys from its issuer URL and validating the ID
+// tokens it returns.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// leeway allows for clocks that differ slightly between us and the provider.
+	leeway = time.Minute
+	// minRefresh stops tokens with unknown key IDs from making us hammer a provider's jwks_uri.
+	minRefresh = time.Minute
+)
+
+// ErrInvalidToken is returned when an ID token is malformed, wrongly signed, expired, or not meant for us.
+var ErrInvalidToken = errors.New("oidc: invalid ID token")
+
+// Provider is an OpenID Connect provider that users can log in with.
+type Provider struct {
+	// ID identifies the provider in URLs and storage, such as "google". It must not change once users have linked
+	// identities.
+	ID string
+	// Name is shown to users, as in "Continue with Google".
+	Name string
+	// Issuer is the provider's issuer URL, from which everything else is discovered. Microsoft's multi-tenant
+	// issuer, https://login.microsoftonline.com/common/v2.0, accepts users from any tenant.
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is where the provider sends the user back to with a code.
+	RedirectURL string
+	// Scopes requested in addition to "openid". Defaults to "email" and "profile".
+	Scopes []string
+	// Client makes requests to the provider. Defaults to a client with a ten second timeout.
+	Client *http.Client
+
+	mtx       sync.Mutex
+	discovery *discovery
+	keys      map[string]crypto.PublicKey
+	fetched   time.Time
+}
+
+type discovery struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	TokenAuthMethods      []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+// AuthRequest is an authorization request in progress. Everything but URL must be kept, out of the user's reach,
+// until the provider redirects back.
+type AuthRequest struct {
+	// URL is where to send the user to log in.
+	URL      string
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// Claims are the claims of a validated ID token that identify the user.
+type Claims struct {
+	Issuer  string `json:"iss"`
+	Subject string `json:"sub"`
+	Email   string `json:"email"`
+	// EmailVerified is only true if the provider vouches that the user controls Email.
+	EmailVerified flexBool `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+type idTokenClaims struct {
+	Claims
+	Audience        audience `json:"aud"`
+	AuthorizedParty string   `json:"azp"`
+	Expiry          int64    `json:"exp"`
+	IssuedAt        int64    `json:"iat"`
+	Nonce           string   `json:"nonce"`
+	TenantID        string   `json:"tid"`
+}
+
+// audience decodes the aud claim, which may be a single string or an array of them.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = audience{s}
+		return nil
+	}
+	var ss []string
+	if err := json.Unmarshal(b, &ss); err != nil {
+		return err
+	}
+	*a = ss
+	return nil
+}
+
+// flexBool decodes a boolean that some providers send as a string.
+type flexBool bool
+
+func (f *flexBool) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*f = flexBool(v)
+	case string:
+		*f = v == "true"
+	}
+	return nil
+}
+
+// NewAuthRequest starts logging in with the provider.
+func (p *Provider) NewAuthRequest(ctx context.Context) (*AuthRequest, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := &AuthRequest{State: random(), Nonce: random(), Verifier: random()}
+	challenge := sha256.Sum256([]byte(req.Verifier))
+	scopes := p.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"email", "profile"}
+	}
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", p.ClientID)
+	v.Set("redirect_uri", p.RedirectURL)
+	v.Set("scope", strings.Join(append([]string{"openid"}, scopes...), " "))
+	v.Set("state", req.State)
+	v.Set("nonce", req.Nonce)
+	v.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	v.Set("code_challenge_method", "S256")
+	sep := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	req.URL = d.AuthorizationEndpoint + sep + v.Encode()
+	return req, nil
+}
+
+// Exchange redeems the code the provider redirected back with for an ID token, and validates it.
+func (p *Provider) Exchange(ctx context.Context, req AuthRequest, code string) (*Claims, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.RedirectURL)
+	form.Set("code_verifier", req.Verifier)
+	basic := len(d.TokenAuthMethods) == 0
+	for _, m := range d.TokenAuthMethods {
+		if m == "client_secret_basic" {
+			basic = true
+		}
+	}
+	if !basic {
+		form.Set("client_id", p.ClientID)
+		form.Set("client_secret", p.ClientSecret)
+	}
+	r, err := http.NewRequest("POST", d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Accept", "application/json")
+	if basic {
+		r.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+	}
+	res, err := p.client().Do(r.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("oidc: cannot decode token response: %v", err)
+	}
+	if body.Error != "" {
+		return nil, fmt.Errorf("oidc: token request failed: %s: %s", body.Error, body.ErrorDescription)
+	}
+	if res.StatusCode != http.StatusOK || body.IDToken == "" {
+		return nil, fmt.Errorf("oidc: token request failed with status %d", res.StatusCode)
+	}
+	return p.Verify(ctx, body.IDToken, req.Nonce)
+}
+
+// Verify validates an ID token issued to us for the authorization request with the given nonce.
+func (p *Provider) Verify(ctx context.Context, token, nonce string) (*Claims, error) {
+	h, payload, signed, sig, err := parseJWS(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	key, err := p.key(ctx, h.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || !verifySignature(h.Alg, key, signed, sig) {
+		return nil, ErrInvalidToken
+	}
+	var c idTokenClaims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, ErrInvalidToken
+	}
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	issuer := d.Issuer
+	if strings.Contains(issuer, "{tenantid}") {
+		// Multi-tenant issuers stand in for the issuer of every tenant, and tokens name the real one.
+		if c.TenantID == "" {
+			return nil, ErrInvalidToken
+		}
+		issuer = strings.Replace(issuer, "{tenantid}", c.TenantID, -1)
+	}
+	now := time.Now()
+	switch {
+	case c.Issuer != issuer,
+		c.Subject == "",
+		!c.Audience.contains(p.ClientID),
+		len(c.Audience) > 1 && c.AuthorizedParty != p.ClientID,
+		now.After(time.Unix(c.Expiry, 0).Add(leeway)),
+		now.Before(time.Unix(c.IssuedAt, 0).Add(-leeway)),
+		nonce == "" || c.Nonce != nonce:
+		return nil, ErrInvalidToken
+	}
+	return &c.Claims, nil
+}
+
+func (a audience) contains(s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// discover fetches the provider's configuration the first time it is needed.
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	var d discovery
+	if err := p.get(ctx, strings.TrimSuffix(p.Issuer, "/")+"/.well-known/openid-configuration", &d); err != nil {
+		return nil, err
+	}
+	// Multi-tenant configurations name a template rather than themselves as the issuer.
+	if d.Issuer != p.Issuer && !strings.Contains(d.Issuer, "{tenantid}") ||
+		d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc: invalid configuration for issuer %s", p.Issuer)
+	}
+	p.discovery = &d
+	return &d, nil
+}
+
+// key returns the provider's signing key with the given ID. Providers rotate their keys, so unknown IDs cause the
+// keys to be fetched again.
+func (p *Provider) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if key, ok := p.keys[kid]; ok || time.Since(p.fetched) < minRefresh {
+		return key, nil
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.get(ctx, d.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+	p.keys = make(map[string]crypto.PublicKey)
+	for _, k := range set.Keys {
+		if key := k.publicKey(); key != nil {
+			p.keys[k.Kid] = key
+		}
+	}
+	p.fetched = time.Now()
+	return p.keys[kid], nil
+}
+
+func (p *Provider) get(ctx context.Context, u string, v interface{}) error {
+	r, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+	res, err := p.client().Do(r.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: GET %s returned status %d", u, res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func (p *Provider) client() *http.Client {
+	if p.Client != nil {
+		return p.Client
+	}
+	return defaultClient
+}
+
+var defaultClient = &http.Client{Timeout: 10 * time.Second}
+
+func random() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
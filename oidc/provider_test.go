@@ -0,0 +1,202 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/studiously/usersvc/oidc"
+	"github.com/studiously/usersvc/oidc/oidctest"
+)
+
+func TestNewAuthRequest(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	req, err := iss.Provider("test").NewAuthRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.Scheme + "://" + u.Host + u.Path; got != iss.URL+"/authorize" {
+		t.Errorf("authorization endpoint = %s", got)
+	}
+	q := u.Query()
+	sum := sha256.Sum256([]byte(req.Verifier))
+	for k, want := range map[string]string{
+		"response_type":         "code",
+		"client_id":             oidctest.ClientID,
+		"redirect_uri":          oidctest.RedirectURL,
+		"scope":                 "openid email profile",
+		"state":                 req.State,
+		"nonce":                 req.Nonce,
+		"code_challenge":        base64.RawURLEncoding.EncodeToString(sum[:]),
+		"code_challenge_method": "S256",
+	} {
+		if q.Get(k) != want {
+			t.Errorf("%s = %q, want %q", k, q.Get(k), want)
+		}
+	}
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	iss.DiscoveredIssuer = "https://evil.example"
+	if _, err := iss.Provider("test").NewAuthRequest(context.Background()); err == nil {
+		t.Fatal("NewAuthRequest accepted a configuration for another issuer")
+	}
+}
+
+func TestExchange(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	p := iss.Provider("test")
+	ctx := context.Background()
+	req, err := p.NewAuthRequest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := iss.Claims(*req, "alice")
+	claims["email"] = "alice@example.com"
+	claims["email_verified"] = "true"
+	claims["name"] = "Alice"
+	c, err := p.Exchange(ctx, *req, iss.Code(*req, claims))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := oidc.Claims{Issuer: iss.URL, Subject: "alice", Email: "alice@example.com", EmailVerified: true, Name: "Alice"}
+	if *c != want {
+		t.Errorf("Exchange = %+v, want %+v", *c, want)
+	}
+}
+
+func TestExchangeRejectsCode(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	p := iss.Provider("test")
+	ctx := context.Background()
+	req, err := p.NewAuthRequest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := iss.Code(*req, iss.Claims(*req, "alice"))
+	// Someone who intercepted the code, but not the verifier.
+	stolen := *req
+	stolen.Verifier = "guess"
+	if _, err := p.Exchange(ctx, stolen, code); err == nil {
+		t.Fatal("Exchange succeeded with the wrong verifier")
+	}
+	if _, err := p.Exchange(ctx, *req, "unknown"); err == nil {
+		t.Fatal("Exchange succeeded with an unknown code")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	p := iss.Provider("test")
+	ctx := context.Background()
+	req, err := p.NewAuthRequest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for _, tc := range []struct {
+		name  string
+		claim string
+		value interface{}
+		ok    bool
+	}{
+		{"valid", "", nil, true},
+		{"issuer", "iss", "https://evil.example", false},
+		{"audience", "aud", "other", false},
+		{"audiences", "aud", []string{oidctest.ClientID, "other"}, false},
+		{"nonce", "nonce", "replayed", false},
+		{"expired", "exp", now.Add(-2 * time.Minute).Unix(), false},
+		{"expired within leeway", "exp", now.Add(-30 * time.Second).Unix(), true},
+		{"issued in the future", "iat", now.Add(2 * time.Minute).Unix(), false},
+		{"no subject", "sub", "", false},
+	} {
+		claims := iss.Claims(*req, "alice")
+		if tc.claim != "" {
+			claims[tc.claim] = tc.value
+		}
+		_, err := p.Verify(ctx, iss.IDToken(claims), req.Nonce)
+		if tc.ok && err != nil {
+			t.Errorf("%s: Verify = %v", tc.name, err)
+		}
+		if !tc.ok && err != oidc.ErrInvalidToken {
+			t.Errorf("%s: Verify = %v, want ErrInvalidToken", tc.name, err)
+		}
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	p := iss.Provider("test")
+	ctx := context.Background()
+	req, err := p.NewAuthRequest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := iss.IDToken(iss.Claims(*req, "alice"))
+	parts := strings.Split(token, ".")
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"test"}`)) + "." + parts[1] + "."
+	if _, err := p.Verify(ctx, unsigned, req.Nonce); err != oidc.ErrInvalidToken {
+		t.Errorf("unsigned: Verify = %v, want ErrInvalidToken", err)
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iss.Key = key
+	if _, err := p.Verify(ctx, iss.IDToken(iss.Claims(*req, "alice")), req.Nonce); err != oidc.ErrInvalidToken {
+		t.Errorf("wrong key: Verify = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestVerifyMultiTenant(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	iss.DiscoveredIssuer = iss.URL + "/{tenantid}/v2.0"
+	p := iss.Provider("microsoft")
+	ctx := context.Background()
+	req, err := p.NewAuthRequest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name     string
+		issuer   string
+		tenantID string
+		ok       bool
+	}{
+		{"tenant", iss.URL + "/9188040d/v2.0", "9188040d", true},
+		{"other tenant", iss.URL + "/9188040d/v2.0", "72f988bf", false},
+		{"no tenant", iss.URL + "/9188040d/v2.0", "", false},
+		{"template", iss.URL + "/{tenantid}/v2.0", "", false},
+	} {
+		claims := iss.Claims(*req, "alice")
+		claims["iss"] = tc.issuer
+		if tc.tenantID != "" {
+			claims["tid"] = tc.tenantID
+		}
+		_, err := p.Verify(ctx, iss.IDToken(claims), req.Nonce)
+		if tc.ok && err != nil {
+			t.Errorf("%s: Verify = %v", tc.name, err)
+		}
+		if !tc.ok && err != oidc.ErrInvalidToken {
+			t.Errorf("%s: Verify = %v, want ErrInvalidToken", tc.name, err)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package pb holds the protocol buffer definition of the usersvc API and the code generated from it.
+package pb
+
+//go:generate protoc --go_out=plugins=grpc:. usersvc.proto
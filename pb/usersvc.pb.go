@@ -0,0 +1,2540 @@
+// Code generated by protoc-gen-go.
+// source: usersvc.proto
+// DO NOT EDIT!
+
+/*
+Package pb is a generated protocol buffer package.
+
+It is generated from these files:
+	usersvc.proto
+
+It has these top-level messages:
+	User
+	Profile
+	GetUserInfoRequest
+	UpdateUserRequest
+	UpdateUserReply
+	DeleteUserRequest
+	DeleteUserReply
+	SetAvatarRequest
+	SetAvatarReply
+	DeleteAvatarRequest
+	DeleteAvatarReply
+	EnrollTOTPRequest
+	EnrollTOTPReply
+	TOTPCodeRequest
+	RecoveryCodesReply
+	DisableTOTPReply
+	Identity
+	ListIdentitiesRequest
+	ListIdentitiesReply
+	UnlinkIdentityRequest
+	UnlinkIdentityReply
+	Session
+	ListSessionsRequest
+	ListSessionsReply
+	RevokeSessionRequest
+	RevokeSessionReply
+	App
+	ListAppsRequest
+	ListAppsReply
+	RevokeAppRequest
+	RevokeAppReply
+	GetProfileRequest
+	GetProfilesRequest
+	GetProfilesReply
+	SearchUsersRequest
+	SearchUsersReply
+	ListUsersRequest
+	ListUsersReply
+	GetUserRequest
+	ForcePasswordResetRequest
+	ForcePasswordResetReply
+	SetUserActiveRequest
+	SetUserActiveReply
+	PurgeUserRequest
+	PurgeUserReply
+	ListStateChangesRequest
+	StateChange
+	ListStateChangesReply
+	ListAuditEventsRequest
+	AuditEvent
+	ListAuditEventsReply
+	VerifyAuditLogRequest
+	VerifyAuditLogReply
+*/
+package pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/wrappers"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type User struct {
+	Id            string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Email         string `protobuf:"bytes,3,opt,name=email" json:"email,omitempty"`
+	Active        bool   `protobuf:"varint,4,opt,name=active" json:"active,omitempty"`
+	EmailVerified bool   `protobuf:"varint,5,opt,name=email_verified,json=emailVerified" json:"email_verified,omitempty"`
+	GivenName     string `protobuf:"bytes,6,opt,name=given_name,json=givenName" json:"given_name,omitempty"`
+	FamilyName    string `protobuf:"bytes,7,opt,name=family_name,json=familyName" json:"family_name,omitempty"`
+	Pronouns      string `protobuf:"bytes,8,opt,name=pronouns" json:"pronouns,omitempty"`
+	Picture       string `protobuf:"bytes,9,opt,name=picture" json:"picture,omitempty"`
+	Locale        string `protobuf:"bytes,10,opt,name=locale" json:"locale,omitempty"`
+	Zoneinfo      string `protobuf:"bytes,11,opt,name=zoneinfo" json:"zoneinfo,omitempty"`
+	// One of pending_verification, active, suspended or pending_deletion. Active is true only for active users.
+	State string `protobuf:"bytes,12,opt,name=state" json:"state,omitempty"`
+}
+
+func (m *User) Reset()                    { *m = User{} }
+func (m *User) String() string            { return proto.CompactTextString(m) }
+func (*User) ProtoMessage()               {}
+func (*User) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *User) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *User) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *User) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *User) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *User) GetEmailVerified() bool {
+	if m != nil {
+		return m.EmailVerified
+	}
+	return false
+}
+
+func (m *User) GetGivenName() string {
+	if m != nil {
+		return m.GivenName
+	}
+	return ""
+}
+
+func (m *User) GetFamilyName() string {
+	if m != nil {
+		return m.FamilyName
+	}
+	return ""
+}
+
+func (m *User) GetPronouns() string {
+	if m != nil {
+		return m.Pronouns
+	}
+	return ""
+}
+
+func (m *User) GetPicture() string {
+	if m != nil {
+		return m.Picture
+	}
+	return ""
+}
+
+func (m *User) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *User) GetZoneinfo() string {
+	if m != nil {
+		return m.Zoneinfo
+	}
+	return ""
+}
+
+func (m *User) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+type Profile struct {
+	Id         string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	GivenName  string `protobuf:"bytes,3,opt,name=given_name,json=givenName" json:"given_name,omitempty"`
+	FamilyName string `protobuf:"bytes,4,opt,name=family_name,json=familyName" json:"family_name,omitempty"`
+	Pronouns   string `protobuf:"bytes,5,opt,name=pronouns" json:"pronouns,omitempty"`
+	Picture    string `protobuf:"bytes,6,opt,name=picture" json:"picture,omitempty"`
+	Locale     string `protobuf:"bytes,7,opt,name=locale" json:"locale,omitempty"`
+	Zoneinfo   string `protobuf:"bytes,8,opt,name=zoneinfo" json:"zoneinfo,omitempty"`
+}
+
+func (m *Profile) Reset()                    { *m = Profile{} }
+func (m *Profile) String() string            { return proto.CompactTextString(m) }
+func (*Profile) ProtoMessage()               {}
+func (*Profile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Profile) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Profile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Profile) GetGivenName() string {
+	if m != nil {
+		return m.GivenName
+	}
+	return ""
+}
+
+func (m *Profile) GetFamilyName() string {
+	if m != nil {
+		return m.FamilyName
+	}
+	return ""
+}
+
+func (m *Profile) GetPronouns() string {
+	if m != nil {
+		return m.Pronouns
+	}
+	return ""
+}
+
+func (m *Profile) GetPicture() string {
+	if m != nil {
+		return m.Picture
+	}
+	return ""
+}
+
+func (m *Profile) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *Profile) GetZoneinfo() string {
+	if m != nil {
+		return m.Zoneinfo
+	}
+	return ""
+}
+
+type GetUserInfoRequest struct {
+}
+
+func (m *GetUserInfoRequest) Reset()                    { *m = GetUserInfoRequest{} }
+func (m *GetUserInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetUserInfoRequest) ProtoMessage()               {}
+func (*GetUserInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+// UpdateUserRequest changes the fields that are set.
+type UpdateUserRequest struct {
+	Name       *google_protobuf1.StringValue `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Email      *google_protobuf1.StringValue `protobuf:"bytes,2,opt,name=email" json:"email,omitempty"`
+	Password   *google_protobuf1.StringValue `protobuf:"bytes,3,opt,name=password" json:"password,omitempty"`
+	GivenName  *google_protobuf1.StringValue `protobuf:"bytes,4,opt,name=given_name,json=givenName" json:"given_name,omitempty"`
+	FamilyName *google_protobuf1.StringValue `protobuf:"bytes,5,opt,name=family_name,json=familyName" json:"family_name,omitempty"`
+	Pronouns   *google_protobuf1.StringValue `protobuf:"bytes,6,opt,name=pronouns" json:"pronouns,omitempty"`
+	Locale     *google_protobuf1.StringValue `protobuf:"bytes,7,opt,name=locale" json:"locale,omitempty"`
+	Zoneinfo   *google_protobuf1.StringValue `protobuf:"bytes,8,opt,name=zoneinfo" json:"zoneinfo,omitempty"`
+}
+
+func (m *UpdateUserRequest) Reset()                    { *m = UpdateUserRequest{} }
+func (m *UpdateUserRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateUserRequest) ProtoMessage()               {}
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *UpdateUserRequest) GetName() *google_protobuf1.StringValue {
+	if m != nil {
+		return m.Name
+	}
+	return nil
+}
+
+func (m *UpdateUserRequest) GetEmail() *google_protobuf1.StringValue {
+	if m != nil {
+		return m.Email
+	}
+	return nil
+}
+
+func (m *UpdateUserRequest) GetPassword() *google_protobuf1.StringValue {
+	if m != nil {
+		return m.Password
+	}
+	return nil
+}
+
+func (m *UpdateUserRequest) GetGivenName() *google_protobuf1.StringValue {
+	if m != nil {
+		return m.GivenName
+	}
+	return nil
+}
+
+func (m *UpdateUserRequest) GetFamilyName() *google_protobuf1.StringValue {
+	if m != nil {
+		return m.FamilyName
+	}
+	return nil
+}
+
+func (m *UpdateUserRequest) GetPronouns() *google_protobuf1.StringValue {
+	if m != nil {
+		return m.Pronouns
+	}
+	return nil
+}
+
+func (m *UpdateUserRequest) GetLocale() *google_protobuf1.StringValue {
+	if m != nil {
+		return m.Locale
+	}
+	return nil
+}
+
+func (m *UpdateUserRequest) GetZoneinfo() *google_protobuf1.StringValue {
+	if m != nil {
+		return m.Zoneinfo
+	}
+	return nil
+}
+
+type UpdateUserReply struct {
+}
+
+func (m *UpdateUserReply) Reset()                    { *m = UpdateUserReply{} }
+func (m *UpdateUserReply) String() string            { return proto.CompactTextString(m) }
+func (*UpdateUserReply) ProtoMessage()               {}
+func (*UpdateUserReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+type DeleteUserRequest struct {
+}
+
+func (m *DeleteUserRequest) Reset()                    { *m = DeleteUserRequest{} }
+func (m *DeleteUserRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteUserRequest) ProtoMessage()               {}
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+type DeleteUserReply struct {
+}
+
+func (m *DeleteUserReply) Reset()                    { *m = DeleteUserReply{} }
+func (m *DeleteUserReply) String() string            { return proto.CompactTextString(m) }
+func (*DeleteUserReply) ProtoMessage()               {}
+func (*DeleteUserReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+type SetAvatarRequest struct {
+	// One of image/jpeg, image/png or image/gif.
+	ContentType string `protobuf:"bytes,1,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
+	Image       []byte `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+}
+
+func (m *SetAvatarRequest) Reset()                    { *m = SetAvatarRequest{} }
+func (m *SetAvatarRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetAvatarRequest) ProtoMessage()               {}
+func (*SetAvatarRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *SetAvatarRequest) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *SetAvatarRequest) GetImage() []byte {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+type SetAvatarReply struct {
+	Picture string `protobuf:"bytes,1,opt,name=picture" json:"picture,omitempty"`
+}
+
+func (m *SetAvatarReply) Reset()                    { *m = SetAvatarReply{} }
+func (m *SetAvatarReply) String() string            { return proto.CompactTextString(m) }
+func (*SetAvatarReply) ProtoMessage()               {}
+func (*SetAvatarReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *SetAvatarReply) GetPicture() string {
+	if m != nil {
+		return m.Picture
+	}
+	return ""
+}
+
+type DeleteAvatarRequest struct {
+}
+
+func (m *DeleteAvatarRequest) Reset()                    { *m = DeleteAvatarRequest{} }
+func (m *DeleteAvatarRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteAvatarRequest) ProtoMessage()               {}
+func (*DeleteAvatarRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+type DeleteAvatarReply struct {
+}
+
+func (m *DeleteAvatarReply) Reset()                    { *m = DeleteAvatarReply{} }
+func (m *DeleteAvatarReply) String() string            { return proto.CompactTextString(m) }
+func (*DeleteAvatarReply) ProtoMessage()               {}
+func (*DeleteAvatarReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+type EnrollTOTPRequest struct {
+}
+
+func (m *EnrollTOTPRequest) Reset()                    { *m = EnrollTOTPRequest{} }
+func (m *EnrollTOTPRequest) String() string            { return proto.CompactTextString(m) }
+func (*EnrollTOTPRequest) ProtoMessage()               {}
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+type EnrollTOTPReply struct {
+	Secret string `protobuf:"bytes,1,opt,name=secret" json:"secret,omitempty"`
+	// An otpauth:// URI for the client to display as a QR code.
+	Uri string `protobuf:"bytes,2,opt,name=uri" json:"uri,omitempty"`
+}
+
+func (m *EnrollTOTPReply) Reset()                    { *m = EnrollTOTPReply{} }
+func (m *EnrollTOTPReply) String() string            { return proto.CompactTextString(m) }
+func (*EnrollTOTPReply) ProtoMessage()               {}
+func (*EnrollTOTPReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *EnrollTOTPReply) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *EnrollTOTPReply) GetUri() string {
+	if m != nil {
+		return m.Uri
+	}
+	return ""
+}
+
+// TOTPCodeRequest carries a code from the user's authenticator app, or for some calls, a recovery code.
+type TOTPCodeRequest struct {
+	Code string `protobuf:"bytes,1,opt,name=code" json:"code,omitempty"`
+}
+
+func (m *TOTPCodeRequest) Reset()                    { *m = TOTPCodeRequest{} }
+func (m *TOTPCodeRequest) String() string            { return proto.CompactTextString(m) }
+func (*TOTPCodeRequest) ProtoMessage()               {}
+func (*TOTPCodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *TOTPCodeRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type RecoveryCodesReply struct {
+	RecoveryCodes []string `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes" json:"recovery_codes,omitempty"`
+}
+
+func (m *RecoveryCodesReply) Reset()                    { *m = RecoveryCodesReply{} }
+func (m *RecoveryCodesReply) String() string            { return proto.CompactTextString(m) }
+func (*RecoveryCodesReply) ProtoMessage()               {}
+func (*RecoveryCodesReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *RecoveryCodesReply) GetRecoveryCodes() []string {
+	if m != nil {
+		return m.RecoveryCodes
+	}
+	return nil
+}
+
+type DisableTOTPReply struct {
+}
+
+func (m *DisableTOTPReply) Reset()                    { *m = DisableTOTPReply{} }
+func (m *DisableTOTPReply) String() string            { return proto.CompactTextString(m) }
+func (*DisableTOTPReply) ProtoMessage()               {}
+func (*DisableTOTPReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+type Identity struct {
+	Provider  string                     `protobuf:"bytes,1,opt,name=provider" json:"provider,omitempty"`
+	Subject   string                     `protobuf:"bytes,2,opt,name=subject" json:"subject,omitempty"`
+	Email     string                     `protobuf:"bytes,3,opt,name=email" json:"email,omitempty"`
+	CreatedAt *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+}
+
+func (m *Identity) Reset()                    { *m = Identity{} }
+func (m *Identity) String() string            { return proto.CompactTextString(m) }
+func (*Identity) ProtoMessage()               {}
+func (*Identity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *Identity) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+func (m *Identity) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *Identity) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Identity) GetCreatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type ListIdentitiesRequest struct {
+}
+
+func (m *ListIdentitiesRequest) Reset()                    { *m = ListIdentitiesRequest{} }
+func (m *ListIdentitiesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListIdentitiesRequest) ProtoMessage()               {}
+func (*ListIdentitiesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+type ListIdentitiesReply struct {
+	Identities []*Identity `protobuf:"bytes,1,rep,name=identities" json:"identities,omitempty"`
+}
+
+func (m *ListIdentitiesReply) Reset()                    { *m = ListIdentitiesReply{} }
+func (m *ListIdentitiesReply) String() string            { return proto.CompactTextString(m) }
+func (*ListIdentitiesReply) ProtoMessage()               {}
+func (*ListIdentitiesReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *ListIdentitiesReply) GetIdentities() []*Identity {
+	if m != nil {
+		return m.Identities
+	}
+	return nil
+}
+
+type UnlinkIdentityRequest struct {
+	Provider string `protobuf:"bytes,1,opt,name=provider" json:"provider,omitempty"`
+}
+
+func (m *UnlinkIdentityRequest) Reset()                    { *m = UnlinkIdentityRequest{} }
+func (m *UnlinkIdentityRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnlinkIdentityRequest) ProtoMessage()               {}
+func (*UnlinkIdentityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *UnlinkIdentityRequest) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+type UnlinkIdentityReply struct {
+}
+
+func (m *UnlinkIdentityReply) Reset()                    { *m = UnlinkIdentityReply{} }
+func (m *UnlinkIdentityReply) String() string            { return proto.CompactTextString(m) }
+func (*UnlinkIdentityReply) ProtoMessage()               {}
+func (*UnlinkIdentityReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+type Session struct {
+	Id         string                     `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	CreatedAt  *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	LastSeenAt *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=last_seen_at,json=lastSeenAt" json:"last_seen_at,omitempty"`
+	// Where the session was last seen from.
+	Ip        string `protobuf:"bytes,4,opt,name=ip" json:"ip,omitempty"`
+	UserAgent string `protobuf:"bytes,5,opt,name=user_agent,json=userAgent" json:"user_agent,omitempty"`
+}
+
+func (m *Session) Reset()                    { *m = Session{} }
+func (m *Session) String() string            { return proto.CompactTextString(m) }
+func (*Session) ProtoMessage()               {}
+func (*Session) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *Session) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Session) GetCreatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Session) GetLastSeenAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return nil
+}
+
+func (m *Session) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *Session) GetUserAgent() string {
+	if m != nil {
+		return m.UserAgent
+	}
+	return ""
+}
+
+type ListSessionsRequest struct {
+}
+
+func (m *ListSessionsRequest) Reset()                    { *m = ListSessionsRequest{} }
+func (m *ListSessionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListSessionsRequest) ProtoMessage()               {}
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+type ListSessionsReply struct {
+	Sessions []*Session `protobuf:"bytes,1,rep,name=sessions" json:"sessions,omitempty"`
+}
+
+func (m *ListSessionsReply) Reset()                    { *m = ListSessionsReply{} }
+func (m *ListSessionsReply) String() string            { return proto.CompactTextString(m) }
+func (*ListSessionsReply) ProtoMessage()               {}
+func (*ListSessionsReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ListSessionsReply) GetSessions() []*Session {
+	if m != nil {
+		return m.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+}
+
+func (m *RevokeSessionRequest) Reset()                    { *m = RevokeSessionRequest{} }
+func (m *RevokeSessionRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevokeSessionRequest) ProtoMessage()               {}
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *RevokeSessionRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+type RevokeSessionReply struct {
+}
+
+func (m *RevokeSessionReply) Reset()                    { *m = RevokeSessionReply{} }
+func (m *RevokeSessionReply) String() string            { return proto.CompactTextString(m) }
+func (*RevokeSessionReply) ProtoMessage()               {}
+func (*RevokeSessionReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+type App struct {
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId" json:"client_id,omitempty"`
+	// As the client registered them with Hydra, if it did.
+	Name      string                     `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	ClientUri string                     `protobuf:"bytes,3,opt,name=client_uri,json=clientUri" json:"client_uri,omitempty"`
+	LogoUri   string                     `protobuf:"bytes,4,opt,name=logo_uri,json=logoUri" json:"logo_uri,omitempty"`
+	Scopes    []string                   `protobuf:"bytes,5,rep,name=scopes" json:"scopes,omitempty"`
+	GrantedAt *google_protobuf.Timestamp `protobuf:"bytes,6,opt,name=granted_at,json=grantedAt" json:"granted_at,omitempty"`
+	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
+}
+
+func (m *App) Reset()                    { *m = App{} }
+func (m *App) String() string            { return proto.CompactTextString(m) }
+func (*App) ProtoMessage()               {}
+func (*App) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *App) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *App) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *App) GetClientUri() string {
+	if m != nil {
+		return m.ClientUri
+	}
+	return ""
+}
+
+func (m *App) GetLogoUri() string {
+	if m != nil {
+		return m.LogoUri
+	}
+	return ""
+}
+
+func (m *App) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+func (m *App) GetGrantedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.GrantedAt
+	}
+	return nil
+}
+
+func (m *App) GetUpdatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type ListAppsRequest struct {
+}
+
+func (m *ListAppsRequest) Reset()                    { *m = ListAppsRequest{} }
+func (m *ListAppsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListAppsRequest) ProtoMessage()               {}
+func (*ListAppsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+type ListAppsReply struct {
+	Apps []*App `protobuf:"bytes,1,rep,name=apps" json:"apps,omitempty"`
+}
+
+func (m *ListAppsReply) Reset()                    { *m = ListAppsReply{} }
+func (m *ListAppsReply) String() string            { return proto.CompactTextString(m) }
+func (*ListAppsReply) ProtoMessage()               {}
+func (*ListAppsReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *ListAppsReply) GetApps() []*App {
+	if m != nil {
+		return m.Apps
+	}
+	return nil
+}
+
+type RevokeAppRequest struct {
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId" json:"client_id,omitempty"`
+}
+
+func (m *RevokeAppRequest) Reset()                    { *m = RevokeAppRequest{} }
+func (m *RevokeAppRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevokeAppRequest) ProtoMessage()               {}
+func (*RevokeAppRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *RevokeAppRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+type RevokeAppReply struct {
+}
+
+func (m *RevokeAppReply) Reset()                    { *m = RevokeAppReply{} }
+func (m *RevokeAppReply) String() string            { return proto.CompactTextString(m) }
+func (*RevokeAppReply) ProtoMessage()               {}
+func (*RevokeAppReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+type GetProfileRequest struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+}
+
+func (m *GetProfileRequest) Reset()                    { *m = GetProfileRequest{} }
+func (m *GetProfileRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetProfileRequest) ProtoMessage()               {}
+func (*GetProfileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *GetProfileRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type GetProfilesRequest struct {
+	UserIds []string `protobuf:"bytes,1,rep,name=user_ids,json=userIds" json:"user_ids,omitempty"`
+}
+
+func (m *GetProfilesRequest) Reset()                    { *m = GetProfilesRequest{} }
+func (m *GetProfilesRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetProfilesRequest) ProtoMessage()               {}
+func (*GetProfilesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *GetProfilesRequest) GetUserIds() []string {
+	if m != nil {
+		return m.UserIds
+	}
+	return nil
+}
+
+type GetProfilesReply struct {
+	Profiles map[string]*Profile `protobuf:"bytes,1,rep,name=profiles" json:"profiles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The requested IDs that don't belong to a user.
+	Missing []string `protobuf:"bytes,2,rep,name=missing" json:"missing,omitempty"`
+}
+
+func (m *GetProfilesReply) Reset()                    { *m = GetProfilesReply{} }
+func (m *GetProfilesReply) String() string            { return proto.CompactTextString(m) }
+func (*GetProfilesReply) ProtoMessage()               {}
+func (*GetProfilesReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *GetProfilesReply) GetProfiles() map[string]*Profile {
+	if m != nil {
+		return m.Profiles
+	}
+	return nil
+}
+
+func (m *GetProfilesReply) GetMissing() []string {
+	if m != nil {
+		return m.Missing
+	}
+	return nil
+}
+
+type SearchUsersRequest struct {
+	Query  string `protobuf:"bytes,1,opt,name=query" json:"query,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	Cursor string `protobuf:"bytes,3,opt,name=cursor" json:"cursor,omitempty"`
+}
+
+func (m *SearchUsersRequest) Reset()                    { *m = SearchUsersRequest{} }
+func (m *SearchUsersRequest) String() string            { return proto.CompactTextString(m) }
+func (*SearchUsersRequest) ProtoMessage()               {}
+func (*SearchUsersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *SearchUsersRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchUsersRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *SearchUsersRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+type SearchUsersReply struct {
+	Profiles []*Profile `protobuf:"bytes,1,rep,name=profiles" json:"profiles,omitempty"`
+	// Continues the search after this page. Empty after the last one.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor" json:"cursor,omitempty"`
+}
+
+func (m *SearchUsersReply) Reset()                    { *m = SearchUsersReply{} }
+func (m *SearchUsersReply) String() string            { return proto.CompactTextString(m) }
+func (*SearchUsersReply) ProtoMessage()               {}
+func (*SearchUsersReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *SearchUsersReply) GetProfiles() []*Profile {
+	if m != nil {
+		return m.Profiles
+	}
+	return nil
+}
+
+func (m *SearchUsersReply) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	Email  string                      `protobuf:"bytes,1,opt,name=email" json:"email,omitempty"`
+	Name   string                      `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Active *google_protobuf1.BoolValue `protobuf:"bytes,3,opt,name=active" json:"active,omitempty"`
+	Limit  int32                       `protobuf:"varint,4,opt,name=limit" json:"limit,omitempty"`
+	Cursor string                      `protobuf:"bytes,5,opt,name=cursor" json:"cursor,omitempty"`
+	State  string                      `protobuf:"bytes,6,opt,name=state" json:"state,omitempty"`
+}
+
+func (m *ListUsersRequest) Reset()                    { *m = ListUsersRequest{} }
+func (m *ListUsersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListUsersRequest) ProtoMessage()               {}
+func (*ListUsersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *ListUsersRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *ListUsersRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ListUsersRequest) GetActive() *google_protobuf1.BoolValue {
+	if m != nil {
+		return m.Active
+	}
+	return nil
+}
+
+func (m *ListUsersRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListUsersRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *ListUsersRequest) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+type ListUsersReply struct {
+	Users []*User `protobuf:"bytes,1,rep,name=users" json:"users,omitempty"`
+	// Continues the listing after this page. Empty after the last one.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor" json:"cursor,omitempty"`
+}
+
+func (m *ListUsersReply) Reset()                    { *m = ListUsersReply{} }
+func (m *ListUsersReply) String() string            { return proto.CompactTextString(m) }
+func (*ListUsersReply) ProtoMessage()               {}
+func (*ListUsersReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *ListUsersReply) GetUsers() []*User {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
+func (m *ListUsersReply) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+type GetUserRequest struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+}
+
+func (m *GetUserRequest) Reset()                    { *m = GetUserRequest{} }
+func (m *GetUserRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetUserRequest) ProtoMessage()               {}
+func (*GetUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *GetUserRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type ForcePasswordResetRequest struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+}
+
+func (m *ForcePasswordResetRequest) Reset()                    { *m = ForcePasswordResetRequest{} }
+func (m *ForcePasswordResetRequest) String() string            { return proto.CompactTextString(m) }
+func (*ForcePasswordResetRequest) ProtoMessage()               {}
+func (*ForcePasswordResetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *ForcePasswordResetRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type ForcePasswordResetReply struct {
+}
+
+func (m *ForcePasswordResetReply) Reset()                    { *m = ForcePasswordResetReply{} }
+func (m *ForcePasswordResetReply) String() string            { return proto.CompactTextString(m) }
+func (*ForcePasswordResetReply) ProtoMessage()               {}
+func (*ForcePasswordResetReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+type SetUserActiveRequest struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	Active bool   `protobuf:"varint,2,opt,name=active" json:"active,omitempty"`
+	// Recorded with the change of state.
+	Reason string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *SetUserActiveRequest) Reset()                    { *m = SetUserActiveRequest{} }
+func (m *SetUserActiveRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetUserActiveRequest) ProtoMessage()               {}
+func (*SetUserActiveRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *SetUserActiveRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *SetUserActiveRequest) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *SetUserActiveRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type SetUserActiveReply struct {
+}
+
+func (m *SetUserActiveReply) Reset()                    { *m = SetUserActiveReply{} }
+func (m *SetUserActiveReply) String() string            { return proto.CompactTextString(m) }
+func (*SetUserActiveReply) ProtoMessage()               {}
+func (*SetUserActiveReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+type PurgeUserRequest struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+}
+
+func (m *PurgeUserRequest) Reset()                    { *m = PurgeUserRequest{} }
+func (m *PurgeUserRequest) String() string            { return proto.CompactTextString(m) }
+func (*PurgeUserRequest) ProtoMessage()               {}
+func (*PurgeUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *PurgeUserRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type PurgeUserReply struct {
+}
+
+func (m *PurgeUserReply) Reset()                    { *m = PurgeUserReply{} }
+func (m *PurgeUserReply) String() string            { return proto.CompactTextString(m) }
+func (*PurgeUserReply) ProtoMessage()               {}
+func (*PurgeUserReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+type ListStateChangesRequest struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+}
+
+func (m *ListStateChangesRequest) Reset()                    { *m = ListStateChangesRequest{} }
+func (m *ListStateChangesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListStateChangesRequest) ProtoMessage()               {}
+func (*ListStateChangesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *ListStateChangesRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type StateChange struct {
+	Id        string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	UserId    string `protobuf:"bytes,2,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	FromState string `protobuf:"bytes,3,opt,name=from_state,json=fromState" json:"from_state,omitempty"`
+	ToState   string `protobuf:"bytes,4,opt,name=to_state,json=toState" json:"to_state,omitempty"`
+	Reason    string `protobuf:"bytes,5,opt,name=reason" json:"reason,omitempty"`
+	// Empty if usersvc made the change on its own.
+	ActorId   string                     `protobuf:"bytes,6,opt,name=actor_id,json=actorId" json:"actor_id,omitempty"`
+	CreatedAt *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+}
+
+func (m *StateChange) Reset()                    { *m = StateChange{} }
+func (m *StateChange) String() string            { return proto.CompactTextString(m) }
+func (*StateChange) ProtoMessage()               {}
+func (*StateChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *StateChange) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *StateChange) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *StateChange) GetFromState() string {
+	if m != nil {
+		return m.FromState
+	}
+	return ""
+}
+
+func (m *StateChange) GetToState() string {
+	if m != nil {
+		return m.ToState
+	}
+	return ""
+}
+
+func (m *StateChange) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *StateChange) GetActorId() string {
+	if m != nil {
+		return m.ActorId
+	}
+	return ""
+}
+
+func (m *StateChange) GetCreatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type ListStateChangesReply struct {
+	Changes []*StateChange `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
+}
+
+func (m *ListStateChangesReply) Reset()                    { *m = ListStateChangesReply{} }
+func (m *ListStateChangesReply) String() string            { return proto.CompactTextString(m) }
+func (*ListStateChangesReply) ProtoMessage()               {}
+func (*ListStateChangesReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *ListStateChangesReply) GetChanges() []*StateChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+type ListAuditEventsRequest struct {
+	// Matches events the user either performed or was the subject of.
+	UserId string                     `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	Type   string                     `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	Since  *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=since" json:"since,omitempty"`
+	Until  *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=until" json:"until,omitempty"`
+	Limit  int32                      `protobuf:"varint,5,opt,name=limit" json:"limit,omitempty"`
+	Cursor string                     `protobuf:"bytes,6,opt,name=cursor" json:"cursor,omitempty"`
+}
+
+func (m *ListAuditEventsRequest) Reset()                    { *m = ListAuditEventsRequest{} }
+func (m *ListAuditEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListAuditEventsRequest) ProtoMessage()               {}
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *ListAuditEventsRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetSince() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *ListAuditEventsRequest) GetUntil() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Until
+	}
+	return nil
+}
+
+func (m *ListAuditEventsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+type AuditEvent struct {
+	Id   int64  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Type string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	// Empty if no one could be told apart, such as for a failed login.
+	ActorId   string                     `protobuf:"bytes,3,opt,name=actor_id,json=actorId" json:"actor_id,omitempty"`
+	SubjectId string                     `protobuf:"bytes,4,opt,name=subject_id,json=subjectId" json:"subject_id,omitempty"`
+	ClientId  string                     `protobuf:"bytes,5,opt,name=client_id,json=clientId" json:"client_id,omitempty"`
+	Ip        string                     `protobuf:"bytes,6,opt,name=ip" json:"ip,omitempty"`
+	UserAgent string                     `protobuf:"bytes,7,opt,name=user_agent,json=userAgent" json:"user_agent,omitempty"`
+	Metadata  map[string]string          `protobuf:"bytes,8,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAt *google_protobuf.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	Hash      string                     `protobuf:"bytes,10,opt,name=hash" json:"hash,omitempty"`
+}
+
+func (m *AuditEvent) Reset()                    { *m = AuditEvent{} }
+func (m *AuditEvent) String() string            { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()               {}
+func (*AuditEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *AuditEvent) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *AuditEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetActorId() string {
+	if m != nil {
+		return m.ActorId
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetSubjectId() string {
+	if m != nil {
+		return m.SubjectId
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetUserAgent() string {
+	if m != nil {
+		return m.UserAgent
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *AuditEvent) GetCreatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *AuditEvent) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type ListAuditEventsReply struct {
+	Events []*AuditEvent `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
+	Cursor string        `protobuf:"bytes,2,opt,name=cursor" json:"cursor,omitempty"`
+}
+
+func (m *ListAuditEventsReply) Reset()                    { *m = ListAuditEventsReply{} }
+func (m *ListAuditEventsReply) String() string            { return proto.CompactTextString(m) }
+func (*ListAuditEventsReply) ProtoMessage()               {}
+func (*ListAuditEventsReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *ListAuditEventsReply) GetEvents() []*AuditEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ListAuditEventsReply) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+type VerifyAuditLogRequest struct {
+}
+
+func (m *VerifyAuditLogRequest) Reset()                    { *m = VerifyAuditLogRequest{} }
+func (m *VerifyAuditLogRequest) String() string            { return proto.CompactTextString(m) }
+func (*VerifyAuditLogRequest) ProtoMessage()               {}
+func (*VerifyAuditLogRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+type VerifyAuditLogReply struct {
+	Intact bool `protobuf:"varint,1,opt,name=intact" json:"intact,omitempty"`
+	// The ID of the first event that doesn't follow from the one before it, if the log isn't intact.
+	BrokenAt int64 `protobuf:"varint,2,opt,name=broken_at,json=brokenAt" json:"broken_at,omitempty"`
+}
+
+func (m *VerifyAuditLogReply) Reset()                    { *m = VerifyAuditLogReply{} }
+func (m *VerifyAuditLogReply) String() string            { return proto.CompactTextString(m) }
+func (*VerifyAuditLogReply) ProtoMessage()               {}
+func (*VerifyAuditLogReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *VerifyAuditLogReply) GetIntact() bool {
+	if m != nil {
+		return m.Intact
+	}
+	return false
+}
+
+func (m *VerifyAuditLogReply) GetBrokenAt() int64 {
+	if m != nil {
+		return m.BrokenAt
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*User)(nil), "usersvc.User")
+	proto.RegisterType((*Profile)(nil), "usersvc.Profile")
+	proto.RegisterType((*GetUserInfoRequest)(nil), "usersvc.GetUserInfoRequest")
+	proto.RegisterType((*UpdateUserRequest)(nil), "usersvc.UpdateUserRequest")
+	proto.RegisterType((*UpdateUserReply)(nil), "usersvc.UpdateUserReply")
+	proto.RegisterType((*DeleteUserRequest)(nil), "usersvc.DeleteUserRequest")
+	proto.RegisterType((*DeleteUserReply)(nil), "usersvc.DeleteUserReply")
+	proto.RegisterType((*SetAvatarRequest)(nil), "usersvc.SetAvatarRequest")
+	proto.RegisterType((*SetAvatarReply)(nil), "usersvc.SetAvatarReply")
+	proto.RegisterType((*DeleteAvatarRequest)(nil), "usersvc.DeleteAvatarRequest")
+	proto.RegisterType((*DeleteAvatarReply)(nil), "usersvc.DeleteAvatarReply")
+	proto.RegisterType((*EnrollTOTPRequest)(nil), "usersvc.EnrollTOTPRequest")
+	proto.RegisterType((*EnrollTOTPReply)(nil), "usersvc.EnrollTOTPReply")
+	proto.RegisterType((*TOTPCodeRequest)(nil), "usersvc.TOTPCodeRequest")
+	proto.RegisterType((*RecoveryCodesReply)(nil), "usersvc.RecoveryCodesReply")
+	proto.RegisterType((*DisableTOTPReply)(nil), "usersvc.DisableTOTPReply")
+	proto.RegisterType((*Identity)(nil), "usersvc.Identity")
+	proto.RegisterType((*ListIdentitiesRequest)(nil), "usersvc.ListIdentitiesRequest")
+	proto.RegisterType((*ListIdentitiesReply)(nil), "usersvc.ListIdentitiesReply")
+	proto.RegisterType((*UnlinkIdentityRequest)(nil), "usersvc.UnlinkIdentityRequest")
+	proto.RegisterType((*UnlinkIdentityReply)(nil), "usersvc.UnlinkIdentityReply")
+	proto.RegisterType((*Session)(nil), "usersvc.Session")
+	proto.RegisterType((*ListSessionsRequest)(nil), "usersvc.ListSessionsRequest")
+	proto.RegisterType((*ListSessionsReply)(nil), "usersvc.ListSessionsReply")
+	proto.RegisterType((*RevokeSessionRequest)(nil), "usersvc.RevokeSessionRequest")
+	proto.RegisterType((*RevokeSessionReply)(nil), "usersvc.RevokeSessionReply")
+	proto.RegisterType((*App)(nil), "usersvc.App")
+	proto.RegisterType((*ListAppsRequest)(nil), "usersvc.ListAppsRequest")
+	proto.RegisterType((*ListAppsReply)(nil), "usersvc.ListAppsReply")
+	proto.RegisterType((*RevokeAppRequest)(nil), "usersvc.RevokeAppRequest")
+	proto.RegisterType((*RevokeAppReply)(nil), "usersvc.RevokeAppReply")
+	proto.RegisterType((*GetProfileRequest)(nil), "usersvc.GetProfileRequest")
+	proto.RegisterType((*GetProfilesRequest)(nil), "usersvc.GetProfilesRequest")
+	proto.RegisterType((*GetProfilesReply)(nil), "usersvc.GetProfilesReply")
+	proto.RegisterType((*SearchUsersRequest)(nil), "usersvc.SearchUsersRequest")
+	proto.RegisterType((*SearchUsersReply)(nil), "usersvc.SearchUsersReply")
+	proto.RegisterType((*ListUsersRequest)(nil), "usersvc.ListUsersRequest")
+	proto.RegisterType((*ListUsersReply)(nil), "usersvc.ListUsersReply")
+	proto.RegisterType((*GetUserRequest)(nil), "usersvc.GetUserRequest")
+	proto.RegisterType((*ForcePasswordResetRequest)(nil), "usersvc.ForcePasswordResetRequest")
+	proto.RegisterType((*ForcePasswordResetReply)(nil), "usersvc.ForcePasswordResetReply")
+	proto.RegisterType((*SetUserActiveRequest)(nil), "usersvc.SetUserActiveRequest")
+	proto.RegisterType((*SetUserActiveReply)(nil), "usersvc.SetUserActiveReply")
+	proto.RegisterType((*PurgeUserRequest)(nil), "usersvc.PurgeUserRequest")
+	proto.RegisterType((*PurgeUserReply)(nil), "usersvc.PurgeUserReply")
+	proto.RegisterType((*ListStateChangesRequest)(nil), "usersvc.ListStateChangesRequest")
+	proto.RegisterType((*StateChange)(nil), "usersvc.StateChange")
+	proto.RegisterType((*ListStateChangesReply)(nil), "usersvc.ListStateChangesReply")
+	proto.RegisterType((*ListAuditEventsRequest)(nil), "usersvc.ListAuditEventsRequest")
+	proto.RegisterType((*AuditEvent)(nil), "usersvc.AuditEvent")
+	proto.RegisterType((*ListAuditEventsReply)(nil), "usersvc.ListAuditEventsReply")
+	proto.RegisterType((*VerifyAuditLogRequest)(nil), "usersvc.VerifyAuditLogRequest")
+	proto.RegisterType((*VerifyAuditLogReply)(nil), "usersvc.VerifyAuditLogReply")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Users service
+
+type UsersClient interface {
+	// Requires users.get.
+	GetUserInfo(ctx context.Context, in *GetUserInfoRequest, opts ...grpc.CallOption) (*User, error)
+	// Requires users.update.
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserReply, error)
+	// Requires users.delete.
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserReply, error)
+	// Requires users.update.
+	SetAvatar(ctx context.Context, in *SetAvatarRequest, opts ...grpc.CallOption) (*SetAvatarReply, error)
+	// Requires users.update.
+	DeleteAvatar(ctx context.Context, in *DeleteAvatarRequest, opts ...grpc.CallOption) (*DeleteAvatarReply, error)
+	// Requires users.update.
+	EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*EnrollTOTPReply, error)
+	// Requires users.update.
+	EnableTOTP(ctx context.Context, in *TOTPCodeRequest, opts ...grpc.CallOption) (*RecoveryCodesReply, error)
+	// Requires users.update.
+	DisableTOTP(ctx context.Context, in *TOTPCodeRequest, opts ...grpc.CallOption) (*DisableTOTPReply, error)
+	// Requires users.update.
+	RegenerateRecoveryCodes(ctx context.Context, in *TOTPCodeRequest, opts ...grpc.CallOption) (*RecoveryCodesReply, error)
+	// Requires users.get.
+	ListIdentities(ctx context.Context, in *ListIdentitiesRequest, opts ...grpc.CallOption) (*ListIdentitiesReply, error)
+	// Requires users.update.
+	UnlinkIdentity(ctx context.Context, in *UnlinkIdentityRequest, opts ...grpc.CallOption) (*UnlinkIdentityReply, error)
+	// Requires users.get.
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsReply, error)
+	// Requires users.update.
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionReply, error)
+	// Requires users.get.
+	ListApps(ctx context.Context, in *ListAppsRequest, opts ...grpc.CallOption) (*ListAppsReply, error)
+	// Requires users.update.
+	RevokeApp(ctx context.Context, in *RevokeAppRequest, opts ...grpc.CallOption) (*RevokeAppReply, error)
+	// Requires users.get.
+	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*Profile, error)
+	// Requires users.get.
+	GetProfiles(ctx context.Context, in *GetProfilesRequest, opts ...grpc.CallOption) (*GetProfilesReply, error)
+	// Requires users.search.
+	SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersReply, error)
+	// Requires users.admin.
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersReply, error)
+	// Requires users.admin.
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	// Requires users.admin.
+	ForcePasswordReset(ctx context.Context, in *ForcePasswordResetRequest, opts ...grpc.CallOption) (*ForcePasswordResetReply, error)
+	// Requires users.admin.
+	SetUserActive(ctx context.Context, in *SetUserActiveRequest, opts ...grpc.CallOption) (*SetUserActiveReply, error)
+	// Requires users.admin.
+	PurgeUser(ctx context.Context, in *PurgeUserRequest, opts ...grpc.CallOption) (*PurgeUserReply, error)
+	// Requires users.admin.
+	ListStateChanges(ctx context.Context, in *ListStateChangesRequest, opts ...grpc.CallOption) (*ListStateChangesReply, error)
+	// Requires users.admin.
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsReply, error)
+	// Requires users.admin.
+	VerifyAuditLog(ctx context.Context, in *VerifyAuditLogRequest, opts ...grpc.CallOption) (*VerifyAuditLogReply, error)
+}
+
+type usersClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUsersClient(cc *grpc.ClientConn) UsersClient {
+	return &usersClient{cc}
+}
+
+func (c *usersClient) GetUserInfo(ctx context.Context, in *GetUserInfoRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := grpc.Invoke(ctx, "/usersvc.Users/GetUserInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserReply, error) {
+	out := new(UpdateUserReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/UpdateUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserReply, error) {
+	out := new(DeleteUserReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/DeleteUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) SetAvatar(ctx context.Context, in *SetAvatarRequest, opts ...grpc.CallOption) (*SetAvatarReply, error) {
+	out := new(SetAvatarReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/SetAvatar", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) DeleteAvatar(ctx context.Context, in *DeleteAvatarRequest, opts ...grpc.CallOption) (*DeleteAvatarReply, error) {
+	out := new(DeleteAvatarReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/DeleteAvatar", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*EnrollTOTPReply, error) {
+	out := new(EnrollTOTPReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/EnrollTOTP", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) EnableTOTP(ctx context.Context, in *TOTPCodeRequest, opts ...grpc.CallOption) (*RecoveryCodesReply, error) {
+	out := new(RecoveryCodesReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/EnableTOTP", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) DisableTOTP(ctx context.Context, in *TOTPCodeRequest, opts ...grpc.CallOption) (*DisableTOTPReply, error) {
+	out := new(DisableTOTPReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/DisableTOTP", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) RegenerateRecoveryCodes(ctx context.Context, in *TOTPCodeRequest, opts ...grpc.CallOption) (*RecoveryCodesReply, error) {
+	out := new(RecoveryCodesReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/RegenerateRecoveryCodes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) ListIdentities(ctx context.Context, in *ListIdentitiesRequest, opts ...grpc.CallOption) (*ListIdentitiesReply, error) {
+	out := new(ListIdentitiesReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/ListIdentities", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) UnlinkIdentity(ctx context.Context, in *UnlinkIdentityRequest, opts ...grpc.CallOption) (*UnlinkIdentityReply, error) {
+	out := new(UnlinkIdentityReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/UnlinkIdentity", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsReply, error) {
+	out := new(ListSessionsReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/ListSessions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionReply, error) {
+	out := new(RevokeSessionReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/RevokeSession", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) ListApps(ctx context.Context, in *ListAppsRequest, opts ...grpc.CallOption) (*ListAppsReply, error) {
+	out := new(ListAppsReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/ListApps", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) RevokeApp(ctx context.Context, in *RevokeAppRequest, opts ...grpc.CallOption) (*RevokeAppReply, error) {
+	out := new(RevokeAppReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/RevokeApp", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*Profile, error) {
+	out := new(Profile)
+	err := grpc.Invoke(ctx, "/usersvc.Users/GetProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) GetProfiles(ctx context.Context, in *GetProfilesRequest, opts ...grpc.CallOption) (*GetProfilesReply, error) {
+	out := new(GetProfilesReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/GetProfiles", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersReply, error) {
+	out := new(SearchUsersReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/SearchUsers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersReply, error) {
+	out := new(ListUsersReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/ListUsers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := grpc.Invoke(ctx, "/usersvc.Users/GetUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) ForcePasswordReset(ctx context.Context, in *ForcePasswordResetRequest, opts ...grpc.CallOption) (*ForcePasswordResetReply, error) {
+	out := new(ForcePasswordResetReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/ForcePasswordReset", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) SetUserActive(ctx context.Context, in *SetUserActiveRequest, opts ...grpc.CallOption) (*SetUserActiveReply, error) {
+	out := new(SetUserActiveReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/SetUserActive", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) PurgeUser(ctx context.Context, in *PurgeUserRequest, opts ...grpc.CallOption) (*PurgeUserReply, error) {
+	out := new(PurgeUserReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/PurgeUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) ListStateChanges(ctx context.Context, in *ListStateChangesRequest, opts ...grpc.CallOption) (*ListStateChangesReply, error) {
+	out := new(ListStateChangesReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/ListStateChanges", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsReply, error) {
+	out := new(ListAuditEventsReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/ListAuditEvents", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) VerifyAuditLog(ctx context.Context, in *VerifyAuditLogRequest, opts ...grpc.CallOption) (*VerifyAuditLogReply, error) {
+	out := new(VerifyAuditLogReply)
+	err := grpc.Invoke(ctx, "/usersvc.Users/VerifyAuditLog", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Users service
+
+type UsersServer interface {
+	// Requires users.get.
+	GetUserInfo(context.Context, *GetUserInfoRequest) (*User, error)
+	// Requires users.update.
+	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserReply, error)
+	// Requires users.delete.
+	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserReply, error)
+	// Requires users.update.
+	SetAvatar(context.Context, *SetAvatarRequest) (*SetAvatarReply, error)
+	// Requires users.update.
+	DeleteAvatar(context.Context, *DeleteAvatarRequest) (*DeleteAvatarReply, error)
+	// Requires users.update.
+	EnrollTOTP(context.Context, *EnrollTOTPRequest) (*EnrollTOTPReply, error)
+	// Requires users.update.
+	EnableTOTP(context.Context, *TOTPCodeRequest) (*RecoveryCodesReply, error)
+	// Requires users.update.
+	DisableTOTP(context.Context, *TOTPCodeRequest) (*DisableTOTPReply, error)
+	// Requires users.update.
+	RegenerateRecoveryCodes(context.Context, *TOTPCodeRequest) (*RecoveryCodesReply, error)
+	// Requires users.get.
+	ListIdentities(context.Context, *ListIdentitiesRequest) (*ListIdentitiesReply, error)
+	// Requires users.update.
+	UnlinkIdentity(context.Context, *UnlinkIdentityRequest) (*UnlinkIdentityReply, error)
+	// Requires users.get.
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsReply, error)
+	// Requires users.update.
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionReply, error)
+	// Requires users.get.
+	ListApps(context.Context, *ListAppsRequest) (*ListAppsReply, error)
+	// Requires users.update.
+	RevokeApp(context.Context, *RevokeAppRequest) (*RevokeAppReply, error)
+	// Requires users.get.
+	GetProfile(context.Context, *GetProfileRequest) (*Profile, error)
+	// Requires users.get.
+	GetProfiles(context.Context, *GetProfilesRequest) (*GetProfilesReply, error)
+	// Requires users.search.
+	SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersReply, error)
+	// Requires users.admin.
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersReply, error)
+	// Requires users.admin.
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	// Requires users.admin.
+	ForcePasswordReset(context.Context, *ForcePasswordResetRequest) (*ForcePasswordResetReply, error)
+	// Requires users.admin.
+	SetUserActive(context.Context, *SetUserActiveRequest) (*SetUserActiveReply, error)
+	// Requires users.admin.
+	PurgeUser(context.Context, *PurgeUserRequest) (*PurgeUserReply, error)
+	// Requires users.admin.
+	ListStateChanges(context.Context, *ListStateChangesRequest) (*ListStateChangesReply, error)
+	// Requires users.admin.
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsReply, error)
+	// Requires users.admin.
+	VerifyAuditLog(context.Context, *VerifyAuditLogRequest) (*VerifyAuditLogReply, error)
+}
+
+func RegisterUsersServer(s *grpc.Server, srv UsersServer) {
+	s.RegisterService(&_Users_serviceDesc, srv)
+}
+
+func _Users_GetUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).GetUserInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/GetUserInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).GetUserInfo(ctx, req.(*GetUserInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/UpdateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/DeleteUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_SetAvatar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAvatarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).SetAvatar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/SetAvatar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).SetAvatar(ctx, req.(*SetAvatarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_DeleteAvatar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAvatarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).DeleteAvatar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/DeleteAvatar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).DeleteAvatar(ctx, req.(*DeleteAvatarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_EnrollTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).EnrollTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/EnrollTOTP",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).EnrollTOTP(ctx, req.(*EnrollTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_EnableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TOTPCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).EnableTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/EnableTOTP",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).EnableTOTP(ctx, req.(*TOTPCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_DisableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TOTPCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).DisableTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/DisableTOTP",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).DisableTOTP(ctx, req.(*TOTPCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_RegenerateRecoveryCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TOTPCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).RegenerateRecoveryCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/RegenerateRecoveryCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).RegenerateRecoveryCodes(ctx, req.(*TOTPCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_ListIdentities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIdentitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).ListIdentities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/ListIdentities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).ListIdentities(ctx, req.(*ListIdentitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_UnlinkIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).UnlinkIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/UnlinkIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).UnlinkIdentity(ctx, req.(*UnlinkIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/RevokeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_ListApps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAppsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).ListApps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/ListApps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).ListApps(ctx, req.(*ListAppsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_RevokeApp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAppRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).RevokeApp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/RevokeApp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).RevokeApp(ctx, req.(*RevokeAppRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/GetProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).GetProfile(ctx, req.(*GetProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_GetProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).GetProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/GetProfiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).GetProfiles(ctx, req.(*GetProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_SearchUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).SearchUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/SearchUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).SearchUsers(ctx, req.(*SearchUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/ListUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/GetUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_ForcePasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForcePasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).ForcePasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/ForcePasswordReset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).ForcePasswordReset(ctx, req.(*ForcePasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_SetUserActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserActiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).SetUserActive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/SetUserActive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).SetUserActive(ctx, req.(*SetUserActiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_PurgeUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).PurgeUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/PurgeUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).PurgeUser(ctx, req.(*PurgeUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_ListStateChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStateChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).ListStateChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/ListStateChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).ListStateChanges(ctx, req.(*ListStateChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_VerifyAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).VerifyAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usersvc.Users/VerifyAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).VerifyAuditLog(ctx, req.(*VerifyAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Users_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "usersvc.Users",
+	HandlerType: (*UsersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUserInfo",
+			Handler:    _Users_GetUserInfo_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _Users_UpdateUser_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _Users_DeleteUser_Handler,
+		},
+		{
+			MethodName: "SetAvatar",
+			Handler:    _Users_SetAvatar_Handler,
+		},
+		{
+			MethodName: "DeleteAvatar",
+			Handler:    _Users_DeleteAvatar_Handler,
+		},
+		{
+			MethodName: "EnrollTOTP",
+			Handler:    _Users_EnrollTOTP_Handler,
+		},
+		{
+			MethodName: "EnableTOTP",
+			Handler:    _Users_EnableTOTP_Handler,
+		},
+		{
+			MethodName: "DisableTOTP",
+			Handler:    _Users_DisableTOTP_Handler,
+		},
+		{
+			MethodName: "RegenerateRecoveryCodes",
+			Handler:    _Users_RegenerateRecoveryCodes_Handler,
+		},
+		{
+			MethodName: "ListIdentities",
+			Handler:    _Users_ListIdentities_Handler,
+		},
+		{
+			MethodName: "UnlinkIdentity",
+			Handler:    _Users_UnlinkIdentity_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _Users_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _Users_RevokeSession_Handler,
+		},
+		{
+			MethodName: "ListApps",
+			Handler:    _Users_ListApps_Handler,
+		},
+		{
+			MethodName: "RevokeApp",
+			Handler:    _Users_RevokeApp_Handler,
+		},
+		{
+			MethodName: "GetProfile",
+			Handler:    _Users_GetProfile_Handler,
+		},
+		{
+			MethodName: "GetProfiles",
+			Handler:    _Users_GetProfiles_Handler,
+		},
+		{
+			MethodName: "SearchUsers",
+			Handler:    _Users_SearchUsers_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _Users_ListUsers_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _Users_GetUser_Handler,
+		},
+		{
+			MethodName: "ForcePasswordReset",
+			Handler:    _Users_ForcePasswordReset_Handler,
+		},
+		{
+			MethodName: "SetUserActive",
+			Handler:    _Users_SetUserActive_Handler,
+		},
+		{
+			MethodName: "PurgeUser",
+			Handler:    _Users_PurgeUser_Handler,
+		},
+		{
+			MethodName: "ListStateChanges",
+			Handler:    _Users_ListStateChanges_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _Users_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "VerifyAuditLog",
+			Handler:    _Users_VerifyAuditLog_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "usersvc.proto",
+}
+
+func init() { proto.RegisterFile("usersvc.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 2078 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x38, 0x5b, 0x73, 0xdb, 0xc6,
+	0xd5, 0xc3, 0x3b, 0x79, 0x74, 0x31, 0xb5, 0x96, 0x45, 0x0a, 0x92, 0x6c, 0x05, 0xdf, 0xf8, 0xab,
+	0xdb, 0x64, 0xa4, 0x44, 0x49, 0x67, 0x9c, 0x38, 0x99, 0x0e, 0xe5, 0xa8, 0x8e, 0x9a, 0x38, 0x55,
+	0x29, 0x39, 0x93, 0x49, 0x1f, 0x38, 0x10, 0xb8, 0xa2, 0x50, 0x83, 0x00, 0x02, 0x2c, 0x99, 0x61,
+	0xdf, 0xfa, 0x13, 0xfa, 0x5f, 0xfa, 0xde, 0xf6, 0xb9, 0x3f, 0xa0, 0xd3, 0x97, 0xfe, 0x94, 0x4e,
+	0xe7, 0xec, 0x1e, 0x00, 0x0b, 0x10, 0x10, 0xe5, 0xbe, 0xe1, 0x5c, 0x77, 0xcf, 0x65, 0xcf, 0x05,
+	0xb0, 0x31, 0x8b, 0x78, 0x18, 0xcd, 0xed, 0xa3, 0x20, 0xf4, 0x85, 0xcf, 0x5a, 0x04, 0x1a, 0x4f,
+	0x26, 0xbe, 0x3f, 0x71, 0xf9, 0xb1, 0x44, 0x5f, 0xcf, 0x6e, 0x8e, 0x85, 0x33, 0xe5, 0x91, 0xb0,
+	0xa6, 0x81, 0xe2, 0x34, 0x1e, 0xe7, 0x19, 0x7e, 0x0a, 0xad, 0x20, 0xe0, 0x61, 0xa4, 0xe8, 0xe6,
+	0x5f, 0xab, 0x50, 0x7f, 0x13, 0xf1, 0x90, 0x6d, 0x42, 0xd5, 0x19, 0xf7, 0x2b, 0x87, 0x95, 0x67,
+	0x9d, 0x61, 0xd5, 0x19, 0x33, 0x06, 0x75, 0xcf, 0x9a, 0xf2, 0x7e, 0x55, 0x62, 0xe4, 0x37, 0xdb,
+	0x86, 0x06, 0x9f, 0x5a, 0x8e, 0xdb, 0xaf, 0x49, 0xa4, 0x02, 0xd8, 0x0e, 0x34, 0x2d, 0x5b, 0x38,
+	0x73, 0xde, 0xaf, 0x1f, 0x56, 0x9e, 0xb5, 0x87, 0x04, 0xb1, 0xa7, 0xb0, 0x29, 0x19, 0x46, 0x73,
+	0x1e, 0x3a, 0x37, 0x0e, 0x1f, 0xf7, 0x1b, 0x92, 0xbe, 0x21, 0xb1, 0xdf, 0x11, 0x92, 0x1d, 0x00,
+	0x4c, 0x9c, 0x39, 0xf7, 0x46, 0xf2, 0xb8, 0xa6, 0xd4, 0xdc, 0x91, 0x98, 0x6f, 0xf1, 0xcc, 0x27,
+	0xb0, 0x76, 0x63, 0x4d, 0x1d, 0x77, 0xa1, 0xe8, 0x2d, 0x49, 0x07, 0x85, 0x92, 0x0c, 0x06, 0xb4,
+	0x83, 0xd0, 0xf7, 0xfc, 0x99, 0x17, 0xf5, 0xdb, 0x92, 0x9a, 0xc0, 0xac, 0x0f, 0xad, 0xc0, 0xb1,
+	0xc5, 0x2c, 0xe4, 0xfd, 0x8e, 0x24, 0xc5, 0x20, 0x5e, 0xda, 0xf5, 0x6d, 0xcb, 0xe5, 0x7d, 0x90,
+	0x04, 0x82, 0x50, 0xdb, 0x1f, 0x7d, 0x8f, 0x3b, 0xde, 0x8d, 0xdf, 0x5f, 0x53, 0xda, 0x62, 0x18,
+	0xcd, 0x8f, 0x84, 0x25, 0x78, 0x7f, 0x5d, 0x99, 0x2f, 0x01, 0xf3, 0x9f, 0x15, 0x68, 0x5d, 0x84,
+	0xfe, 0x8d, 0xe3, 0xf2, 0x7b, 0x39, 0x31, 0x6b, 0x6f, 0x6d, 0x85, 0xbd, 0xf5, 0x3b, 0xed, 0x6d,
+	0x94, 0xdb, 0xdb, 0x2c, 0xb3, 0xb7, 0x55, 0x6a, 0x6f, 0x3b, 0x6b, 0xaf, 0xb9, 0x0d, 0xec, 0x15,
+	0x17, 0x98, 0x1d, 0xe7, 0xde, 0x8d, 0x3f, 0xe4, 0x3f, 0xce, 0x78, 0x24, 0xcc, 0x7f, 0xd5, 0x60,
+	0xeb, 0x4d, 0x30, 0xb6, 0x04, 0x47, 0x0a, 0x61, 0xd9, 0x87, 0x64, 0x29, 0xda, 0xbe, 0x76, 0xb2,
+	0x7f, 0xa4, 0xd2, 0xee, 0x28, 0x4e, 0xbb, 0xa3, 0x4b, 0x11, 0x3a, 0xde, 0xe4, 0x3b, 0xcb, 0x9d,
+	0x71, 0xf2, 0xc3, 0x49, 0x9c, 0x4c, 0xd5, 0x7b, 0x88, 0x50, 0xaa, 0x3d, 0x87, 0x76, 0x60, 0x45,
+	0xd1, 0x4f, 0x7e, 0x38, 0xee, 0xd7, 0xee, 0x21, 0x96, 0x70, 0xb3, 0x17, 0x19, 0xaf, 0xd7, 0xef,
+	0x21, 0xab, 0xc5, 0xe4, 0x8b, 0x6c, 0x4c, 0x1a, 0xf7, 0x90, 0xd6, 0x23, 0xf6, 0x5c, 0x8b, 0x58,
+	0xf3, 0x5e, 0xb7, 0x8e, 0xe3, 0xf9, 0x49, 0x26, 0x6a, 0xab, 0xe4, 0xe2, 0x98, 0x3e, 0xcf, 0xc5,
+	0x74, 0xe5, 0x79, 0x49, 0xc4, 0xb7, 0xe0, 0x81, 0x1e, 0xda, 0xc0, 0x5d, 0x98, 0x0f, 0x61, 0xeb,
+	0x4b, 0xee, 0xf2, 0x4c, 0xb4, 0x91, 0x4f, 0x47, 0x22, 0xdf, 0xd7, 0xd0, 0xbd, 0xe4, 0x62, 0x30,
+	0xb7, 0x84, 0x95, 0x24, 0xc5, 0x7b, 0xb0, 0x6e, 0xfb, 0x9e, 0xe0, 0x9e, 0x18, 0x89, 0x45, 0xc0,
+	0xe9, 0x61, 0xac, 0x11, 0xee, 0x6a, 0x11, 0xc8, 0x92, 0xe2, 0x4c, 0xad, 0x89, 0x7a, 0x22, 0xeb,
+	0x43, 0x05, 0x98, 0xbf, 0x80, 0x4d, 0x4d, 0x59, 0xe0, 0x2e, 0xf4, 0xcc, 0xae, 0x64, 0x32, 0xdb,
+	0x7c, 0x04, 0x0f, 0xd5, 0x5d, 0x32, 0x67, 0xa7, 0xf7, 0xd6, 0xb4, 0x20, 0xf2, 0xcc, 0x0b, 0x7d,
+	0xd7, 0xbd, 0xfa, 0xed, 0xd5, 0x45, 0xcc, 0xf9, 0x02, 0x1e, 0xe8, 0x48, 0x3c, 0x6d, 0x07, 0x9a,
+	0x11, 0xb7, 0x43, 0x2e, 0xe8, 0x30, 0x82, 0x58, 0x17, 0x6a, 0xb3, 0xd0, 0xa1, 0xe7, 0x8c, 0x9f,
+	0xe6, 0x53, 0x78, 0x80, 0x62, 0x2f, 0xfd, 0x31, 0x8f, 0xad, 0x66, 0x50, 0xb7, 0xfd, 0x71, 0x7c,
+	0x4f, 0xf9, 0x6d, 0xbe, 0x00, 0x36, 0xe4, 0xb6, 0x3f, 0xe7, 0xe1, 0x02, 0x59, 0x23, 0x75, 0xcc,
+	0x53, 0xd8, 0x0c, 0x09, 0x3b, 0x42, 0xb6, 0xa8, 0x5f, 0x39, 0xac, 0x3d, 0xeb, 0x0c, 0x37, 0x42,
+	0x9d, 0xd7, 0x64, 0xd0, 0xfd, 0xd2, 0x89, 0xac, 0x6b, 0x97, 0x27, 0x37, 0x34, 0xff, 0x5c, 0x81,
+	0xf6, 0xf9, 0x98, 0x7b, 0xc2, 0x11, 0x0b, 0x2a, 0x09, 0x73, 0x67, 0xcc, 0x43, 0x3a, 0x35, 0x81,
+	0xd1, 0x71, 0xd1, 0xec, 0xfa, 0x0f, 0xdc, 0x16, 0x74, 0xed, 0x18, 0x2c, 0xa9, 0xe6, 0x9f, 0x02,
+	0xd8, 0x21, 0xb7, 0x04, 0x1f, 0x8f, 0x2c, 0x41, 0x0f, 0xc5, 0x58, 0x4a, 0x9f, 0xab, 0xb8, 0xcd,
+	0x0c, 0x3b, 0xc4, 0x3d, 0x10, 0x66, 0x0f, 0x1e, 0x7d, 0xe3, 0x44, 0x82, 0xae, 0xe5, 0xf0, 0x88,
+	0x3c, 0x62, 0x7e, 0x05, 0x0f, 0xf3, 0x04, 0x34, 0xff, 0x23, 0x00, 0x27, 0x41, 0x49, 0xd3, 0xd7,
+	0x4e, 0xb6, 0x8e, 0xe2, 0x4e, 0x17, 0x5b, 0x37, 0xd4, 0x98, 0xcc, 0x8f, 0xe1, 0xd1, 0x1b, 0xcf,
+	0x75, 0xbc, 0xb7, 0x09, 0x95, 0x9c, 0x7e, 0x87, 0x0b, 0x30, 0x43, 0xf2, 0x42, 0xe8, 0xc2, 0xbf,
+	0x57, 0xa0, 0x75, 0xc9, 0xa3, 0xc8, 0xf1, 0xbd, 0xa5, 0xc2, 0x9d, 0xf5, 0x42, 0xf5, 0x1d, 0xbc,
+	0xc0, 0x3e, 0x87, 0x75, 0xd7, 0x8a, 0xc4, 0x28, 0xe2, 0xdc, 0x43, 0xe1, 0xda, 0x4a, 0x61, 0x40,
+	0xfe, 0x4b, 0xce, 0xbd, 0x81, 0x90, 0x17, 0x09, 0xa8, 0xea, 0x57, 0x9d, 0x00, 0xbb, 0x05, 0x3a,
+	0x64, 0x64, 0x4d, 0xb8, 0x27, 0xa8, 0xde, 0x77, 0x10, 0x33, 0x40, 0x84, 0xf9, 0x48, 0x79, 0x96,
+	0xcc, 0x48, 0x1c, 0x3e, 0x80, 0xad, 0x2c, 0x1a, 0xdd, 0xfd, 0x01, 0xb4, 0x23, 0x42, 0x90, 0xb3,
+	0xbb, 0x89, 0xb3, 0x89, 0x73, 0x98, 0x70, 0x98, 0xbf, 0x84, 0xed, 0x21, 0x9f, 0xfb, 0x6f, 0x79,
+	0x4c, 0x22, 0x47, 0x1f, 0x00, 0x10, 0xcf, 0x28, 0xf1, 0x58, 0x87, 0x30, 0xe7, 0x63, 0xec, 0x19,
+	0x39, 0x31, 0x74, 0xf5, 0x7f, 0x2a, 0x50, 0x1b, 0x04, 0x01, 0xdb, 0x83, 0x8e, 0xed, 0x3a, 0x58,
+	0x0f, 0x12, 0xd9, 0xb6, 0x42, 0x9c, 0x97, 0x36, 0x4b, 0x12, 0xc0, 0x77, 0x47, 0xcd, 0x52, 0x61,
+	0xde, 0x84, 0x0e, 0xdb, 0x85, 0xb6, 0xeb, 0x4f, 0x7c, 0x49, 0x54, 0x3e, 0x6b, 0x21, 0x8c, 0x24,
+	0x7c, 0xc2, 0xb6, 0x1f, 0x70, 0x6c, 0x92, 0x35, 0xf9, 0x84, 0x25, 0x84, 0x91, 0x9d, 0x84, 0x96,
+	0x47, 0x91, 0x6d, 0xae, 0x8e, 0x2c, 0x71, 0x0f, 0x04, 0x8a, 0xce, 0x64, 0x75, 0x94, 0xa2, 0xad,
+	0xd5, 0xa2, 0xc4, 0x3d, 0x90, 0x05, 0x13, 0x03, 0x32, 0x08, 0x82, 0x24, 0x46, 0x1f, 0xc1, 0x46,
+	0x8a, 0xc2, 0xf8, 0x1c, 0x42, 0xdd, 0x0a, 0x82, 0x38, 0x36, 0xeb, 0x49, 0x6c, 0x06, 0x41, 0x30,
+	0x94, 0x14, 0xf3, 0x18, 0xba, 0xca, 0xb9, 0x88, 0xa2, 0x78, 0xdc, 0xe5, 0x52, 0xb3, 0x0b, 0x9b,
+	0x9a, 0x00, 0x46, 0xe2, 0x03, 0xd8, 0x7a, 0xc5, 0x05, 0xcd, 0x2b, 0xb1, 0x8e, 0x1e, 0xc8, 0x81,
+	0x32, 0xd5, 0xd0, 0x44, 0xf0, 0x7c, 0x6c, 0x1e, 0x03, 0x4b, 0xb9, 0xe3, 0x9b, 0xa3, 0xd7, 0x89,
+	0x3d, 0x2e, 0x58, 0x2d, 0xc5, 0x1f, 0x99, 0x7f, 0xab, 0x40, 0x37, 0x23, 0x81, 0x86, 0xbd, 0x94,
+	0x6f, 0x53, 0x22, 0xc8, 0xb8, 0x9f, 0x25, 0xc6, 0xe5, 0x99, 0x8f, 0x62, 0xe8, 0xcc, 0x13, 0xe1,
+	0x62, 0x98, 0x08, 0x62, 0x1d, 0x9b, 0x3a, 0x51, 0xe4, 0x78, 0x93, 0x7e, 0x55, 0x9d, 0x49, 0xa0,
+	0xf1, 0x1a, 0x36, 0x32, 0x42, 0x58, 0xa5, 0xdf, 0xf2, 0x05, 0x99, 0x82, 0x9f, 0xec, 0xff, 0xa1,
+	0x31, 0xc7, 0x56, 0x47, 0x2f, 0x39, 0xcd, 0xfb, 0xd8, 0x11, 0x8a, 0xfc, 0x59, 0xf5, 0x79, 0xc5,
+	0xfc, 0x1e, 0xd8, 0x25, 0xb7, 0x42, 0xfb, 0x16, 0x7b, 0x5b, 0x62, 0xf3, 0x36, 0x34, 0x7e, 0x9c,
+	0xf1, 0x30, 0xd6, 0xaa, 0x00, 0xc4, 0xba, 0xce, 0xd4, 0x51, 0x15, 0xa2, 0x31, 0x54, 0x00, 0xa6,
+	0x9e, 0x3d, 0x0b, 0x23, 0x3f, 0xa4, 0x84, 0x25, 0xc8, 0xfc, 0x1e, 0xba, 0x19, 0xcd, 0xf4, 0x28,
+	0x73, 0xbe, 0x59, 0xbe, 0x5c, 0xea, 0x84, 0x54, 0x73, 0x35, 0xa3, 0xf9, 0x2f, 0x15, 0xe8, 0x62,
+	0x32, 0xe5, 0xaf, 0xac, 0xea, 0x7b, 0x45, 0xaf, 0xef, 0x45, 0xaf, 0xec, 0x24, 0x99, 0xe0, 0xcb,
+	0x8a, 0xd5, 0xa9, 0xef, 0xbb, 0x34, 0x64, 0x28, 0xce, 0xd4, 0xf4, 0x7a, 0xb1, 0xe9, 0x0d, 0xfd,
+	0x82, 0xe9, 0xe8, 0xdc, 0xd4, 0x47, 0xe7, 0xd7, 0xb0, 0xa9, 0xdd, 0x1a, 0xdd, 0xf1, 0x7f, 0xd0,
+	0x90, 0xd6, 0x93, 0x2f, 0x36, 0x12, 0x5f, 0x20, 0xcf, 0x50, 0xd1, 0x4a, 0xbd, 0xf0, 0x73, 0xd8,
+	0xa4, 0x79, 0x75, 0x65, 0x62, 0x7f, 0x02, 0xbb, 0xbf, 0xf6, 0x43, 0x9b, 0x5f, 0xd0, 0x7c, 0x38,
+	0xe4, 0x11, 0x17, 0x2b, 0xa5, 0x76, 0xa1, 0x57, 0x24, 0x85, 0xef, 0x6a, 0x04, 0xdb, 0x97, 0xea,
+	0xec, 0x81, 0xf4, 0xcf, 0x2a, 0x5d, 0xda, 0xd6, 0x54, 0xcd, 0x6c, 0x4d, 0x3b, 0xd0, 0x0c, 0xb9,
+	0x15, 0xf9, 0x5e, 0x9c, 0x3c, 0x0a, 0xc2, 0xc2, 0x9a, 0x3b, 0x00, 0x8f, 0x7d, 0x1f, 0xba, 0x17,
+	0xb3, 0x70, 0xc2, 0xef, 0x65, 0x74, 0x17, 0x36, 0x35, 0x66, 0x14, 0x3f, 0x81, 0x9e, 0xec, 0x13,
+	0x18, 0x8d, 0x97, 0xb7, 0x96, 0x37, 0xe1, 0xd1, 0x4a, 0x2d, 0xff, 0xae, 0xc0, 0x9a, 0x26, 0xb0,
+	0xd4, 0x3a, 0x35, 0xc1, 0x6a, 0xc6, 0xe2, 0x03, 0x80, 0x9b, 0xd0, 0x9f, 0x8e, 0x54, 0x22, 0x50,
+	0x2d, 0x47, 0x8c, 0xd4, 0x86, 0x55, 0x45, 0xf8, 0x44, 0xa4, 0x5a, 0x2e, 0x7c, 0x45, 0x4a, 0x7d,
+	0xd2, 0xd0, 0x7d, 0x82, 0x22, 0x96, 0x2d, 0x7c, 0x79, 0x16, 0xed, 0x3b, 0x12, 0x3e, 0xcf, 0x37,
+	0xf0, 0xd6, 0xbb, 0x8c, 0x31, 0xaf, 0xd4, 0x18, 0x93, 0x75, 0x0a, 0x26, 0xe7, 0x11, 0xb4, 0x6c,
+	0x05, 0x53, 0x7a, 0x6e, 0xa7, 0xfd, 0x33, 0x65, 0x1e, 0xc6, 0x4c, 0xb8, 0x19, 0xee, 0xc8, 0x12,
+	0x3f, 0x1b, 0x3b, 0xe2, 0x6c, 0xce, 0x3d, 0xb1, 0xd2, 0xbb, 0xf8, 0x3c, 0xe5, 0xa8, 0x4c, 0xcf,
+	0x13, 0xbf, 0xd9, 0x87, 0xd0, 0x88, 0x1c, 0xcf, 0xe6, 0xf7, 0x18, 0x25, 0x14, 0x23, 0x4a, 0xcc,
+	0x3c, 0xe1, 0xb8, 0xf7, 0x98, 0xdf, 0x14, 0x63, 0xfa, 0x9c, 0x1b, 0xc5, 0xcf, 0xb9, 0x99, 0x79,
+	0x69, 0x7f, 0xaa, 0x01, 0xa4, 0x56, 0x69, 0x29, 0x50, 0x8b, 0xd7, 0xde, 0x25, 0x23, 0xf4, 0x58,
+	0xd5, 0xb2, 0xb1, 0xc2, 0x91, 0x42, 0xcd, 0xa4, 0x48, 0xac, 0xd3, 0x48, 0xa1, 0x30, 0xe7, 0xe3,
+	0x6c, 0x87, 0x6b, 0xe4, 0x86, 0x06, 0x35, 0x2f, 0x35, 0x4b, 0xe6, 0xa5, 0x56, 0x6e, 0x5e, 0x62,
+	0x5f, 0x40, 0x7b, 0xca, 0x85, 0x35, 0xb6, 0x84, 0xd5, 0x6f, 0xcb, 0x18, 0xbe, 0x97, 0xf6, 0xd9,
+	0xc4, 0xa0, 0xa3, 0xd7, 0xc4, 0x43, 0x4d, 0x28, 0x16, 0xc9, 0x65, 0x55, 0xe7, 0x5d, 0xc6, 0x42,
+	0x06, 0xf5, 0x5b, 0x2b, 0xba, 0xa5, 0xdf, 0x0d, 0xf2, 0xdb, 0x78, 0x01, 0x1b, 0x99, 0x93, 0x0a,
+	0x3a, 0xd7, 0xb6, 0xde, 0xb9, 0x3a, 0x7a, 0x9f, 0xfa, 0x3d, 0x6c, 0x2f, 0x25, 0x17, 0x66, 0xe9,
+	0xfb, 0xd0, 0xe4, 0x12, 0xa4, 0x24, 0x7d, 0x58, 0x60, 0xe0, 0x90, 0x58, 0x4a, 0x4b, 0x69, 0x0f,
+	0x1e, 0xc9, 0x1f, 0x34, 0x0b, 0x29, 0xf3, 0x8d, 0x3f, 0x89, 0xa7, 0x96, 0xdf, 0xc0, 0xc3, 0x3c,
+	0x81, 0x16, 0x26, 0xc7, 0x13, 0x96, 0xad, 0x16, 0xa6, 0xf6, 0x90, 0x20, 0x8c, 0xdd, 0x75, 0xe8,
+	0xbf, 0x55, 0x93, 0x70, 0x55, 0x26, 0x48, 0x5b, 0x21, 0x06, 0xe2, 0xe4, 0x1f, 0x9b, 0xd0, 0x90,
+	0xb5, 0x9f, 0x7d, 0x0a, 0x6b, 0xda, 0x9f, 0x06, 0xb6, 0xa7, 0x8f, 0x07, 0xb9, 0xff, 0x0f, 0x46,
+	0xb6, 0x27, 0xb0, 0x53, 0x80, 0x74, 0x65, 0x65, 0x46, 0x4a, 0xcc, 0xff, 0xa2, 0x30, 0xfa, 0x85,
+	0x34, 0xbc, 0xfd, 0x29, 0x40, 0xba, 0xce, 0x6a, 0x3a, 0x96, 0x16, 0x5f, 0xa3, 0x5f, 0x48, 0x43,
+	0x1d, 0xbf, 0x82, 0x4e, 0xb2, 0xb2, 0xb2, 0x5d, 0x6d, 0xb0, 0xce, 0xee, 0xc4, 0x46, 0xaf, 0x88,
+	0x84, 0x0a, 0xbe, 0x82, 0x75, 0x7d, 0x61, 0x65, 0xfb, 0xb9, 0xa3, 0xb2, 0x6a, 0x8c, 0x12, 0x2a,
+	0x99, 0x93, 0x2e, 0xb4, 0x9a, 0x39, 0x4b, 0xab, 0xaf, 0xd1, 0x2f, 0xa4, 0xa9, 0x99, 0x0d, 0xce,
+	0xbc, 0x78, 0xe5, 0x64, 0x29, 0x5f, 0x6e, 0xd9, 0x35, 0xd2, 0x50, 0x15, 0xec, 0xb7, 0xa7, 0xb0,
+	0xa6, 0x2d, 0xae, 0x77, 0x68, 0x49, 0xfd, 0x95, 0x5f, 0x74, 0xd9, 0x05, 0xf4, 0x86, 0x7c, 0xc2,
+	0x3d, 0x1e, 0x5a, 0x82, 0x67, 0xce, 0xf8, 0x5f, 0x6f, 0xf5, 0xad, 0x9a, 0x3a, 0xd2, 0x6d, 0x94,
+	0x3d, 0x4e, 0xd8, 0x0b, 0xf7, 0x57, 0x63, 0xbf, 0x94, 0x4e, 0xfa, 0xb2, 0xeb, 0xa5, 0xa6, 0xaf,
+	0x70, 0x59, 0x35, 0xf6, 0x4b, 0xe9, 0x94, 0x08, 0xfa, 0xf2, 0xc6, 0xb2, 0xa7, 0xe7, 0x56, 0x3d,
+	0xc3, 0x28, 0xa1, 0xa2, 0xa6, 0xaf, 0x61, 0x23, 0xb3, 0x8c, 0xb1, 0x03, 0xcd, 0x2f, 0xcb, 0xbb,
+	0x9d, 0xb1, 0x57, 0x46, 0x46, 0x65, 0x9f, 0x43, 0x3b, 0xde, 0x57, 0x34, 0xcf, 0xe7, 0xb6, 0x1a,
+	0x63, 0xa7, 0x80, 0x42, 0xcf, 0x23, 0xd9, 0x44, 0xb4, 0xe7, 0x91, 0x5f, 0x67, 0x8c, 0x5e, 0x11,
+	0x09, 0x15, 0x7c, 0x06, 0x90, 0xee, 0x0a, 0x5a, 0x52, 0x2f, 0x6d, 0x33, 0xc6, 0xd2, 0x00, 0xcd,
+	0xce, 0x64, 0x79, 0xb9, 0x88, 0xa7, 0xe8, 0xbd, 0xe2, 0xed, 0x23, 0x9f, 0x8a, 0x4b, 0x7b, 0xcc,
+	0x19, 0xac, 0x69, 0xf3, 0xbb, 0xa6, 0x66, 0x79, 0x5f, 0x30, 0x76, 0x8b, 0x89, 0xe4, 0x8a, 0x64,
+	0xea, 0xd5, 0x5c, 0x91, 0x9f, 0xdf, 0x8d, 0x5e, 0x11, 0x49, 0xfd, 0x37, 0x69, 0x51, 0x5d, 0x64,
+	0xbd, 0x7c, 0xa5, 0x2c, 0xa9, 0x92, 0x3f, 0x00, 0x5b, 0x9e, 0x5c, 0x99, 0x99, 0x30, 0x95, 0x0e,
+	0xc3, 0xc6, 0xe1, 0x9d, 0x3c, 0x94, 0x65, 0x99, 0xc9, 0x54, 0xcb, 0xb2, 0xa2, 0x91, 0xd8, 0xd8,
+	0x2b, 0x23, 0x93, 0x73, 0x92, 0x19, 0x55, 0x73, 0x4e, 0x7e, 0xc8, 0x35, 0x7a, 0x45, 0x24, 0x54,
+	0x70, 0xa5, 0x36, 0x21, 0x7d, 0x7a, 0x63, 0x87, 0xd9, 0x37, 0xb2, 0x3c, 0xed, 0x1a, 0x8f, 0xef,
+	0xe0, 0x40, 0xad, 0xbf, 0xa3, 0xfd, 0x3d, 0x6d, 0xb6, 0xec, 0x49, 0x36, 0xd3, 0x97, 0x66, 0x3c,
+	0xe3, 0xa0, 0x9c, 0x81, 0xca, 0x46, 0xb6, 0x93, 0x6a, 0x65, 0xa3, 0xb0, 0xf7, 0x1a, 0xfb, 0xa5,
+	0xf4, 0xc0, 0x5d, 0x9c, 0x9a, 0x3f, 0x1c, 0x4e, 0x1c, 0x71, 0x3b, 0xbb, 0x3e, 0xb2, 0xfd, 0xe9,
+	0x71, 0x24, 0x66, 0x63, 0xc7, 0x9f, 0x45, 0xee, 0xe2, 0x98, 0x84, 0x8e, 0x83, 0xeb, 0xeb, 0xa6,
+	0x9c, 0x51, 0x3e, 0xfe, 0xef, 0x00, 0x78, 0x11, 0x02, 0xb8, 0x4f, 0x1a, 0x00, 0x00,
+}
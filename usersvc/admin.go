@@ -0,0 +1,93 @@
+package usersvc
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/studiously/usersvc/models"
+)
+
+const (
+	// defaultPageSize is how many users ListUsers returns when no limit is given.
+	defaultPageSize = 50
+	// maxPageSize caps the limit given to ListUsers.
+	maxPageSize = 200
+)
+
+// UserFilter narrows down and pages through the users returned by ListUsers.
+type UserFilter struct {
+	// Email and Name match users whose email or name contain them, ignoring case.
+	Email string
+	Name  string
+	// Active, if set, matches only active or only inactive users.
+	Active *bool
+	// State, if set, matches only users in that state.
+	State string
+	// Cursor continues a previous listing from where it left off.
+	Cursor string
+	// Limit is the most users to return. Defaults to 50, and cannot exceed 200.
+	Limit int
+}
+
+// query returns the SQL and arguments listing up to limit users matching f, ordered by email.
+func (f UserFilter) query(limit int) (string, []interface{}, error) {
+	var where []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if f.Email != "" {
+		where = append(where, "email ILIKE "+arg("%"+escapeLike(f.Email)+"%"))
+	}
+	if f.Name != "" {
+		where = append(where, "name ILIKE "+arg("%"+escapeLike(f.Name)+"%"))
+	}
+	if f.Active != nil {
+		where = append(where, "(state = 'active') = "+arg(*f.Active))
+	}
+	if f.State != "" {
+		where = append(where, "state = "+arg(f.State))
+	}
+	if f.Cursor != "" {
+		after, err := base64.RawURLEncoding.DecodeString(f.Cursor)
+		if err != nil {
+			return "", nil, ErrBadRequest
+		}
+		where = append(where, "email > "+arg(string(after)))
+	}
+	q := `SELECT id, name, email, email_verified, ` +
+		`given_name, family_name, pronouns, picture, locale, zoneinfo, deleted_at, state FROM users`
+	if len(where) > 0 {
+		q += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	q += ` ORDER BY email LIMIT ` + arg(limit)
+	return q, args, nil
+}
+
+// pageLimit applies the default and maximum page sizes to a requested limit.
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return defaultPageSize
+	}
+	if limit > maxPageSize {
+		return maxPageSize
+	}
+	return limit
+}
+
+// nextPage trims a page fetched with one row more than limit, returning the cursor that continues after it, or ""
+// if there is nothing more.
+func nextPage(page []*models.User, limit int) ([]*models.User, string) {
+	if len(page) <= limit {
+		return page, ""
+	}
+	page = page[:limit]
+	return page, base64.RawURLEncoding.EncodeToString([]byte(page[limit-1].Email))
+}
+
+// escapeLike escapes the characters that are special in a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
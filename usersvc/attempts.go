@@ -0,0 +1,151 @@
+package usersvc
+
+import (
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AttemptStore records failed login attempts so that Authenticate can slow down password guessing.
+type AttemptStore interface {
+	// Failures returns how many failed attempts are recorded for key, and when the most recent one happened.
+	Failures(key string) (n int, last time.Time, err error)
+	// AddFailure records a failed attempt for key at the given time. Failures recorded before since are forgotten
+	// first.
+	AddFailure(key string, at, since time.Time) error
+	// Reset forgets all failed attempts for key.
+	Reset(key string) error
+}
+
+// throttle implements exponential backoff over an AttemptStore. The first free failures cost nothing; after that,
+// each failure doubles the time the caller has to wait before trying again, up to max. Failures are forgotten once
+// window has passed without another one.
+type throttle struct {
+	free      int
+	base, max time.Duration
+	window    time.Duration
+}
+
+var (
+	// accountThrottle applies per email address, whether or not an account with that address exists.
+	accountThrottle = throttle{free: 5, base: time.Second, max: 15 * time.Minute, window: 24 * time.Hour}
+	// addrThrottle applies per client IP. It is far more lenient, since a whole school may share one address.
+	addrThrottle = throttle{free: 100, base: time.Second, max: 15 * time.Minute, window: time.Hour}
+	// codeThrottle applies per user to two-factor codes. Without it, a million possible codes don't last long.
+	codeThrottle = throttle{free: 5, base: time.Second, max: 15 * time.Minute, window: 24 * time.Hour}
+)
+
+// wait returns how long key has to wait before its next attempt is allowed.
+func (t throttle) wait(store AttemptStore, key string, now time.Time) (time.Duration, error) {
+	n, last, err := store.Failures(key)
+	if err != nil || n <= t.free || now.Sub(last) > t.window {
+		return 0, err
+	}
+	delay := t.max
+	if shift := uint(n - t.free - 1); shift < 32 {
+		if d := t.base << shift; d < t.max {
+			delay = d
+		}
+	}
+	return last.Add(delay).Sub(now), nil
+}
+
+func (t throttle) fail(store AttemptStore, key string, now time.Time) error {
+	return store.AddFailure(key, now, now.Add(-t.window))
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(email)
+}
+
+func addrKey(ip string) string {
+	return "ip:" + ip
+}
+
+func codeKey(userID string) string {
+	return "totp:" + userID
+}
+
+type attempt struct {
+	n    int
+	last time.Time
+}
+
+// NewMemoryAttemptStore returns an AttemptStore that keeps attempts in memory. It is only suitable for a single
+// instance of usersvc.
+func NewMemoryAttemptStore() AttemptStore {
+	return &memoryAttemptStore{attempts: make(map[string]attempt)}
+}
+
+type memoryAttemptStore struct {
+	mtx      sync.Mutex
+	attempts map[string]attempt
+	adds     int
+}
+
+func (m *memoryAttemptStore) Failures(key string) (int, time.Time, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	a := m.attempts[key]
+	return a.n, a.last, nil
+}
+
+func (m *memoryAttemptStore) AddFailure(key string, at, since time.Time) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	a := m.attempts[key]
+	if a.last.Before(since) {
+		a.n = 0
+	}
+	a.n++
+	a.last = at
+	m.attempts[key] = a
+	// Every so often, drop keys that have gone quiet so guessing random addresses can't grow the map forever.
+	if m.adds++; m.adds%1000 == 0 {
+		for k, v := range m.attempts {
+			if v.last.Before(since) {
+				delete(m.attempts, k)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *memoryAttemptStore) Reset(key string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.attempts, key)
+	return nil
+}
+
+// NewPostgresAttemptStore returns an AttemptStore backed by the login_attempts table, shared by all instances of
+// usersvc using the same database.
+func NewPostgresAttemptStore(db *sql.DB) AttemptStore {
+	return postgresAttemptStore{db}
+}
+
+type postgresAttemptStore struct {
+	*sql.DB
+}
+
+func (s postgresAttemptStore) Failures(key string) (n int, last time.Time, err error) {
+	err = s.QueryRow(`SELECT failures, last_failure FROM login_attempts WHERE key = $1`, key).Scan(&n, &last)
+	if err == sql.ErrNoRows {
+		return 0, time.Time{}, nil
+	}
+	return
+}
+
+func (s postgresAttemptStore) AddFailure(key string, at, since time.Time) error {
+	_, err := s.Exec(`INSERT INTO login_attempts (key, failures, last_failure) VALUES ($1, 1, $2)
+ON CONFLICT (key) DO UPDATE SET
+  failures     = CASE WHEN login_attempts.last_failure < $3 THEN 1 ELSE login_attempts.failures + 1 END,
+  last_failure = EXCLUDED.last_failure`, key, at, since)
+	return err
+}
+
+func (s postgresAttemptStore) Reset(key string) error {
+	_, err := s.Exec(`DELETE FROM login_attempts WHERE key = $1`, key)
+	return err
+}
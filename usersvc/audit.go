@@ -0,0 +1,257 @@
+package usersvc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ory/hydra/oauth2"
+	"github.com/studiously/introspector"
+	"github.com/studiously/svcerror"
+	"github.com/studiously/usersvc/models"
+)
+
+// The types of events in the audit log.
+const (
+	AuditUserCreated = "user.created"
+	// AuditLogin and AuditLoginFailed record each factor of a login separately, with the method in the metadata.
+	AuditLogin                    = "login.succeeded"
+	AuditLoginFailed              = "login.failed"
+	AuditPasswordChanged          = "password.changed"
+	AuditPasswordResetRequested   = "password.reset_requested"
+	AuditEmailChangeRequested     = "email.change_requested"
+	AuditEmailVerified            = "email.verified"
+	AuditTwoFactorEnabled         = "two_factor.enabled"
+	AuditTwoFactorDisabled        = "two_factor.disabled"
+	AuditRecoveryCodesRegenerated = "two_factor.recovery_codes_regenerated"
+	AuditPasskeyAdded             = "passkey.added"
+	AuditIdentityLinked           = "identity.linked"
+	AuditIdentityUnlinked         = "identity.unlinked"
+	AuditSessionRevoked           = "session.revoked"
+	AuditConsentGranted           = "consent.granted"
+	AuditConsentRevoked           = "consent.revoked"
+	// AuditStateChanged covers deletion, restoration, suspension and purging, with the states and reason in the
+	// metadata.
+	AuditStateChanged  = "state.changed"
+	AuditExportStarted = "export.started"
+)
+
+// AuditFilter narrows down and pages through the events returned by ListAuditEvents.
+type AuditFilter struct {
+	// UserID, if set, matches events the user either performed or was the subject of.
+	UserID *uuid.UUID
+	// Type, if set, matches only events of that type.
+	Type string
+	// Since and Until, if set, match only events at or after Since and before Until.
+	Since time.Time
+	Until time.Time
+	// Cursor continues a previous listing from where it left off.
+	Cursor string
+	// Limit is the most events to return. Defaults to 50, and cannot exceed 200.
+	Limit int
+}
+
+const auditColumns = `id, type, actor_id, subject_id, client_id, ip, user_agent, metadata, created_at, hash`
+
+// query returns the SQL and arguments listing up to limit events matching f, newest first.
+func (f AuditFilter) query(limit int) (string, []interface{}, error) {
+	var where []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if f.UserID != nil {
+		id := arg(*f.UserID)
+		where = append(where, "(actor_id = "+id+" OR subject_id = "+id+")")
+	}
+	if f.Type != "" {
+		where = append(where, "type = "+arg(f.Type))
+	}
+	if !f.Since.IsZero() {
+		where = append(where, "created_at >= "+arg(f.Since))
+	}
+	if !f.Until.IsZero() {
+		where = append(where, "created_at < "+arg(f.Until))
+	}
+	if f.Cursor != "" {
+		before, err := base64.RawURLEncoding.DecodeString(f.Cursor)
+		if err != nil {
+			return "", nil, ErrBadRequest
+		}
+		id, err := strconv.ParseInt(string(before), 10, 64)
+		if err != nil {
+			return "", nil, ErrBadRequest
+		}
+		where = append(where, "id < "+arg(id))
+	}
+	q := `SELECT ` + auditColumns + ` FROM audit_events`
+	if len(where) > 0 {
+		q += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	q += ` ORDER BY id DESC LIMIT ` + arg(limit)
+	return q, args, nil
+}
+
+// nextAuditPage trims a page fetched with one row more than limit, returning the cursor that continues after it, or
+// "" if there is nothing more.
+func nextAuditPage(page []*models.AuditEvent, limit int) ([]*models.AuditEvent, string) {
+	if len(page) <= limit {
+		return page, ""
+	}
+	page = page[:limit]
+	return page, base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(page[limit-1].ID, 10)))
+}
+
+func scanAuditEvents(rows *sql.Rows) ([]*models.AuditEvent, error) {
+	defer rows.Close()
+	events := []*models.AuditEvent{}
+	for rows.Next() {
+		var ae models.AuditEvent
+		if err := rows.Scan(&ae.ID, &ae.Type, &ae.ActorID, &ae.SubjectID, &ae.ClientID, &ae.IP, &ae.UserAgent,
+			&ae.Metadata, &ae.CreatedAt, &ae.Hash); err != nil {
+			return nil, err
+		}
+		events = append(events, &ae)
+	}
+	return events, rows.Err()
+}
+
+// audit records an event in a transaction of its own. The actor is whoever caused the event, if known, and the
+// subject is the user it happened to.
+func (s *postgresService) audit(ctx context.Context, typ string, actor, subject *uuid.UUID, metadata map[string]string) error {
+	return transact(ctx, s.DB, func(tx *sql.Tx) error {
+		return writeAudit(ctx, tx, typ, actor, subject, metadata)
+	})
+}
+
+// writeAudit records an event as part of tx, so that it is only recorded if what it describes happens. The client, IP
+// address and user agent are those of the request in ctx.
+func writeAudit(ctx context.Context, tx *sql.Tx, typ string, actor, subject *uuid.UUID, metadata map[string]string) error {
+	// Events are chained in the order they are written, so writers take turns holding the head of the chain until
+	// they commit.
+	var prevID int64
+	var prevHash string
+	err := tx.QueryRow(`SELECT id, hash FROM audit_chain_head FOR UPDATE`).Scan(&prevID, &prevHash)
+	if err != nil {
+		return err
+	}
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	md, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	ae := &models.AuditEvent{
+		ID:        prevID + 1,
+		Type:      typ,
+		ActorID:   actor,
+		SubjectID: subject,
+		ClientID:  clientID(ctx),
+		IP:        remoteAddr(ctx),
+		UserAgent: userAgent(ctx),
+		Metadata:  md,
+		// Postgres keeps microseconds, and the hash must match what is read back.
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	if ae.Hash, err = auditHash(prevHash, ae); err != nil {
+		return err
+	}
+	if err := ae.Insert(tx); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE audit_chain_head SET id = $1, hash = $2`, ae.ID, ae.Hash)
+	return err
+}
+
+// auditHash chains an event to the hash of the one before it.
+func auditHash(prev string, ae *models.AuditEvent) (string, error) {
+	// Postgres reformats JSONB, so the metadata is hashed as encoding/json would write it, with sorted keys.
+	var metadata map[string]string
+	if err := json.Unmarshal(ae.Metadata, &metadata); err != nil {
+		return "", err
+	}
+	id := func(u *uuid.UUID) string {
+		if u == nil {
+			return ""
+		}
+		return u.String()
+	}
+	// A JSON array keeps the fields apart however they are spelled.
+	b, err := json.Marshal([]interface{}{
+		prev, ae.ID, ae.Type, id(ae.ActorID), id(ae.SubjectID), ae.ClientID, ae.IP, ae.UserAgent, metadata,
+		ae.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// auditEmail stands in for an email address in audit metadata. Events outlive the users they are about, so addresses
+// aren't kept, but whoever holds the key can still tell which events concern a given one.
+func (s *postgresService) auditEmail(email string) string {
+	h := hmac.New(sha256.New, s.auditSecret)
+	h.Write([]byte(strings.ToLower(email)))
+	return "hmac-sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// auditLogin records how a login, or one factor of it, went. Failures are only recorded if they are the user's doing,
+// rather than ours. The user is nil if they couldn't be told apart.
+func (s *postgresService) auditLogin(ctx context.Context, method string, userID *uuid.UUID, metadata map[string]string, err error) error {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata["method"] = method
+	if err == nil {
+		return s.audit(ctx, AuditLogin, userID, userID, metadata)
+	}
+	if _, ok := err.(svcerror.Error); !ok {
+		return nil
+	}
+	metadata["error"] = err.Error()
+	return s.audit(ctx, AuditLoginFailed, nil, userID, metadata)
+}
+
+// exportAuditEvents returns the events a user was the subject of. Where someone else was behind the event, such as
+// an administrator, who they are and where they connected from are left out.
+func (s *postgresService) exportAuditEvents(ctx context.Context, userID uuid.UUID) (interface{}, error) {
+	rows, err := s.QueryContext(ctx, `SELECT `+auditColumns+` FROM audit_events WHERE subject_id = $1 ORDER BY id`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := scanAuditEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, ae := range events {
+		if ae.ActorID != nil && *ae.ActorID != userID {
+			ae.ActorID, ae.ClientID, ae.IP, ae.UserAgent = nil, "", "", ""
+		}
+		ae.Hash = ""
+	}
+	return events, nil
+}
+
+// clientID returns the OAuth2 client a request was made through, or "" if it wasn't made with an access token.
+func clientID(ctx context.Context) string {
+	in, _ := ctx.Value(introspector.OAuth2IntrospectionContextKey).(oauth2.Introspection)
+	return in.ClientID
+}
+
+func userAgent(ctx context.Context) string {
+	ua, _ := ctx.Value(UserAgentContextKey).(string)
+	return ua
+}
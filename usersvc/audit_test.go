@@ -0,0 +1,60 @@
+package usersvc
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAuditEmail(t *testing.T) {
+	s := New(nil, nil, ResetSecret([]byte("reset secret"))).(*postgresService)
+	got := s.auditEmail("Alice@Example.com")
+	if strings.Contains(strings.ToLower(got), "alice") {
+		t.Errorf("auditEmail kept the address: %s", got)
+	}
+	if s.auditEmail("alice@example.com") != got {
+		t.Error("auditEmail depends on the case of the address")
+	}
+	if s.auditEmail("bob@example.com") == got {
+		t.Error("auditEmail is the same for different addresses")
+	}
+	other := New(nil, nil, ResetSecret([]byte("other secret"))).(*postgresService)
+	if other.auditEmail("alice@example.com") == got {
+		t.Error("auditEmail doesn't depend on the key")
+	}
+}
+
+func TestAuditChain(t *testing.T) {
+	db := testDB(t)
+	s := New(db, nil).(*postgresService)
+	ctx := context.Background()
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- s.audit(ctx, AuditLogin, nil, nil, nil)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	brokenAt, err := s.VerifyAuditLog(ctx)
+	if err != nil || brokenAt != 0 {
+		t.Fatalf("VerifyAuditLog = %d, %v", brokenAt, err)
+	}
+	var head, count int64
+	if err := db.QueryRow(`SELECT id, (SELECT count(*) FROM audit_events) FROM audit_chain_head`).Scan(&head, &count); err != nil {
+		t.Fatal(err)
+	}
+	if head != writers || count != writers {
+		t.Errorf("chain head is at %d, with %d events, want %d", head, count, writers)
+	}
+}
@@ -0,0 +1,217 @@
+package usersvc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"io/ioutil"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxAvatarBytes caps the size of uploaded pictures.
+	maxAvatarBytes = 5 << 20
+	// maxAvatarPixels stops small files that decode to huge images from exhausting memory.
+	maxAvatarPixels = 24 << 20
+	avatarQuality   = 85
+)
+
+// avatarSizes are the widths, in pixels, of the square thumbnails made from each picture. Profiles link to the
+// first.
+var avatarSizes = []int{512, 256, 128, 64}
+
+// avatarTypes are the content types accepted for pictures, and the image formats they must decode as.
+var avatarTypes = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+func avatarKey(userID uuid.UUID, size int) string {
+	return fmt.Sprintf("avatars/%s/%d", userID, size)
+}
+
+// makeAvatars crops the middle square out of a picture and encodes it as a JPEG of each of avatarSizes. Re-encoding
+// leaves behind EXIF and any other metadata, such as where a photo was taken, though the orientation it records is
+// applied first.
+func makeAvatars(contentType string, r io.Reader) ([][]byte, error) {
+	format, ok := avatarTypes[contentType]
+	if !ok {
+		return nil, ErrInvalidAvatar
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxAvatarBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxAvatarBytes {
+		return nil, ErrAvatarTooLarge
+	}
+	config, decoded, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decoded != format {
+		return nil, ErrInvalidAvatar
+	}
+	if config.Width*config.Height > maxAvatarPixels {
+		return nil, ErrAvatarTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidAvatar
+	}
+	b := img.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+	if side == 0 {
+		return nil, ErrInvalidAvatar
+	}
+	// JPEG has no transparency, so flatten it onto white.
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(square, square.Bounds(), img, image.Pt(b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2), draw.Over)
+	avatars := make([][]byte, len(avatarSizes))
+	for i, size := range avatarSizes {
+		// Each size is made from the one before, which is both faster and no worse than starting over.
+		square = resize(square, size)
+		if i == 0 && format == "jpeg" {
+			square = orient(square, exifOrientation(data))
+		}
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, square, &jpeg.Options{Quality: avatarQuality}); err != nil {
+			return nil, err
+		}
+		avatars[i] = buf.Bytes()
+	}
+	return avatars, nil
+}
+
+// resize scales a square image to size by averaging the pixels that each pixel of the result covers.
+func resize(src *image.RGBA, size int) *image.RGBA {
+	n := src.Bounds().Dx()
+	if n == size {
+		return src
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := span(y, n, size)
+		for x := 0; x < size; x++ {
+			x0, x1 := span(x, n, size)
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				p := src.Pix[src.PixOffset(x0, sy):src.PixOffset(x1, sy)]
+				for i := 0; i < len(p); i += 4 {
+					sum[0] += int(p[i])
+					sum[1] += int(p[i+1])
+					sum[2] += int(p[i+2])
+					sum[3] += int(p[i+3])
+				}
+			}
+			count := (x1 - x0) * (y1 - y0)
+			d := dst.Pix[dst.PixOffset(x, y):]
+			for c := range sum {
+				d[c] = uint8(sum[c] / count)
+			}
+		}
+	}
+	return dst
+}
+
+// span returns the range of the n source pixels that pixel i of m covers.
+func span(i, n, m int) (int, int) {
+	lo, hi := i*n/m, (i+1)*n/m
+	if hi <= lo {
+		hi = lo + 1
+	}
+	return lo, hi
+}
+
+// orient transforms a square image as an EXIF orientation says it should be displayed.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	n := src.Bounds().Dx()
+	dst := image.NewRGBA(src.Bounds())
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			sx, sy := x, y
+			switch orientation {
+			case 2:
+				sx = n - 1 - x
+			case 3:
+				sx, sy = n-1-x, n-1-y
+			case 4:
+				sy = n - 1 - y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, n-1-x
+			case 7:
+				sx, sy = n-1-y, n-1-x
+			case 8:
+				sx, sy = n-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):][:4], src.Pix[src.PixOffset(sx, sy):])
+		}
+	}
+	return dst
+}
+
+// exifOrientation returns the orientation, from 1 to 8, recorded in a JPEG's EXIF data, or 1 if there is none.
+func exifOrientation(data []byte) int {
+	if len(data) < 2 || data[0] != 0xff || data[1] != 0xd8 {
+		return 1
+	}
+	b := data[2:]
+	// Metadata lives in the segments before the image data, which starts with the SOS marker.
+	for len(b) >= 4 && b[0] == 0xff && b[1] != 0xda {
+		n := int(binary.BigEndian.Uint16(b[2:]))
+		if n < 2 || len(b) < 2+n {
+			return 1
+		}
+		segment := b[4 : 2+n]
+		if b[1] == 0xe1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		b = b[2+n:]
+	}
+	return 1
+}
+
+// tiffOrientation finds the orientation tag in the first IFD of TIFF-encoded EXIF data.
+func tiffOrientation(t []byte) int {
+	if len(t) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(t[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := order.Uint32(t[4:])
+	if ifd > uint32(len(t)-2) {
+		return 1
+	}
+	entries := t[ifd+2:]
+	for i := 0; i < int(order.Uint16(t[ifd:])) && len(entries) >= 12; i++ {
+		if order.Uint16(entries) == 0x0112 {
+			if o := int(order.Uint16(entries[8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 1
+		}
+		entries = entries[12:]
+	}
+	return 1
+}
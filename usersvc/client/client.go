@@ -0,0 +1,506 @@
+// Package client provides a usersvc.Service that calls a remote instance of usersvc over HTTP.
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+	"github.com/studiously/introspector"
+	"github.com/studiously/usersvc/models"
+	"github.com/studiously/usersvc/oidc"
+	"github.com/studiously/usersvc/usersvc"
+	"github.com/studiously/usersvc/webauthn"
+)
+
+// ErrUnsupported is returned by the methods of usersvc.Service that only usersvc's own pages and background jobs
+// may call, such as Authenticate.
+var ErrUnsupported = errors.New("usersvc client: method is not available over HTTP")
+
+// Endpoints is a usersvc.Service that calls a remote instance of usersvc. Each call is made with the OAuth2 access
+// token in its context, which must carry the scope the method requires.
+type Endpoints struct {
+	GetProfileEndpoint  endpoint.Endpoint
+	GetProfilesEndpoint endpoint.Endpoint
+	SearchUsersEndpoint endpoint.Endpoint
+	GetUserInfoEndpoint endpoint.Endpoint
+	UpdateUserEndpoint  endpoint.Endpoint
+	DeleteUserEndpoint  endpoint.Endpoint
+	RestoreUserEndpoint endpoint.Endpoint
+
+	SetAvatarEndpoint    endpoint.Endpoint
+	DeleteAvatarEndpoint endpoint.Endpoint
+	GetAvatarEndpoint    endpoint.Endpoint
+
+	EnrollTOTPEndpoint              endpoint.Endpoint
+	EnableTOTPEndpoint              endpoint.Endpoint
+	DisableTOTPEndpoint             endpoint.Endpoint
+	RegenerateRecoveryCodesEndpoint endpoint.Endpoint
+
+	BeginPasskeyRegistrationEndpoint  endpoint.Endpoint
+	FinishPasskeyRegistrationEndpoint endpoint.Endpoint
+
+	ListIdentitiesEndpoint endpoint.Endpoint
+	UnlinkIdentityEndpoint endpoint.Endpoint
+
+	StartExportEndpoint    endpoint.Endpoint
+	GetExportEndpoint      endpoint.Endpoint
+	DownloadExportEndpoint endpoint.Endpoint
+
+	ListSessionsEndpoint  endpoint.Endpoint
+	RevokeSessionEndpoint endpoint.Endpoint
+
+	ListAppsEndpoint  endpoint.Endpoint
+	RevokeAppEndpoint endpoint.Endpoint
+
+	ListUsersEndpoint          endpoint.Endpoint
+	GetUserEndpoint            endpoint.Endpoint
+	ForcePasswordResetEndpoint endpoint.Endpoint
+	SetUserActiveEndpoint      endpoint.Endpoint
+	PurgeUserEndpoint          endpoint.Endpoint
+	ListStateChangesEndpoint   endpoint.Endpoint
+
+	ListAuditEventsEndpoint endpoint.Endpoint
+	VerifyAuditLogEndpoint  endpoint.Endpoint
+}
+
+// MakeClientEndpoints returns Endpoints that call the instance of usersvc at the given URL. The scheme defaults to
+// http.
+func MakeClientEndpoints(instance string) (Endpoints, error) {
+	if !strings.HasPrefix(instance, "http") {
+		instance = "http://" + instance
+	}
+	tgt, err := url.Parse(instance)
+	if err != nil {
+		return Endpoints{}, err
+	}
+	tgt.Path = ""
+
+	options := []httptransport.ClientOption{
+		httptransport.ClientBefore(tokenToHTTP),
+	}
+	newClient := func(method string, enc httptransport.EncodeRequestFunc, dec httptransport.DecodeResponseFunc) endpoint.Endpoint {
+		return httptransport.NewClient(method, tgt, enc, dec, options...).Endpoint()
+	}
+
+	return Endpoints{
+		GetProfileEndpoint:  newClient("GET", encodeGetProfileRequest, decodeResponse(func() interface{} { return new(usersvc.Profile) })),
+		GetProfilesEndpoint: newClient("POST", encodeGetProfilesRequest, decodeResponse(func() interface{} { return new(getProfilesResponse) })),
+		SearchUsersEndpoint: newClient("GET", encodeSearchUsersRequest, decodeResponse(func() interface{} { return new(searchUsersResponse) })),
+		GetUserInfoEndpoint: newClient("GET", encodePath("/userinfo"), decodeResponse(func() interface{} { return new(models.User) })),
+		UpdateUserEndpoint:  newClient("PATCH", encodeJSONRequest("/userinfo"), decodeEmptyResponse),
+		DeleteUserEndpoint:  newClient("DELETE", encodePath("/userinfo"), decodeEmptyResponse),
+		RestoreUserEndpoint: newClient("POST", encodePath("/userinfo/restore"), decodeEmptyResponse),
+
+		SetAvatarEndpoint:    newClient("PUT", encodeSetAvatarRequest, decodeResponse(func() interface{} { return new(setAvatarResponse) })),
+		DeleteAvatarEndpoint: newClient("DELETE", encodePath("/userinfo/avatar"), decodeEmptyResponse),
+		GetAvatarEndpoint:    newClient("GET", encodeGetAvatarRequest, decodeFileResponse),
+
+		EnrollTOTPEndpoint:              newClient("POST", encodePath("/userinfo/totp"), decodeResponse(func() interface{} { return new(enrollTOTPResponse) })),
+		EnableTOTPEndpoint:              newClient("POST", encodeJSONRequest("/userinfo/totp/enable"), decodeResponse(func() interface{} { return new(recoveryCodesResponse) })),
+		DisableTOTPEndpoint:             newClient("POST", encodeJSONRequest("/userinfo/totp/disable"), decodeEmptyResponse),
+		RegenerateRecoveryCodesEndpoint: newClient("POST", encodeJSONRequest("/userinfo/totp/recovery-codes"), decodeResponse(func() interface{} { return new(recoveryCodesResponse) })),
+
+		BeginPasskeyRegistrationEndpoint:  newClient("POST", encodePath("/userinfo/passkeys/options"), decodeResponse(func() interface{} { return new(beginPasskeyRegistrationResponse) })),
+		FinishPasskeyRegistrationEndpoint: newClient("POST", encodeJSONRequest("/userinfo/passkeys"), decodeEmptyResponse),
+
+		ListIdentitiesEndpoint: newClient("GET", encodePath("/userinfo/identities"), decodeResponse(func() interface{} { return new(listIdentitiesResponse) })),
+		UnlinkIdentityEndpoint: newClient("DELETE", encodeUnlinkIdentityRequest, decodeEmptyResponse),
+
+		StartExportEndpoint:    newClient("POST", encodePath("/userinfo/export"), decodeResponse(func() interface{} { return new(usersvc.Export) })),
+		GetExportEndpoint:      newClient("GET", encodeGetExportRequest, decodeResponse(func() interface{} { return new(usersvc.Export) })),
+		DownloadExportEndpoint: newClient("GET", encodeDownloadExportRequest, decodeFileResponse),
+
+		ListSessionsEndpoint:  newClient("GET", encodePath("/sessions"), decodeResponse(func() interface{} { return new(listSessionsResponse) })),
+		RevokeSessionEndpoint: newClient("DELETE", encodeRevokeSessionRequest, decodeEmptyResponse),
+
+		ListAppsEndpoint:  newClient("GET", encodePath("/apps"), decodeResponse(func() interface{} { return new(listAppsResponse) })),
+		RevokeAppEndpoint: newClient("DELETE", encodeRevokeAppRequest, decodeEmptyResponse),
+
+		ListUsersEndpoint:          newClient("GET", encodeListUsersRequest, decodeResponse(func() interface{} { return new(listUsersResponse) })),
+		GetUserEndpoint:            newClient("GET", encodeAdminUserRequest(""), decodeResponse(func() interface{} { return new(models.User) })),
+		ForcePasswordResetEndpoint: newClient("POST", encodeAdminUserRequest("/reset-password"), decodeEmptyResponse),
+		SetUserActiveEndpoint:      newClient("POST", encodeSetUserActiveRequest, decodeEmptyResponse),
+		PurgeUserEndpoint:          newClient("DELETE", encodeAdminUserRequest(""), decodeEmptyResponse),
+		ListStateChangesEndpoint:   newClient("GET", encodeAdminUserRequest("/state-changes"), decodeResponse(func() interface{} { return new(listStateChangesResponse) })),
+
+		ListAuditEventsEndpoint: newClient("GET", encodeListAuditEventsRequest, decodeResponse(func() interface{} { return new(listAuditEventsResponse) })),
+		VerifyAuditLogEndpoint:  newClient("GET", encodePath("/admin/audit-events:verify"), decodeResponse(func() interface{} { return new(verifyAuditLogResponse) })),
+	}, nil
+}
+
+// NewContext returns a context that makes calls with the given access token. Calls made while serving a request
+// already have the caller's token in their context, as placed there by introspector.ToHTTPContext.
+func NewContext(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, introspector.OAuth2TokenContextKey, token)
+}
+
+func (e Endpoints) GetProfile(ctx context.Context, userID uuid.UUID) (*usersvc.Profile, error) {
+	resp, err := e.GetProfileEndpoint(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*usersvc.Profile), nil
+}
+
+func (e Endpoints) GetProfiles(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*usersvc.Profile, []uuid.UUID, error) {
+	resp, err := e.GetProfilesEndpoint(ctx, getProfilesRequest{UserIDs: userIDs})
+	if err != nil {
+		return nil, nil, err
+	}
+	r := resp.(*getProfilesResponse)
+	return r.Profiles, r.Missing, nil
+}
+
+func (e Endpoints) SearchUsers(ctx context.Context, query string, limit int, cursor string) ([]*usersvc.Profile, string, error) {
+	resp, err := e.SearchUsersEndpoint(ctx, searchUsersRequest{Query: query, Limit: limit, Cursor: cursor})
+	if err != nil {
+		return nil, "", err
+	}
+	r := resp.(*searchUsersResponse)
+	return r.Profiles, r.Cursor, nil
+}
+
+func (e Endpoints) GetUserInfo(ctx context.Context) (*models.User, error) {
+	resp, err := e.GetUserInfoEndpoint(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*models.User), nil
+}
+
+// CreateUser is not available over HTTP, as users register through usersvc's own pages.
+func (e Endpoints) CreateUser(name, email, password string) error {
+	return ErrUnsupported
+}
+
+func (e Endpoints) SetName(ctx context.Context, name string) error {
+	_, err := e.UpdateUserEndpoint(ctx, updateUserRequest{Name: &name})
+	return err
+}
+
+func (e Endpoints) SetProfile(ctx context.Context, update usersvc.ProfileUpdate) error {
+	_, err := e.UpdateUserEndpoint(ctx, updateUserRequest{
+		GivenName:  update.GivenName,
+		FamilyName: update.FamilyName,
+		Pronouns:   update.Pronouns,
+		Locale:     update.Locale,
+		Zoneinfo:   update.Zoneinfo,
+	})
+	return err
+}
+
+func (e Endpoints) SetAvatar(ctx context.Context, contentType string, image io.Reader) (string, error) {
+	resp, err := e.SetAvatarEndpoint(ctx, setAvatarRequest{ContentType: contentType, Image: image})
+	if err != nil {
+		return "", err
+	}
+	return resp.(*setAvatarResponse).Picture, nil
+}
+
+func (e Endpoints) DeleteAvatar(ctx context.Context) error {
+	_, err := e.DeleteAvatarEndpoint(ctx, nil)
+	return err
+}
+
+func (e Endpoints) GetAvatar(ctx context.Context, userID uuid.UUID, size int) (io.ReadCloser, error) {
+	resp, err := e.GetAvatarEndpoint(ctx, getAvatarRequest{UserID: userID, Size: size})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(io.ReadCloser), nil
+}
+
+func (e Endpoints) SetEmail(ctx context.Context, email string) error {
+	_, err := e.UpdateUserEndpoint(ctx, updateUserRequest{Email: &email})
+	return err
+}
+
+func (e Endpoints) SetPassword(ctx context.Context, password string) error {
+	_, err := e.UpdateUserEndpoint(ctx, updateUserRequest{Password: &password})
+	return err
+}
+
+// Authenticate is not available over HTTP, as users log in through usersvc's own pages.
+func (e Endpoints) Authenticate(ctx context.Context, email string, password string) (uuid.UUID, error) {
+	return uuid.Nil, ErrUnsupported
+}
+
+func (e Endpoints) DeleteUser(ctx context.Context) error {
+	_, err := e.DeleteUserEndpoint(ctx, nil)
+	return err
+}
+
+func (e Endpoints) RestoreUser(ctx context.Context) error {
+	_, err := e.RestoreUserEndpoint(ctx, nil)
+	return err
+}
+
+// ResetPassword is not available over HTTP, as users reset their passwords through usersvc's own pages.
+func (e Endpoints) ResetPassword(ctx context.Context, email string) error {
+	return ErrUnsupported
+}
+
+// ConfirmPasswordReset is not available over HTTP, as users reset their passwords through usersvc's own pages.
+func (e Endpoints) ConfirmPasswordReset(ctx context.Context, token, password string) error {
+	return ErrUnsupported
+}
+
+// VerifyEmail is not available over HTTP, as verification links lead to usersvc's own pages.
+func (e Endpoints) VerifyEmail(ctx context.Context, token string) error {
+	return ErrUnsupported
+}
+
+func (e Endpoints) EnrollTOTP(ctx context.Context) (string, string, error) {
+	resp, err := e.EnrollTOTPEndpoint(ctx, nil)
+	if err != nil {
+		return "", "", err
+	}
+	r := resp.(*enrollTOTPResponse)
+	return r.Secret, r.URI, nil
+}
+
+func (e Endpoints) EnableTOTP(ctx context.Context, code string) ([]string, error) {
+	resp, err := e.EnableTOTPEndpoint(ctx, totpCodeRequest{Code: code})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*recoveryCodesResponse).RecoveryCodes, nil
+}
+
+func (e Endpoints) DisableTOTP(ctx context.Context, code string) error {
+	_, err := e.DisableTOTPEndpoint(ctx, totpCodeRequest{Code: code})
+	return err
+}
+
+func (e Endpoints) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	resp, err := e.RegenerateRecoveryCodesEndpoint(ctx, totpCodeRequest{Code: code})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*recoveryCodesResponse).RecoveryCodes, nil
+}
+
+// TwoFactorEnabled is not available over HTTP, as it is part of logging in through usersvc's own pages.
+func (e Endpoints) TwoFactorEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return false, ErrUnsupported
+}
+
+// VerifyTwoFactor is not available over HTTP, as it is part of logging in through usersvc's own pages.
+func (e Endpoints) VerifyTwoFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	return ErrUnsupported
+}
+
+func (e Endpoints) BeginPasskeyRegistration(ctx context.Context) (*webauthn.CreationOptions, error) {
+	resp, err := e.BeginPasskeyRegistrationEndpoint(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*beginPasskeyRegistrationResponse).PublicKey, nil
+}
+
+func (e Endpoints) FinishPasskeyRegistration(ctx context.Context, name string, response webauthn.AttestationResponse) error {
+	_, err := e.FinishPasskeyRegistrationEndpoint(ctx, finishPasskeyRegistrationRequest{Name: name, Credential: response})
+	return err
+}
+
+// BeginPasskeyLogin is not available over HTTP, as users log in through usersvc's own pages.
+func (e Endpoints) BeginPasskeyLogin(ctx context.Context) (*webauthn.RequestOptions, error) {
+	return nil, ErrUnsupported
+}
+
+// AuthenticatePasskey is not available over HTTP, as users log in through usersvc's own pages.
+func (e Endpoints) AuthenticatePasskey(ctx context.Context, response webauthn.AssertionResponse) (uuid.UUID, error) {
+	return uuid.Nil, ErrUnsupported
+}
+
+// IdentityProviders is not available over HTTP, and always returns nil.
+func (e Endpoints) IdentityProviders(ctx context.Context) []usersvc.IdentityProvider {
+	return nil
+}
+
+// BeginFederatedLogin is not available over HTTP, as users log in through usersvc's own pages.
+func (e Endpoints) BeginFederatedLogin(ctx context.Context, provider string) (*oidc.AuthRequest, error) {
+	return nil, ErrUnsupported
+}
+
+// FederatedLogin is not available over HTTP, as users log in through usersvc's own pages.
+func (e Endpoints) FederatedLogin(ctx context.Context, provider string, req oidc.AuthRequest, code string) (uuid.UUID, error) {
+	return uuid.Nil, ErrUnsupported
+}
+
+// LinkIdentity is not available over HTTP, as users link accounts through usersvc's own pages.
+func (e Endpoints) LinkIdentity(ctx context.Context, userID uuid.UUID, provider string, req oidc.AuthRequest, code string) error {
+	return ErrUnsupported
+}
+
+func (e Endpoints) ListIdentities(ctx context.Context) ([]*models.FederatedIdentity, error) {
+	resp, err := e.ListIdentitiesEndpoint(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*listIdentitiesResponse).Identities, nil
+}
+
+func (e Endpoints) UnlinkIdentity(ctx context.Context, provider string) error {
+	_, err := e.UnlinkIdentityEndpoint(ctx, unlinkIdentityRequest{Provider: provider})
+	return err
+}
+
+func (e Endpoints) StartExport(ctx context.Context) (*usersvc.Export, error) {
+	resp, err := e.StartExportEndpoint(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*usersvc.Export), nil
+}
+
+func (e Endpoints) GetExport(ctx context.Context, exportID uuid.UUID) (*usersvc.Export, error) {
+	resp, err := e.GetExportEndpoint(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*usersvc.Export), nil
+}
+
+func (e Endpoints) DownloadExport(ctx context.Context, exportID uuid.UUID, token string) (io.ReadCloser, error) {
+	resp, err := e.DownloadExportEndpoint(ctx, downloadExportRequest{ExportID: exportID, Token: token})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(io.ReadCloser), nil
+}
+
+// CreateSession is not available over HTTP, as users log in through usersvc's own pages.
+func (e Endpoints) CreateSession(ctx context.Context, userID uuid.UUID, remember bool) (string, time.Time, error) {
+	return "", time.Time{}, ErrUnsupported
+}
+
+// CheckSession is not available over HTTP, as sessions are only used by usersvc's own pages.
+func (e Endpoints) CheckSession(ctx context.Context, token string) (uuid.UUID, time.Time, error) {
+	return uuid.Nil, time.Time{}, ErrUnsupported
+}
+
+// EndSession is not available over HTTP, as users log out through usersvc's own pages.
+func (e Endpoints) EndSession(ctx context.Context, token string) error {
+	return ErrUnsupported
+}
+
+// AddSessionClient is not available over HTTP, as sessions are only known to usersvc's own pages.
+func (e Endpoints) AddSessionClient(ctx context.Context, token, clientID string, scopes []string) (uuid.UUID, error) {
+	return uuid.Nil, ErrUnsupported
+}
+
+// LogOut is not available over HTTP, as users log out through usersvc's own pages.
+func (e Endpoints) LogOut(ctx context.Context, token string) error {
+	return ErrUnsupported
+}
+
+// CheckLogoutRedirect is not available over HTTP, as it is only needed by usersvc's own logout page.
+func (e Endpoints) CheckLogoutRedirect(ctx context.Context, clientID, redirectURI string) error {
+	return ErrUnsupported
+}
+
+// RememberedScopes is not available over HTTP, as it is only needed by usersvc's own consent page.
+func (e Endpoints) RememberedScopes(ctx context.Context, userID uuid.UUID, clientID string) ([]string, error) {
+	return nil, ErrUnsupported
+}
+
+// RememberConsent is not available over HTTP, as users consent through usersvc's own pages.
+func (e Endpoints) RememberConsent(ctx context.Context, userID uuid.UUID, clientID string, scopes []string) error {
+	return ErrUnsupported
+}
+
+func (e Endpoints) ListApps(ctx context.Context) ([]*usersvc.App, error) {
+	resp, err := e.ListAppsEndpoint(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*listAppsResponse).Apps, nil
+}
+
+func (e Endpoints) RevokeApp(ctx context.Context, clientID string) error {
+	_, err := e.RevokeAppEndpoint(ctx, clientID)
+	return err
+}
+
+func (e Endpoints) ListSessions(ctx context.Context) ([]*usersvc.Session, error) {
+	resp, err := e.ListSessionsEndpoint(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*listSessionsResponse).Sessions, nil
+}
+
+func (e Endpoints) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	_, err := e.RevokeSessionEndpoint(ctx, sessionID)
+	return err
+}
+
+func (e Endpoints) ListUsers(ctx context.Context, filter usersvc.UserFilter) ([]*models.User, string, error) {
+	resp, err := e.ListUsersEndpoint(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	r := resp.(*listUsersResponse)
+	return r.Users, r.Cursor, nil
+}
+
+func (e Endpoints) GetUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	resp, err := e.GetUserEndpoint(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*models.User), nil
+}
+
+func (e Endpoints) ForcePasswordReset(ctx context.Context, userID uuid.UUID) error {
+	_, err := e.ForcePasswordResetEndpoint(ctx, userID)
+	return err
+}
+
+func (e Endpoints) SetUserActive(ctx context.Context, userID uuid.UUID, active bool, reason string) error {
+	_, err := e.SetUserActiveEndpoint(ctx, setUserActiveRequest{UserID: userID, Active: active, Reason: reason})
+	return err
+}
+
+func (e Endpoints) PurgeUser(ctx context.Context, userID uuid.UUID) error {
+	_, err := e.PurgeUserEndpoint(ctx, userID)
+	return err
+}
+
+func (e Endpoints) ListStateChanges(ctx context.Context, userID uuid.UUID) ([]*models.UserStateChange, error) {
+	resp, err := e.ListStateChangesEndpoint(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*listStateChangesResponse).Changes, nil
+}
+
+func (e Endpoints) ListAuditEvents(ctx context.Context, filter usersvc.AuditFilter) ([]*models.AuditEvent, string, error) {
+	resp, err := e.ListAuditEventsEndpoint(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	r := resp.(*listAuditEventsResponse)
+	return r.Events, r.Cursor, nil
+}
+
+func (e Endpoints) VerifyAuditLog(ctx context.Context) (int64, error) {
+	resp, err := e.VerifyAuditLogEndpoint(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return resp.(*verifyAuditLogResponse).BrokenAt, nil
+}
+
+// PurgeDeletedUsers is not available over HTTP, as each instance of usersvc purges deleted users itself.
+func (e Endpoints) PurgeDeletedUsers(ctx context.Context) ([]uuid.UUID, error) {
+	return nil, ErrUnsupported
+}
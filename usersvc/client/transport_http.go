@@ -0,0 +1,406 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"time"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+	"github.com/studiously/introspector"
+	"github.com/studiously/svcerror"
+	"github.com/studiously/usersvc/models"
+	"github.com/studiously/usersvc/usersvc"
+	"github.com/studiously/usersvc/webauthn"
+)
+
+// knownErrors are the errors usersvc may respond with. Errors are decoded as whichever of these has the same code and
+// message, so that callers can compare them with usersvc.ErrNotFound and the like.
+var knownErrors = []svcerror.Error{
+	usersvc.ErrInternal,
+	usersvc.ErrBadRouting,
+	usersvc.ErrBadRequest,
+	usersvc.ErrHashFailed,
+	usersvc.ErrUserExists,
+	usersvc.ErrWrongEmail,
+	usersvc.ErrWrongPassword,
+	usersvc.ErrNotFound,
+	usersvc.ErrDeleteOwner,
+	usersvc.ErrInvalidToken,
+	usersvc.ErrEmailInUse,
+	usersvc.ErrTooManyAttempts,
+	usersvc.ErrWrongCode,
+	usersvc.ErrTwoFactorEnabled,
+	usersvc.ErrInvalidCredential,
+	usersvc.ErrIdentityInUse,
+	usersvc.ErrLastIdentity,
+	usersvc.ErrLinkRequired,
+	usersvc.ErrEmailRequired,
+	usersvc.ErrInvalidLocale,
+	usersvc.ErrInvalidZoneinfo,
+	usersvc.ErrInvalidAvatar,
+	usersvc.ErrAvatarTooLarge,
+	usersvc.ErrQueryTooShort,
+	usersvc.ErrEmailNotVerified,
+	usersvc.ErrAccountSuspended,
+	usersvc.ErrPendingDeletion,
+	usersvc.ErrInvalidTransition,
+}
+
+type getProfilesRequest struct {
+	UserIDs []uuid.UUID `json:"ids"`
+}
+
+type getProfilesResponse struct {
+	Profiles map[uuid.UUID]*usersvc.Profile `json:"profiles"`
+	Missing  []uuid.UUID                    `json:"missing"`
+}
+
+type searchUsersRequest struct {
+	Query  string
+	Limit  int
+	Cursor string
+}
+
+type searchUsersResponse struct {
+	Profiles []*usersvc.Profile `json:"profiles"`
+	Cursor   string             `json:"cursor"`
+}
+
+type updateUserRequest struct {
+	Name       *string `json:"name,omitempty"`
+	Email      *string `json:"email,omitempty"`
+	Password   *string `json:"password,omitempty"`
+	GivenName  *string `json:"given_name,omitempty"`
+	FamilyName *string `json:"family_name,omitempty"`
+	Pronouns   *string `json:"pronouns,omitempty"`
+	Locale     *string `json:"locale,omitempty"`
+	Zoneinfo   *string `json:"zoneinfo,omitempty"`
+}
+
+type setAvatarRequest struct {
+	ContentType string
+	Image       io.Reader
+}
+
+type setAvatarResponse struct {
+	Picture string `json:"picture"`
+}
+
+type getAvatarRequest struct {
+	UserID uuid.UUID
+	Size   int
+}
+
+type enrollTOTPResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type totpCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type beginPasskeyRegistrationResponse struct {
+	PublicKey *webauthn.CreationOptions `json:"publicKey"`
+}
+
+type finishPasskeyRegistrationRequest struct {
+	Name       string                       `json:"name"`
+	Credential webauthn.AttestationResponse `json:"credential"`
+}
+
+type listIdentitiesResponse struct {
+	Identities []*models.FederatedIdentity `json:"identities"`
+}
+
+type unlinkIdentityRequest struct {
+	Provider string
+}
+
+type downloadExportRequest struct {
+	ExportID uuid.UUID
+	Token    string
+}
+
+type listSessionsResponse struct {
+	Sessions []*usersvc.Session `json:"sessions"`
+}
+
+type listAppsResponse struct {
+	Apps []*usersvc.App `json:"apps"`
+}
+
+type listUsersResponse struct {
+	Users  []*models.User `json:"users"`
+	Cursor string         `json:"cursor"`
+}
+
+type setUserActiveRequest struct {
+	UserID uuid.UUID `json:"-"`
+	Active bool      `json:"-"`
+	Reason string    `json:"reason"`
+}
+
+type listStateChangesResponse struct {
+	Changes []*models.UserStateChange `json:"changes"`
+}
+
+type listAuditEventsResponse struct {
+	Events []*models.AuditEvent `json:"events"`
+	Cursor string               `json:"cursor"`
+}
+
+type verifyAuditLogResponse struct {
+	Intact   bool  `json:"intact"`
+	BrokenAt int64 `json:"broken_at"`
+}
+
+// tokenToHTTP sends the access token in the context as a bearer token.
+func tokenToHTTP(ctx context.Context, r *http.Request) context.Context {
+	if token, ok := ctx.Value(introspector.OAuth2TokenContextKey).(string); ok && token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	return ctx
+}
+
+// encodePath returns an encoder for requests that have no body.
+func encodePath(path string) httptransport.EncodeRequestFunc {
+	return func(_ context.Context, r *http.Request, _ interface{}) error {
+		r.URL.Path = path
+		return nil
+	}
+}
+
+// encodeJSONRequest returns an encoder for requests sent as JSON.
+func encodeJSONRequest(path string) httptransport.EncodeRequestFunc {
+	return func(_ context.Context, r *http.Request, request interface{}) error {
+		r.URL.Path = path
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(request); err != nil {
+			return err
+		}
+		r.Header.Set("Content-Type", "application/json; charset=utf-8")
+		r.ContentLength = int64(buf.Len())
+		r.Body = ioutil.NopCloser(&buf)
+		return nil
+	}
+}
+
+func encodeGetProfileRequest(_ context.Context, r *http.Request, request interface{}) error {
+	r.URL.Path = "/users/" + request.(uuid.UUID).String()
+	return nil
+}
+
+func encodeGetProfilesRequest(ctx context.Context, r *http.Request, request interface{}) error {
+	return encodeJSONRequest("/users:batchGet")(ctx, r, request)
+}
+
+func encodeSearchUsersRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(searchUsersRequest)
+	r.URL.Path = "/users:search"
+	q := url.Values{"q": {req.Query}}
+	if req.Limit != 0 {
+		q.Set("limit", strconv.Itoa(req.Limit))
+	}
+	if req.Cursor != "" {
+		q.Set("cursor", req.Cursor)
+	}
+	r.URL.RawQuery = q.Encode()
+	return nil
+}
+
+// encodeSetAvatarRequest sends the picture as the "avatar" field of a multipart form.
+func encodeSetAvatarRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(setAvatarRequest)
+	r.URL.Path = "/userinfo/avatar"
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", `form-data; name="avatar"; filename="avatar"`)
+	h.Set("Content-Type", req.ContentType)
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, req.Image); err != nil {
+		return err
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	r.ContentLength = int64(buf.Len())
+	r.Body = ioutil.NopCloser(&buf)
+	return nil
+}
+
+func encodeGetAvatarRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(getAvatarRequest)
+	r.URL.Path = fmt.Sprintf("/avatars/%s/%d", req.UserID, req.Size)
+	return nil
+}
+
+func encodeUnlinkIdentityRequest(_ context.Context, r *http.Request, request interface{}) error {
+	r.URL.Path = "/userinfo/identities/" + request.(unlinkIdentityRequest).Provider
+	return nil
+}
+
+func encodeGetExportRequest(_ context.Context, r *http.Request, request interface{}) error {
+	r.URL.Path = "/userinfo/export/" + request.(uuid.UUID).String()
+	return nil
+}
+
+func encodeDownloadExportRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(downloadExportRequest)
+	r.URL.Path = "/exports/" + req.ExportID.String()
+	r.URL.RawQuery = url.Values{"token": {req.Token}}.Encode()
+	return nil
+}
+
+func encodeRevokeSessionRequest(_ context.Context, r *http.Request, request interface{}) error {
+	r.URL.Path = "/sessions/" + request.(uuid.UUID).String()
+	return nil
+}
+
+func encodeRevokeAppRequest(_ context.Context, r *http.Request, request interface{}) error {
+	r.URL.Path = "/apps/" + request.(string)
+	return nil
+}
+
+func encodeListUsersRequest(_ context.Context, r *http.Request, request interface{}) error {
+	filter := request.(usersvc.UserFilter)
+	r.URL.Path = "/admin/users"
+	q := url.Values{}
+	if filter.Email != "" {
+		q.Set("email", filter.Email)
+	}
+	if filter.Name != "" {
+		q.Set("name", filter.Name)
+	}
+	if filter.Active != nil {
+		q.Set("active", strconv.FormatBool(*filter.Active))
+	}
+	if filter.State != "" {
+		q.Set("state", filter.State)
+	}
+	if filter.Limit != 0 {
+		q.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.Cursor != "" {
+		q.Set("cursor", filter.Cursor)
+	}
+	r.URL.RawQuery = q.Encode()
+	return nil
+}
+
+func encodeListAuditEventsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	filter := request.(usersvc.AuditFilter)
+	r.URL.Path = "/admin/audit-events"
+	q := url.Values{}
+	if filter.UserID != nil {
+		q.Set("user", filter.UserID.String())
+	}
+	if filter.Type != "" {
+		q.Set("type", filter.Type)
+	}
+	if !filter.Since.IsZero() {
+		q.Set("since", filter.Since.Format(time.RFC3339))
+	}
+	if !filter.Until.IsZero() {
+		q.Set("until", filter.Until.Format(time.RFC3339))
+	}
+	if filter.Limit != 0 {
+		q.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.Cursor != "" {
+		q.Set("cursor", filter.Cursor)
+	}
+	r.URL.RawQuery = q.Encode()
+	return nil
+}
+
+// encodeAdminUserRequest returns an encoder for requests about the user with the ID given as the request, at the
+// given path below theirs.
+func encodeAdminUserRequest(suffix string) httptransport.EncodeRequestFunc {
+	return func(_ context.Context, r *http.Request, request interface{}) error {
+		r.URL.Path = "/admin/users/" + request.(uuid.UUID).String() + suffix
+		return nil
+	}
+}
+
+func encodeSetUserActiveRequest(ctx context.Context, r *http.Request, request interface{}) error {
+	req := request.(setUserActiveRequest)
+	action := "/deactivate"
+	if req.Active {
+		action = "/reactivate"
+	}
+	return encodeJSONRequest("/admin/users/"+req.UserID.String()+action)(ctx, r, req)
+}
+
+// decodeResponse returns a decoder for JSON responses, which decodes successful ones into the value returned by into.
+func decodeResponse(into func() interface{}) httptransport.DecodeResponseFunc {
+	return func(_ context.Context, r *http.Response) (interface{}, error) {
+		if r.StatusCode >= 400 {
+			return nil, decodeError(r)
+		}
+		response := into()
+		if err := json.NewDecoder(r.Body).Decode(response); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+func decodeEmptyResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode >= 400 {
+		return nil, decodeError(r)
+	}
+	return nil, nil
+}
+
+// decodeFileResponse returns a picture or export in memory, as the response body is closed once it is decoded.
+func decodeFileResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode == http.StatusNotFound {
+		return nil, usersvc.ErrNotFound
+	}
+	if r.StatusCode >= 400 {
+		return nil, fmt.Errorf("usersvc client: %s", r.Status)
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+// decodeError turns the error_code and error that usersvc responds with back into the error it was made from.
+func decodeError(r *http.Response) error {
+	var body struct {
+		Code    *int   `json:"error_code"`
+		Message string `json:"error"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Code == nil {
+		return errors.New("usersvc client: " + r.Status)
+	}
+	for _, e := range knownErrors {
+		if e.Status() == *body.Code && e.Error() == body.Message {
+			return e
+		}
+	}
+	return svcerror.New(*body.Code, body.Message)
+}
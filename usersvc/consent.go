@@ -0,0 +1,117 @@
+package usersvc
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// App is a client a user has granted access to, as shown to the user.
+type App struct {
+	ClientID string `json:"client_id"`
+	// Name, ClientURI and LogoURI are as the client registered them with Hydra, if it did.
+	Name      string `json:"name,omitempty"`
+	ClientURI string `json:"client_uri,omitempty"`
+	LogoURI   string `json:"logo_uri,omitempty"`
+	// Scopes are every scope the user has granted the client, which they aren't asked to consent to again.
+	Scopes    []string  `json:"scopes"`
+	GrantedAt time.Time `json:"granted_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// splitScopes splits a space-separated list of scopes, as consent_grants keeps them.
+func splitScopes(scopes string) []string {
+	return strings.Fields(scopes)
+}
+
+// joinScopes returns the scopes in both lists, sorted and space-separated.
+func joinScopes(a, b []string) string {
+	set := make(map[string]bool)
+	for _, scopes := range [][]string{a, b} {
+		for _, scope := range scopes {
+			if scope != "" {
+				set[scope] = true
+			}
+		}
+	}
+	merged := make([]string, 0, len(set))
+	for scope := range set {
+		merged = append(merged, scope)
+	}
+	sort.Strings(merged)
+	return strings.Join(merged, " ")
+}
+
+// grantsAll reports whether every scope in requested is in granted.
+func grantsAll(granted, requested []string) bool {
+	set := make(map[string]bool, len(granted))
+	for _, scope := range granted {
+		set[scope] = true
+	}
+	for _, scope := range requested {
+		if !set[scope] {
+			return false
+		}
+	}
+	return true
+}
+
+// consentGrants returns the clients a user has granted access to, most recently granted first, without the details
+// Hydra has of them.
+func (s *postgresService) consentGrants(ctx context.Context, userID uuid.UUID) ([]*App, error) {
+	rows, err := s.QueryContext(ctx, `SELECT client_id, scopes, created_at, updated_at FROM consent_grants `+
+		`WHERE user_id = $1 ORDER BY updated_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	apps := []*App{}
+	for rows.Next() {
+		var app App
+		var scopes string
+		if err := rows.Scan(&app.ClientID, &scopes, &app.GrantedAt, &app.UpdatedAt); err != nil {
+			return nil, err
+		}
+		app.Scopes = splitScopes(scopes)
+		apps = append(apps, &app)
+	}
+	return apps, rows.Err()
+}
+
+// describeApps fills in what Hydra has of clients, with a single request. Clients that have since been deleted are
+// left as they are, and so are all of them if Hydra can't be reached, as what is stored is still worth showing.
+func (s *postgresService) describeApps(ctx context.Context, apps []*App) {
+	if s.hydra == nil || len(apps) == 0 {
+		return
+	}
+	clients, err := s.hydra.ListClients(ctx)
+	if err != nil {
+		return
+	}
+	for _, app := range apps {
+		if c, ok := clients[app.ClientID]; ok {
+			app.Name, app.ClientURI, app.LogoURI = c.Name, c.ClientURI, c.LogoURI
+		}
+	}
+}
+
+// rememberConsent adds scopes to those a user has granted a client, as part of tx. Scopes are merged by the upsert
+// itself, so that consenting from two browsers at once keeps both.
+func rememberConsent(tx *sql.Tx, userID uuid.UUID, clientID string, scopes []string) error {
+	_, err := tx.Exec(`INSERT INTO consent_grants (id, user_id, client_id, scopes, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $5)
+ON CONFLICT (user_id, client_id) DO UPDATE SET
+  scopes     = COALESCE((SELECT string_agg(DISTINCT scope COLLATE "C", ' ' ORDER BY scope COLLATE "C")
+    FROM unnest(string_to_array(consent_grants.scopes || ' ' || EXCLUDED.scopes, ' ')) AS scope
+    WHERE scope <> ''), ''),
+  updated_at = EXCLUDED.updated_at`, uuid.New(), userID, clientID, joinScopes(nil, scopes), time.Now())
+	return err
+}
+
+func (s *postgresService) exportApps(ctx context.Context, userID uuid.UUID) (interface{}, error) {
+	return s.consentGrants(ctx, userID)
+}
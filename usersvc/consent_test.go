@@ -0,0 +1,118 @@
+package usersvc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/studiously/introspector"
+	"github.com/studiously/usersvc/hydra"
+	"github.com/studiously/usersvc/hydra/hydratest"
+)
+
+func TestRememberConsentConcurrently(t *testing.T) {
+	db := testDB(t)
+	s := New(db, nil)
+	u := testUser(t, db, "alice@example.com")
+	ctx := context.Background()
+	want := []string{"openid"}
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		scope := fmt.Sprintf("scope%d", i)
+		want = append(want, scope)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- s.RememberConsent(ctx, u.ID, "app", []string{"openid", scope})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("RememberConsent: %v", err)
+		}
+	}
+	sort.Strings(want)
+	scopes, err := s.RememberedScopes(ctx, u.ID, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(scopes, want) {
+		t.Errorf("RememberedScopes = %v, want %v", scopes, want)
+	}
+}
+
+func TestListAppsWithoutHydra(t *testing.T) {
+	db := testDB(t)
+	srv := hydratest.NewServer()
+	defer srv.Close()
+	srv.Clients["app"] = &hydra.OAuth2Client{ID: "app", Name: "App"}
+	s := New(db, nil, Hydra(srv.Admin()))
+	u := testUser(t, db, "alice@example.com")
+	ctx := context.WithValue(context.Background(), introspector.SubjectContextKey, u.ID)
+	for _, clientID := range []string{"app", "deleted"} {
+		if err := s.RememberConsent(ctx, u.ID, clientID, []string{"openid"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	names := func() map[string]string {
+		apps, err := s.ListApps(ctx)
+		if err != nil {
+			t.Fatalf("ListApps: %v", err)
+		}
+		names := make(map[string]string)
+		for _, app := range apps {
+			names[app.ClientID] = app.Name
+		}
+		return names
+	}
+	if got, want := names(), map[string]string{"app": "App", "deleted": ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListApps names = %v, want %v", got, want)
+	}
+	srv.Close()
+	if got, want := names(), map[string]string{"app": "", "deleted": ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListApps names with Hydra down = %v, want %v", got, want)
+	}
+}
+
+func TestRevokeApp(t *testing.T) {
+	db := testDB(t)
+	srv := hydratest.NewServer()
+	defer srv.Close()
+	s := New(db, nil, Hydra(srv.Admin()))
+	u := testUser(t, db, "alice@example.com")
+	ctx := context.WithValue(context.Background(), introspector.SubjectContextKey, u.ID)
+	if err := s.RememberConsent(ctx, u.ID, "app", []string{"openid"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RevokeApp(ctx, "app"); err != nil {
+		t.Fatalf("RevokeApp: %v", err)
+	}
+	want := []hydratest.Revocation{{Subject: u.ID.String(), Client: "app"}}
+	if revoked := srv.Revocations(); !reflect.DeepEqual(revoked, want) {
+		t.Errorf("revoked %v, want %v", revoked, want)
+	}
+	if scopes, err := s.RememberedScopes(ctx, u.ID, "app"); err != nil || len(scopes) != 0 {
+		t.Errorf("RememberedScopes = %v, %v, want none", scopes, err)
+	}
+	if err := s.RevokeApp(ctx, "app"); err != ErrNotFound {
+		t.Errorf("RevokeApp again = %v, want ErrNotFound", err)
+	}
+
+	// Hydra failing is reported, but the grant is forgotten all the same.
+	if err := s.RememberConsent(ctx, u.ID, "app", []string{"openid"}); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+	if err := s.RevokeApp(ctx, "app"); err == nil {
+		t.Error("RevokeApp hid Hydra's failure")
+	}
+	if scopes, err := s.RememberedScopes(ctx, u.ID, "app"); err != nil || len(scopes) != 0 {
+		t.Errorf("RememberedScopes = %v, %v, want none", scopes, err)
+	}
+}
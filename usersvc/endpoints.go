@@ -2,25 +2,99 @@ package usersvc
 
 import (
 	"context"
+	"io"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/google/uuid"
 	"github.com/studiously/usersvc/models"
+	"github.com/studiously/usersvc/webauthn"
 )
 
 type Endpoints struct {
 	GetUserInfoEndpoint endpoint.Endpoint
 	GetProfileEndpoint  endpoint.Endpoint
+	GetProfilesEndpoint endpoint.Endpoint
+	SearchUsersEndpoint endpoint.Endpoint
 	UpdateUserEndpoint  endpoint.Endpoint
 	DeleteUserEndpoint  endpoint.Endpoint
+	RestoreUserEndpoint endpoint.Endpoint
+
+	SetAvatarEndpoint    endpoint.Endpoint
+	DeleteAvatarEndpoint endpoint.Endpoint
+
+	EnrollTOTPEndpoint              endpoint.Endpoint
+	EnableTOTPEndpoint              endpoint.Endpoint
+	DisableTOTPEndpoint             endpoint.Endpoint
+	RegenerateRecoveryCodesEndpoint endpoint.Endpoint
+
+	BeginPasskeyRegistrationEndpoint  endpoint.Endpoint
+	FinishPasskeyRegistrationEndpoint endpoint.Endpoint
+
+	ListIdentitiesEndpoint endpoint.Endpoint
+	UnlinkIdentityEndpoint endpoint.Endpoint
+
+	StartExportEndpoint endpoint.Endpoint
+	GetExportEndpoint   endpoint.Endpoint
+
+	ListSessionsEndpoint  endpoint.Endpoint
+	RevokeSessionEndpoint endpoint.Endpoint
+
+	ListAppsEndpoint  endpoint.Endpoint
+	RevokeAppEndpoint endpoint.Endpoint
+
+	ListUsersEndpoint          endpoint.Endpoint
+	GetUserEndpoint            endpoint.Endpoint
+	ForcePasswordResetEndpoint endpoint.Endpoint
+	SetUserActiveEndpoint      endpoint.Endpoint
+	PurgeUserEndpoint          endpoint.Endpoint
+	ListStateChangesEndpoint   endpoint.Endpoint
+
+	ListAuditEventsEndpoint endpoint.Endpoint
+	VerifyAuditLogEndpoint  endpoint.Endpoint
 }
 
 func MakeServerEndpoints(s Service) Endpoints {
 	return Endpoints{
 		GetUserInfoEndpoint: MakeGetUserInfoEndpoint(s),
 		GetProfileEndpoint:  MakeGetProfileEndpoint(s),
+		GetProfilesEndpoint: MakeGetProfilesEndpoint(s),
+		SearchUsersEndpoint: MakeSearchUsersEndpoint(s),
 		UpdateUserEndpoint:  MakeUpdateUserEndpoint(s),
 		DeleteUserEndpoint:  MakeDeleteUserEndpoint(s),
+		RestoreUserEndpoint: MakeRestoreUserEndpoint(s),
+
+		SetAvatarEndpoint:    MakeSetAvatarEndpoint(s),
+		DeleteAvatarEndpoint: MakeDeleteAvatarEndpoint(s),
+
+		EnrollTOTPEndpoint:              MakeEnrollTOTPEndpoint(s),
+		EnableTOTPEndpoint:              MakeEnableTOTPEndpoint(s),
+		DisableTOTPEndpoint:             MakeDisableTOTPEndpoint(s),
+		RegenerateRecoveryCodesEndpoint: MakeRegenerateRecoveryCodesEndpoint(s),
+
+		BeginPasskeyRegistrationEndpoint:  MakeBeginPasskeyRegistrationEndpoint(s),
+		FinishPasskeyRegistrationEndpoint: MakeFinishPasskeyRegistrationEndpoint(s),
+
+		ListIdentitiesEndpoint: MakeListIdentitiesEndpoint(s),
+		UnlinkIdentityEndpoint: MakeUnlinkIdentityEndpoint(s),
+
+		StartExportEndpoint: MakeStartExportEndpoint(s),
+		GetExportEndpoint:   MakeGetExportEndpoint(s),
+
+		ListSessionsEndpoint:  MakeListSessionsEndpoint(s),
+		RevokeSessionEndpoint: MakeRevokeSessionEndpoint(s),
+
+		ListAppsEndpoint:  MakeListAppsEndpoint(s),
+		RevokeAppEndpoint: MakeRevokeAppEndpoint(s),
+
+		ListUsersEndpoint:          MakeListUsersEndpoint(s),
+		GetUserEndpoint:            MakeGetUserEndpoint(s),
+		ForcePasswordResetEndpoint: MakeForcePasswordResetEndpoint(s),
+		SetUserActiveEndpoint:      MakeSetUserActiveEndpoint(s),
+		PurgeUserEndpoint:          MakePurgeUserEndpoint(s),
+		ListStateChangesEndpoint:   MakeListStateChangesEndpoint(s),
+
+		ListAuditEventsEndpoint: MakeListAuditEventsEndpoint(s),
+		VerifyAuditLogEndpoint:  MakeVerifyAuditLogEndpoint(s),
 	}
 }
 
@@ -34,10 +108,34 @@ func MakeGetUserInfoEndpoint(s Service) endpoint.Endpoint {
 func MakeGetProfileEndpoint(s Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(getProfileRequest)
-		name, err := s.GetProfile(ctx, req.UserID)
+		profile, err := s.GetProfile(ctx, req.UserID)
 		return getProfileResponse{
-			Name:  name,
-			Error: err,
+			Profile: profile,
+			Error:   err,
+		}, nil
+	}
+}
+
+func MakeGetProfilesEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(getProfilesRequest)
+		profiles, missing, err := s.GetProfiles(ctx, req.UserIDs)
+		return getProfilesResponse{
+			Profiles: profiles,
+			Missing:  missing,
+			Error:    err,
+		}, nil
+	}
+}
+
+func MakeSearchUsersEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(searchUsersRequest)
+		profiles, cursor, err := s.SearchUsers(ctx, req.Query, req.Limit, req.Cursor)
+		return searchUsersResponse{
+			Profiles: profiles,
+			Cursor:   cursor,
+			Error:    err,
 		}, nil
 	}
 }
@@ -45,6 +143,13 @@ func MakeGetProfileEndpoint(s Service) endpoint.Endpoint {
 func MakeUpdateUserEndpoint(s Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(updateUserRequest)
+		// The profile goes first because it is the most likely to be rejected.
+		if update := req.profileUpdate(); update != (ProfileUpdate{}) {
+			err := s.SetProfile(ctx, update)
+			if err != nil {
+				return updateUserResponse{err}, nil
+			}
+		}
 		if req.Name != nil {
 			err := s.SetName(ctx, *req.Name)
 			if err != nil {
@@ -73,6 +178,224 @@ func MakeDeleteUserEndpoint(s Service) endpoint.Endpoint {
 	}
 }
 
+func MakeRestoreUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		return restoreUserResponse{s.RestoreUser(ctx)}, nil
+	}
+}
+
+func MakeSetAvatarEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(setAvatarRequest)
+		picture, err := s.SetAvatar(ctx, req.ContentType, req.Image)
+		return setAvatarResponse{
+			Picture: picture,
+			Error:   err,
+		}, nil
+	}
+}
+
+func MakeDeleteAvatarEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		return deleteAvatarResponse{s.DeleteAvatar(ctx)}, nil
+	}
+}
+
+func MakeEnrollTOTPEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		secret, uri, err := s.EnrollTOTP(ctx)
+		return enrollTOTPResponse{
+			Secret: secret,
+			URI:    uri,
+			Error:  err,
+		}, nil
+	}
+}
+
+func MakeEnableTOTPEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(totpCodeRequest)
+		codes, err := s.EnableTOTP(ctx, req.Code)
+		return recoveryCodesResponse{
+			RecoveryCodes: codes,
+			Error:         err,
+		}, nil
+	}
+}
+
+func MakeDisableTOTPEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(totpCodeRequest)
+		return disableTOTPResponse{s.DisableTOTP(ctx, req.Code)}, nil
+	}
+}
+
+func MakeRegenerateRecoveryCodesEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(totpCodeRequest)
+		codes, err := s.RegenerateRecoveryCodes(ctx, req.Code)
+		return recoveryCodesResponse{
+			RecoveryCodes: codes,
+			Error:         err,
+		}, nil
+	}
+}
+
+func MakeBeginPasskeyRegistrationEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		opts, err := s.BeginPasskeyRegistration(ctx)
+		return beginPasskeyRegistrationResponse{
+			PublicKey: opts,
+			Error:     err,
+		}, nil
+	}
+}
+
+func MakeFinishPasskeyRegistrationEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(finishPasskeyRegistrationRequest)
+		return finishPasskeyRegistrationResponse{s.FinishPasskeyRegistration(ctx, req.Name, req.Credential)}, nil
+	}
+}
+
+func MakeListIdentitiesEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		identities, err := s.ListIdentities(ctx)
+		return listIdentitiesResponse{
+			Identities: identities,
+			Error:      err,
+		}, nil
+	}
+}
+
+func MakeUnlinkIdentityEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(unlinkIdentityRequest)
+		return unlinkIdentityResponse{s.UnlinkIdentity(ctx, req.Provider)}, nil
+	}
+}
+
+func MakeListSessionsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		sessions, err := s.ListSessions(ctx)
+		return listSessionsResponse{
+			Sessions: sessions,
+			Error:    err,
+		}, nil
+	}
+}
+
+func MakeRevokeSessionEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(revokeSessionRequest)
+		return revokeSessionResponse{s.RevokeSession(ctx, req.SessionID)}, nil
+	}
+}
+
+func MakeListAppsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		apps, err := s.ListApps(ctx)
+		return listAppsResponse{
+			Apps:  apps,
+			Error: err,
+		}, nil
+	}
+}
+
+func MakeRevokeAppEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(revokeAppRequest)
+		return revokeAppResponse{s.RevokeApp(ctx, req.ClientID)}, nil
+	}
+}
+
+func MakeStartExportEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		export, err := s.StartExport(ctx)
+		return exportResponse{export, err}, nil
+	}
+}
+
+func MakeGetExportEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(getExportRequest)
+		export, err := s.GetExport(ctx, req.ExportID)
+		return exportResponse{export, err}, nil
+	}
+}
+
+func MakeListUsersEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(UserFilter)
+		users, cursor, err := s.ListUsers(ctx, req)
+		return listUsersResponse{
+			Users:  users,
+			Cursor: cursor,
+			Error:  err,
+		}, nil
+	}
+}
+
+func MakeGetUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(adminUserRequest)
+		user, err := s.GetUser(ctx, req.UserID)
+		return getUserInfoResponse{user, err}, nil
+	}
+}
+
+func MakeForcePasswordResetEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(adminUserRequest)
+		return adminUserResponse{s.ForcePasswordReset(ctx, req.UserID)}, nil
+	}
+}
+
+func MakeSetUserActiveEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(setUserActiveRequest)
+		return adminUserResponse{s.SetUserActive(ctx, req.UserID, req.Active, req.Reason)}, nil
+	}
+}
+
+func MakePurgeUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(adminUserRequest)
+		return adminUserResponse{s.PurgeUser(ctx, req.UserID)}, nil
+	}
+}
+
+func MakeListStateChangesEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(adminUserRequest)
+		changes, err := s.ListStateChanges(ctx, req.UserID)
+		return listStateChangesResponse{Changes: changes, Error: err}, nil
+	}
+}
+
+func MakeListAuditEventsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(AuditFilter)
+		events, cursor, err := s.ListAuditEvents(ctx, req)
+		return listAuditEventsResponse{
+			Events: events,
+			Cursor: cursor,
+			Error:  err,
+		}, nil
+	}
+}
+
+func MakeVerifyAuditLogEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		brokenAt, err := s.VerifyAuditLog(ctx)
+		return verifyAuditLogResponse{
+			Intact:   brokenAt == 0,
+			BrokenAt: brokenAt,
+			Error:    err,
+		}, nil
+	}
+}
+
 type getUserInfoResponse struct {
 	*models.User
 	Error error `json:"error,omitempty"`
@@ -87,7 +410,7 @@ type getProfileRequest struct {
 }
 
 type getProfileResponse struct {
-	Name  string `json:"name"`
+	*Profile
 	Error error `json:"error,omitempty"`
 }
 
@@ -95,10 +418,57 @@ func (r getProfileResponse) error() error {
 	return r.Error
 }
 
+type getProfilesRequest struct {
+	UserIDs []uuid.UUID `json:"ids"`
+}
+
+type getProfilesResponse struct {
+	Profiles map[uuid.UUID]*Profile `json:"profiles"`
+	// Missing lists the requested IDs that don't belong to a user.
+	Missing []uuid.UUID `json:"missing"`
+	Error   error       `json:"error,omitempty"`
+}
+
+func (r getProfilesResponse) error() error {
+	return r.Error
+}
+
+type searchUsersRequest struct {
+	Query  string
+	Limit  int
+	Cursor string
+}
+
+type searchUsersResponse struct {
+	Profiles []*Profile `json:"profiles"`
+	// Cursor continues the search after this page. It is left out after the last one.
+	Cursor string `json:"cursor,omitempty"`
+	Error  error  `json:"error,omitempty"`
+}
+
+func (r searchUsersResponse) error() error {
+	return r.Error
+}
+
 type updateUserRequest struct {
-	Name     *string
-	Email    *string
-	Password *string
+	Name       *string `json:"name,omitempty"`
+	Email      *string `json:"email,omitempty"`
+	Password   *string `json:"password,omitempty"`
+	GivenName  *string `json:"given_name,omitempty"`
+	FamilyName *string `json:"family_name,omitempty"`
+	Pronouns   *string `json:"pronouns,omitempty"`
+	Locale     *string `json:"locale,omitempty"`
+	Zoneinfo   *string `json:"zoneinfo,omitempty"`
+}
+
+func (r updateUserRequest) profileUpdate() ProfileUpdate {
+	return ProfileUpdate{
+		GivenName:  r.GivenName,
+		FamilyName: r.FamilyName,
+		Pronouns:   r.Pronouns,
+		Locale:     r.Locale,
+		Zoneinfo:   r.Zoneinfo,
+	}
 }
 
 type updateUserResponse struct {
@@ -117,6 +487,241 @@ func (r deleteUserResponse) error() error {
 	return r.Error
 }
 
+type restoreUserResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (r restoreUserResponse) error() error {
+	return r.Error
+}
+
+type setAvatarRequest struct {
+	ContentType string
+	Image       io.Reader
+}
+
+type setAvatarResponse struct {
+	Picture string `json:"picture,omitempty"`
+	Error   error  `json:"error,omitempty"`
+}
+
+func (r setAvatarResponse) error() error {
+	return r.Error
+}
+
+type deleteAvatarResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (r deleteAvatarResponse) error() error {
+	return r.Error
+}
+
+type enrollTOTPResponse struct {
+	Secret string `json:"secret,omitempty"`
+	// URI is an otpauth:// URI for the client to display as a QR code.
+	URI   string `json:"uri,omitempty"`
+	Error error  `json:"error,omitempty"`
+}
+
+func (r enrollTOTPResponse) error() error {
+	return r.Error
+}
+
+// totpCodeRequest carries a code from the user's authenticator app, or for some requests, a recovery code.
+type totpCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	Error         error    `json:"error,omitempty"`
+}
+
+func (r recoveryCodesResponse) error() error {
+	return r.Error
+}
+
+// beginPasskeyRegistrationResponse is shaped so that the client can pass it straight to navigator.credentials.create
+// once the binary fields are decoded.
+type beginPasskeyRegistrationResponse struct {
+	PublicKey *webauthn.CreationOptions `json:"publicKey,omitempty"`
+	Error     error                     `json:"error,omitempty"`
+}
+
+func (r beginPasskeyRegistrationResponse) error() error {
+	return r.Error
+}
+
+type finishPasskeyRegistrationRequest struct {
+	Name       string                       `json:"name"`
+	Credential webauthn.AttestationResponse `json:"credential"`
+}
+
+type finishPasskeyRegistrationResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (r finishPasskeyRegistrationResponse) error() error {
+	return r.Error
+}
+
+type beginPasskeyLoginResponse struct {
+	PublicKey *webauthn.RequestOptions `json:"publicKey,omitempty"`
+	Error     error                    `json:"error,omitempty"`
+}
+
+func (r beginPasskeyLoginResponse) error() error {
+	return r.Error
+}
+
+type listIdentitiesResponse struct {
+	Identities []*models.FederatedIdentity `json:"identities"`
+	Error      error                       `json:"error,omitempty"`
+}
+
+func (r listIdentitiesResponse) error() error {
+	return r.Error
+}
+
+type listSessionsResponse struct {
+	Sessions []*Session `json:"sessions"`
+	Error    error      `json:"error,omitempty"`
+}
+
+func (r listSessionsResponse) error() error {
+	return r.Error
+}
+
+type revokeSessionRequest struct {
+	SessionID uuid.UUID
+}
+
+type revokeSessionResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (r revokeSessionResponse) error() error {
+	return r.Error
+}
+
+type listAppsResponse struct {
+	Apps  []*App `json:"apps"`
+	Error error  `json:"error,omitempty"`
+}
+
+func (r listAppsResponse) error() error {
+	return r.Error
+}
+
+type revokeAppRequest struct {
+	ClientID string
+}
+
+type revokeAppResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (r revokeAppResponse) error() error {
+	return r.Error
+}
+
+type unlinkIdentityRequest struct {
+	Provider string `json:"provider"`
+}
+
+type unlinkIdentityResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (r unlinkIdentityResponse) error() error {
+	return r.Error
+}
+
+type getExportRequest struct {
+	ExportID uuid.UUID
+}
+
+type exportResponse struct {
+	*Export
+	Error error `json:"error,omitempty"`
+}
+
+func (r exportResponse) error() error {
+	return r.Error
+}
+
+type listUsersResponse struct {
+	Users []*models.User `json:"users"`
+	// Cursor continues the listing after this page. It is left out after the last one.
+	Cursor string `json:"cursor,omitempty"`
+	Error  error  `json:"error,omitempty"`
+}
+
+func (r listUsersResponse) error() error {
+	return r.Error
+}
+
+// adminUserRequest names the user that an administrator acts on.
+type adminUserRequest struct {
+	UserID uuid.UUID
+}
+
+// setUserActiveRequest takes the user and whether to suspend or reactivate them from the path, and the reason from
+// the body.
+type setUserActiveRequest struct {
+	UserID uuid.UUID `json:"-"`
+	Active bool      `json:"-"`
+	Reason string    `json:"reason"`
+}
+
+type adminUserResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (r adminUserResponse) error() error {
+	return r.Error
+}
+
+type listStateChangesResponse struct {
+	Changes []*models.UserStateChange `json:"changes"`
+	Error   error                     `json:"error,omitempty"`
+}
+
+func (r listStateChangesResponse) error() error {
+	return r.Error
+}
+
+type listAuditEventsResponse struct {
+	Events []*models.AuditEvent `json:"events"`
+	// Cursor continues the listing after this page. It is left out after the last one.
+	Cursor string `json:"cursor,omitempty"`
+	Error  error  `json:"error,omitempty"`
+}
+
+func (r listAuditEventsResponse) error() error {
+	return r.Error
+}
+
+type verifyAuditLogResponse struct {
+	Intact bool `json:"intact"`
+	// BrokenAt is the ID of the first event that doesn't follow from the one before it, if the log isn't intact.
+	BrokenAt int64 `json:"broken_at,omitempty"`
+	Error    error `json:"error,omitempty"`
+}
+
+func (r verifyAuditLogResponse) error() error {
+	return r.Error
+}
+
+type disableTOTPResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (r disableTOTPResponse) error() error {
+	return r.Error
+}
+
 //func MakeGetUserEndpoint(s Service) endpoint.Endpoint {
 //	return func(c context.Context, request interface{}) (response interface{}, err error) {
 //		req := request.(getUserRequest)
@@ -0,0 +1,219 @@
+package usersvc
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/studiously/usersvc/blobstore"
+	"github.com/studiously/usersvc/models"
+)
+
+// The statuses of an export.
+const (
+	ExportPending = "pending"
+	ExportReady   = "ready"
+	ExportFailed  = "failed"
+)
+
+// exportTimeout bounds how long making an export may take.
+const exportTimeout = 10 * time.Minute
+
+// Export is a copy of everything held about a user, made for them to download.
+type Export struct {
+	ID        uuid.UUID `json:"id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	// URL downloads the export as a ZIP of JSON files once it is ready, until ExpiresAt. Anyone with the URL can
+	// download it, so it must only be shown to the user.
+	URL       string     `json:"url,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ExportSection contributes a file to users' exports, for data kept outside the users table.
+type ExportSection struct {
+	// Name is the name of the file in the ZIP, without the .json extension.
+	Name string
+	// Export returns what is held about the user, to be encoded as JSON.
+	Export func(ctx context.Context, userID uuid.UUID) (interface{}, error)
+}
+
+func exportKey(exportID uuid.UUID) string {
+	return "exports/" + exportID.String() + ".zip"
+}
+
+// exportIdentities is what the identities section holds: every way the user can log in, without anything that could
+// be used to do so.
+type exportIdentities struct {
+	Password  bool                        `json:"password"`
+	TwoFactor bool                        `json:"two_factor"`
+	Passkeys  []exportPasskey             `json:"passkeys"`
+	Federated []*models.FederatedIdentity `json:"federated"`
+}
+
+type exportPasskey struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// builtinExportSections returns the sections every export has.
+func (s *postgresService) builtinExportSections() []ExportSection {
+	return []ExportSection{
+		{Name: "user", Export: func(ctx context.Context, userID uuid.UUID) (interface{}, error) {
+			return models.UserByID(s, userID)
+		}},
+		{Name: "identities", Export: s.exportIdentities},
+		{Name: "state_changes", Export: func(ctx context.Context, userID uuid.UUID) (interface{}, error) {
+			return s.ListStateChanges(ctx, userID)
+		}},
+		{Name: "sessions", Export: s.exportSessions},
+		{Name: "apps", Export: s.exportApps},
+		{Name: "audit_events", Export: s.exportAuditEvents},
+	}
+}
+
+func (s *postgresService) exportIdentities(ctx context.Context, userID uuid.UUID) (interface{}, error) {
+	var ids exportIdentities
+	if _, err := models.LocalIdentityByUserID(s, userID); err == nil {
+		ids.Password = true
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+	if _, err := s.enabledTOTP(userID); err == nil {
+		ids.TwoFactor = true
+	} else if err != ErrNotFound {
+		return nil, err
+	}
+	creds, err := models.WebauthnCredentialsByUserID(s, userID)
+	if err != nil {
+		return nil, err
+	}
+	ids.Passkeys = []exportPasskey{}
+	for _, c := range creds {
+		ids.Passkeys = append(ids.Passkeys, exportPasskey{Name: c.Name, CreatedAt: c.CreatedAt})
+	}
+	if ids.Federated, err = models.FederatedIdentitiesByUserID(s, userID); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// runExport makes a pending export and stores it, marking it ready or failed. It runs in the background, after the
+// request that started it has finished.
+func (s *postgresService) runExport(de *models.DataExport) {
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	de.Status = ExportReady
+	data, err := s.makeExport(ctx, de.UserID)
+	if err == nil {
+		err = s.blobs.Put(ctx, exportKey(de.ID), bytes.NewReader(data))
+	}
+	if err != nil {
+		de.Status = ExportFailed
+	}
+	de.UpdatedAt = time.Now()
+	de.Update(s)
+}
+
+// makeExport writes each section as a JSON file in a ZIP, along with the user's picture if they have one.
+func (s *postgresService) makeExport(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, section := range append(s.builtinExportSections(), s.exportSections...) {
+		v, err := section.Export(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		w, err := zw.Create(section.Name + ".json")
+		if err != nil {
+			return nil, err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	avatar, err := s.blobs.Get(ctx, avatarKey(userID, avatarSizes[0]))
+	if err == nil {
+		defer avatar.Close()
+		w, err := zw.Create("picture.jpg")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(w, avatar); err != nil {
+			return nil, err
+		}
+	} else if err != blobstore.ErrNotFound {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// exportOf describes an export to its user, with a download link if it is ready and hasn't expired.
+func (s *postgresService) exportOf(de *models.DataExport, now time.Time) *Export {
+	e := &Export{ID: de.ID, Status: de.Status, CreatedAt: de.CreatedAt}
+	if de.Status == ExportPending && now.Sub(de.CreatedAt) > exportTimeout {
+		// The replica making it must have stopped.
+		e.Status = ExportFailed
+	}
+	if de.Status != ExportReady {
+		return e
+	}
+	expires := de.UpdatedAt.Add(s.exportTTL)
+	if !now.Before(expires) {
+		e.Status = ExportFailed
+		return e
+	}
+	e.ExpiresAt = &expires
+	e.URL = s.publicURL + "/exports/" + de.ID.String() + "?token=" + signExportToken(s.exportSecret, de.ID, expires)
+	return e
+}
+
+// signExportToken issues the token that lets an export be downloaded until it expires.
+func signExportToken(secret []byte, exportID uuid.UUID, expires time.Time) string {
+	payload := make([]byte, 24)
+	copy(payload, exportID[:])
+	binary.BigEndian.PutUint64(payload[16:], uint64(expires.Unix()))
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(exportMAC(secret, payload))
+}
+
+// verifyExportToken checks that a token was issued for an export and hasn't expired.
+func verifyExportToken(secret []byte, exportID uuid.UUID, token string, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || len(payload) != 24 || !bytes.Equal(payload[:16], exportID[:]) {
+		return ErrInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(mac, exportMAC(secret, payload)) {
+		return ErrInvalidToken
+	}
+	if now.Unix() > int64(binary.BigEndian.Uint64(payload[16:])) {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func exportMAC(secret, payload []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
@@ -0,0 +1,32 @@
+package usersvc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestExportToken(t *testing.T) {
+	resetSecret := []byte("reset secret")
+	key := deriveSecret(resetSecret, "export")
+	id := uuid.New()
+	now := time.Now()
+	token := signExportToken(key, id, now.Add(time.Hour))
+	if err := verifyExportToken(key, id, token, now); err != nil {
+		t.Errorf("verifyExportToken = %v", err)
+	}
+	if err := verifyExportToken(key, id, token, now.Add(2*time.Hour)); err != ErrInvalidToken {
+		t.Errorf("expired: verifyExportToken = %v, want ErrInvalidToken", err)
+	}
+	if err := verifyExportToken(key, uuid.New(), token, now); err != ErrInvalidToken {
+		t.Errorf("other export: verifyExportToken = %v, want ErrInvalidToken", err)
+	}
+	// The reset secret never signs export tokens itself.
+	if err := verifyExportToken(resetSecret, id, signExportToken(resetSecret, id, now.Add(time.Hour)), now); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyExportToken(key, id, signExportToken(resetSecret, id, now.Add(time.Hour)), now); err != ErrInvalidToken {
+		t.Errorf("signed with the reset secret: verifyExportToken = %v, want ErrInvalidToken", err)
+	}
+}
@@ -0,0 +1,163 @@
+package usersvc
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var errUnknownHash = errors.New("password hash is in an unknown format")
+
+// lockedPrefix marks a password that has been locked by an administrator. No password matches it, and the random
+// remainder invalidates any reset links issued for the old password.
+const lockedPrefix = "!locked$"
+
+// PasswordHasher hashes passwords into PHC-style strings of the form $<id>$<params>$<salt>$<hash>, which carry
+// enough metadata to verify them later even after the configured algorithm or its costs have changed.
+type PasswordHasher interface {
+	// Hash returns the encoded hash of password.
+	Hash(password string) (string, error)
+	// Verify reports whether password matches encoded. It returns errUnknownHash if encoded was not produced by
+	// this algorithm.
+	Verify(encoded, password string) (bool, error)
+	// NeedsRehash reports whether encoded was produced by a different algorithm or with different costs than the
+	// hasher currently uses.
+	NeedsRehash(encoded string) bool
+}
+
+// BcryptHasher hashes passwords with bcrypt. bcrypt's own $2a$<cost>$<salt+hash> encoding is already PHC-shaped,
+// so hashes from before PasswordHasher existed remain valid.
+type BcryptHasher struct {
+	Cost int
+}
+
+func (h BcryptHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	return string(hashed), err
+}
+
+func (h BcryptHasher) Verify(encoded, password string) (bool, error) {
+	if !isBcrypt(encoded) {
+		return false, errUnknownHash
+	}
+	switch err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)); err {
+	case nil:
+		return true, nil
+	case bcrypt.ErrMismatchedHashAndPassword:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (h BcryptHasher) NeedsRehash(encoded string) bool {
+	if !isBcrypt(encoded) {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != h.Cost
+}
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+// Argon2idHasher hashes passwords with Argon2id, encoded as $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<hash>.
+type Argon2idHasher struct {
+	// Time is the number of passes over memory.
+	Time uint32
+	// Memory is the amount of memory used, in KiB.
+	Memory uint32
+	// Threads is the degree of parallelism.
+	Threads uint8
+	// SaltLen and KeyLen are the lengths of the salt and derived key, in bytes.
+	SaltLen, KeyLen uint32
+}
+
+type argon2idParams struct {
+	memory, time uint32
+	threads      uint8
+	salt, key    []byte
+}
+
+func (h Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.Time, h.Memory, h.Threads, h.KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.Memory, h.Time, h.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h Argon2idHasher) Verify(encoded, password string) (bool, error) {
+	p, err := parseArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+	key := argon2.IDKey([]byte(password), p.salt, p.time, p.memory, p.threads, uint32(len(p.key)))
+	return subtle.ConstantTimeCompare(key, p.key) == 1, nil
+}
+
+func (h Argon2idHasher) NeedsRehash(encoded string) bool {
+	p, err := parseArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return p.memory != h.Memory || p.time != h.Time || p.threads != h.Threads ||
+		uint32(len(p.salt)) != h.SaltLen || uint32(len(p.key)) != h.KeyLen
+}
+
+func parseArgon2id(encoded string) (*argon2idParams, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return nil, errUnknownHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, errUnknownHash
+	}
+	var p argon2idParams
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return nil, errUnknownHash
+	}
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, errUnknownHash
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(p.key) == 0 {
+		return nil, errUnknownHash
+	}
+	return &p, nil
+}
+
+// DefaultArgon2idHasher uses the parameters recommended by RFC 9106 for memory-constrained environments.
+var DefaultArgon2idHasher = Argon2idHasher{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+	SaltLen: 16,
+	KeyLen:  32,
+}
+
+// verifyPassword checks password against encoded using whichever of the known algorithms produced it, and reports
+// whether the hash should be upgraded to the preferred hasher.
+func verifyPassword(preferred PasswordHasher, encoded, password string) (ok, rehash bool, err error) {
+	if strings.HasPrefix(encoded, lockedPrefix) {
+		return false, false, nil
+	}
+	for _, h := range []PasswordHasher{preferred, BcryptHasher{Cost: bcrypt.DefaultCost}, DefaultArgon2idHasher} {
+		ok, err = h.Verify(encoded, password)
+		if err == errUnknownHash {
+			continue
+		}
+		return ok, ok && preferred.NeedsRehash(encoded), err
+	}
+	return false, false, errUnknownHash
+}
@@ -0,0 +1,102 @@
+package usersvc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/studiously/usersvc/hydra"
+)
+
+// backChannelClient sends logout tokens. Clients are expected to answer promptly, and one that doesn't mustn't hold
+// up logging out of the rest.
+var backChannelClient = &http.Client{Timeout: 5 * time.Second}
+
+// ClientLogout is how a client is logged out of, which Hydra 0.8 doesn't keep with the client.
+type ClientLogout struct {
+	// PostLogoutRedirectURIs are where the client may ask to be sent back to after logging a user out, in addition
+	// to its redirect URIs.
+	PostLogoutRedirectURIs []string
+	// BackChannelLogoutURI, if set, is where the client is sent logout tokens when a user logs out.
+	BackChannelLogoutURI string
+}
+
+// allowsPostLogoutRedirect reports whether c may be sent back to uri after logging out. URIs are compared exactly, as
+// redirect URIs are when logging in.
+func (s *postgresService) allowsPostLogoutRedirect(c *hydra.OAuth2Client, uri string) bool {
+	for _, uris := range [][]string{c.RedirectURIs, s.logoutClients[c.ID].PostLogoutRedirectURIs} {
+		for _, u := range uris {
+			if u == uri {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// logOutClients logs a user out of the clients they used during a session that has ended. The consent they gave each
+// client is revoked at Hydra, so that the refresh tokens it holds for them stop working, and those with a back-channel
+// logout URI are sent a logout token naming the session. A sessionID of uuid.Nil logs the user out of every session.
+// Every client is tried, and the first error is returned.
+func (s *postgresService) logOutClients(ctx context.Context, userID, sessionID uuid.UUID, clients []string) error {
+	if s.hydra == nil {
+		return nil
+	}
+	var first error
+	for _, clientID := range clients {
+		if err := s.logOutClient(ctx, userID, sessionID, clientID); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// logOutClient revokes the consent a user gave a client, and sends it a logout token, even if revoking fails.
+func (s *postgresService) logOutClient(ctx context.Context, userID, sessionID uuid.UUID, clientID string) error {
+	revokeErr := s.hydra.RevokeConsent(ctx, userID.String(), clientID)
+	if err := s.sendBackChannelLogout(ctx, userID, sessionID, clientID); err != nil {
+		return err
+	}
+	return revokeErr
+}
+
+// sendBackChannelLogout sends a client a logout token, if it has a back-channel logout URI.
+func (s *postgresService) sendBackChannelLogout(ctx context.Context, userID, sessionID uuid.UUID, clientID string) error {
+	uri := s.logoutClients[clientID].BackChannelLogoutURI
+	if uri == "" {
+		return nil
+	}
+	var sid string
+	if sessionID != uuid.Nil {
+		sid = sessionID.String()
+	}
+	token, err := s.hydra.LogoutToken(ctx, clientID, userID.String(), sid)
+	if err != nil {
+		return err
+	}
+	return sendLogoutToken(ctx, uri, token)
+}
+
+// sendLogoutToken posts a logout token to a client's back-channel logout URI.
+func sendLogoutToken(ctx context.Context, uri, token string) error {
+	form := url.Values{}
+	form.Set("logout_token", token)
+	r, err := http.NewRequest("POST", uri, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := backChannelClient.Do(r.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("back-channel logout to %s returned status %d", uri, res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package usersvc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/google/uuid"
+	"github.com/studiously/usersvc/hydra"
+	"github.com/studiously/usersvc/hydra/hydratest"
+)
+
+// official are the scopes of an official client, which AddSessionClient gives tokens to without looking up the user's
+// state, so that these tests needn't have a database.
+var official = []string{"openid", "nonconsentual"}
+
+// logoutService returns a service that keeps sessions in memory and logs out of clients registered with srv.
+func logoutService(srv *hydratest.Server, clients map[string]ClientLogout) *postgresService {
+	return New(nil, nil, Sessions(NewMemorySessionStore()), Hydra(srv.Admin()), LogoutClients(clients)).(*postgresService)
+}
+
+func TestCheckLogoutRedirect(t *testing.T) {
+	srv := hydratest.NewServer()
+	defer srv.Close()
+	srv.Clients["app"] = &hydra.OAuth2Client{ID: "app", RedirectURIs: []string{"https://app.example.com/callback"}}
+	srv.Clients["other"] = &hydra.OAuth2Client{ID: "other"}
+	s := logoutService(srv, map[string]ClientLogout{
+		"app": {PostLogoutRedirectURIs: []string{"https://app.example.com/goodbye"}},
+	})
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name, clientID, uri string
+		ok                  bool
+	}{
+		{"redirect URI", "app", "https://app.example.com/callback", true},
+		{"post-logout redirect URI", "app", "https://app.example.com/goodbye", true},
+		{"prefix", "app", "https://app.example.com/goodbye/../evil", false},
+		{"unregistered", "app", "https://evil.example", false},
+		{"another client's", "other", "https://app.example.com/goodbye", false},
+		{"unknown client", "unknown", "https://app.example.com/goodbye", false},
+		{"no client", "", "https://app.example.com/goodbye", false},
+	} {
+		err := s.CheckLogoutRedirect(ctx, tc.clientID, tc.uri)
+		if tc.ok && err != nil {
+			t.Errorf("%s: CheckLogoutRedirect = %v", tc.name, err)
+		}
+		if !tc.ok && err != ErrBadRequest {
+			t.Errorf("%s: CheckLogoutRedirect = %v, want ErrBadRequest", tc.name, err)
+		}
+	}
+}
+
+func TestLogoutRedirectState(t *testing.T) {
+	srv := hydratest.NewServer()
+	defer srv.Close()
+	srv.Clients["app"] = &hydra.OAuth2Client{ID: "app"}
+	s := logoutService(srv, map[string]ClientLogout{
+		"app": {PostLogoutRedirectURIs: []string{"https://app.example.com/goodbye?lang=en"}},
+	})
+	h := MakeGetLogout(s, log.NewNopLogger())
+	for _, tc := range []struct{ query, want string }{
+		{"client_id=app&post_logout_redirect_uri=https%3A%2F%2Fapp.example.com%2Fgoodbye%3Flang%3Den&state=a%2Bb",
+			"https://app.example.com/goodbye?lang=en&state=a%2Bb"},
+		{"client_id=app&post_logout_redirect_uri=https%3A%2F%2Fapp.example.com%2Fgoodbye%3Flang%3Den",
+			"https://app.example.com/goodbye?lang=en"},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/logout?"+tc.query, nil))
+		if w.Code != http.StatusFound || w.Header().Get("Location") != tc.want {
+			t.Errorf("%s: redirected with %d to %q, want %q", tc.query, w.Code, w.Header().Get("Location"), tc.want)
+		}
+	}
+}
+
+// backChannel receives logout tokens.
+type backChannel struct {
+	*httptest.Server
+	status int
+	mtx    sync.Mutex
+	tokens []string
+}
+
+func newBackChannel(status int) *backChannel {
+	b := &backChannel{status: status}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.mtx.Lock()
+		b.tokens = append(b.tokens, r.PostFormValue("logout_token"))
+		b.mtx.Unlock()
+		w.WriteHeader(b.status)
+	}))
+	return b
+}
+
+func TestLogOutBackChannel(t *testing.T) {
+	srv := hydratest.NewServer()
+	defer srv.Close()
+	bc := newBackChannel(http.StatusOK)
+	defer bc.Close()
+	s := logoutService(srv, map[string]ClientLogout{"app": {BackChannelLogoutURI: bc.URL}})
+	ctx := context.Background()
+	userID := uuid.New()
+	token, _, err := s.CreateSession(ctx, userID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _, err := s.CreateSession(ctx, userID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sid uuid.UUID
+	for _, clientID := range []string{"app", "quiet"} {
+		if sid, err = s.AddSessionClient(ctx, token, clientID, official); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.AddSessionClient(ctx, other, "app", official); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.LogOut(ctx, token); err != nil {
+		t.Fatalf("LogOut: %v", err)
+	}
+	revoked := srv.Revocations()
+	sort.Slice(revoked, func(i, j int) bool { return revoked[i].Client < revoked[j].Client })
+	want := []hydratest.Revocation{{Subject: userID.String(), Client: "app"}, {Subject: userID.String(), Client: "quiet"}}
+	if !reflect.DeepEqual(revoked, want) {
+		t.Errorf("revoked %v, want %v", revoked, want)
+	}
+	if len(bc.tokens) != 1 {
+		t.Fatalf("%d logout tokens sent, want 1", len(bc.tokens))
+	}
+	claims, err := srv.LogoutClaims(bc.tokens[0])
+	if err != nil {
+		t.Fatalf("LogoutClaims: %v", err)
+	}
+	for k, want := range map[string]string{"aud": "app", "sub": userID.String(), "sid": sid.String()} {
+		if claims[k] != want {
+			t.Errorf("%s = %v, want %q", k, claims[k], want)
+		}
+	}
+	if _, _, err := s.CheckSession(ctx, token); err == nil {
+		t.Error("session survived logging out")
+	}
+	if _, _, err := s.CheckSession(ctx, other); err != nil {
+		t.Errorf("logging out ended another session: %v", err)
+	}
+}
+
+func TestLogOutBackChannelFailure(t *testing.T) {
+	srv := hydratest.NewServer()
+	defer srv.Close()
+	bc := newBackChannel(http.StatusInternalServerError)
+	defer bc.Close()
+	s := logoutService(srv, map[string]ClientLogout{"app": {BackChannelLogoutURI: bc.URL}})
+	ctx := context.Background()
+	userID := uuid.New()
+	token, _, err := s.CreateSession(ctx, userID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddSessionClient(ctx, token, "app", official); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.LogOut(ctx, token); err == nil {
+		t.Error("LogOut hid the client's failure")
+	}
+	// The client's tokens are revoked all the same.
+	want := []hydratest.Revocation{{Subject: userID.String(), Client: "app"}}
+	if revoked := srv.Revocations(); !reflect.DeepEqual(revoked, want) {
+		t.Errorf("revoked %v, want %v", revoked, want)
+	}
+	if _, _, err := s.CheckSession(ctx, token); err == nil {
+		t.Error("session survived a client failing to log out")
+	}
+}
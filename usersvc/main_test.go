@@ -0,0 +1,66 @@
+package usersvc
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	"github.com/rubenv/sql-migrate"
+	"github.com/studiously/usersvc/ddl"
+	"github.com/studiously/usersvc/models"
+)
+
+// testDB returns a database migrated to the latest schema, in a schema of its own that is dropped when the test
+// ends. Tests that need one are skipped unless TEST_DATABASE_CONFIG is a URL to a Postgres database.
+func testDB(t *testing.T) *sql.DB {
+	config := os.Getenv("TEST_DATABASE_CONFIG")
+	if config == "" {
+		t.Skip("TEST_DATABASE_CONFIG is unset")
+	}
+	admin, err := sql.Open("postgres", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 8)
+	rand.Read(b)
+	schema := "test_" + hex.EncodeToString(b)
+	if _, err := admin.Exec(`CREATE SCHEMA ` + schema); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`)
+		admin.Close()
+	})
+	u, err := url.Parse(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	// Extensions such as pg_trgm live in public.
+	q.Set("search_path", schema+",public")
+	u.RawQuery = q.Encode()
+	db, err := sql.Open("postgres", u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	migrations := &migrate.AssetMigrationSource{Asset: ddl.Asset, AssetDir: ddl.AssetDir, Dir: "postgres"}
+	if _, err := migrate.Exec(db, "postgres", migrations, migrate.Up); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// testUser creates an active user with a verified email address.
+func testUser(t *testing.T, db *sql.DB, email string) *models.User {
+	u := &models.User{ID: uuid.New(), Name: email, Email: email, EmailVerified: true, State: StateActive}
+	if err := u.Insert(db); err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
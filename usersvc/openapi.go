@@ -0,0 +1,676 @@
+package usersvc
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/ory/common/env"
+	"github.com/studiously/usersvc/codes"
+)
+
+// apiOperation documents a route for the OpenAPI document served at /openapi.json.
+type apiOperation struct {
+	Summary string
+	Query   []apiParam
+	// Request and Response are values of the types the route decodes its JSON body into and encodes its response
+	// from, which the schemas are made from.
+	Request  interface{}
+	Response interface{}
+	// Upload is the name of the multipart form field a file is sent in.
+	Upload string
+	// Form lists the fields of the form a page is posted with.
+	Form []string
+	// Produces is the content type of responses that aren't JSON.
+	Produces string
+	// Errors are the error codes the route responds with, besides codes.Nil and, for routes with a body,
+	// codes.BadRequest.
+	Errors []int
+}
+
+type apiParam struct {
+	Name string
+	Type string
+}
+
+// apiOperations documents every route of MakeHTTPHandler, keyed by method and path template. TestOpenAPI fails if a
+// route is missing, so they can't fall out of step.
+var apiOperations = map[string]apiOperation{
+	"GET /openapi.json": {
+		Summary:  "This document.",
+		Produces: "application/json",
+	},
+
+	"GET /userinfo": {
+		Summary:  "Get the user the access token was issued for.",
+		Response: getUserInfoResponse{},
+		Errors:   []int{codes.NotFound},
+	},
+	"PATCH /userinfo": {
+		Summary:  "Update the fields that are present. A new email address only takes effect once it is verified.",
+		Request:  updateUserRequest{},
+		Response: updateUserResponse{},
+		Errors:   []int{codes.NotFound, codes.EmailInUse, codes.HashFailed, codes.AccountInactive},
+	},
+	"DELETE /userinfo": {
+		Summary:  "Delete the user. They are purged once the retention period has passed, and can be restored until then.",
+		Response: deleteUserResponse{},
+		Errors:   []int{codes.NotFound, codes.DeleteOwner, codes.InvalidTransition},
+	},
+	"POST /userinfo/restore": {
+		Summary:  "Cancel the user's deletion before they are purged.",
+		Response: restoreUserResponse{},
+		Errors:   []int{codes.NotFound, codes.AccountInactive},
+	},
+	"PUT /userinfo/avatar": {
+		Summary:  "Replace the user's picture with a JPEG, PNG or GIF image of up to 5 MB.",
+		Upload:   "avatar",
+		Response: setAvatarResponse{},
+		Errors:   []int{codes.BadRequest, codes.NotFound, codes.AccountInactive},
+	},
+	"DELETE /userinfo/avatar": {
+		Summary:  "Remove the user's picture.",
+		Response: deleteAvatarResponse{},
+		Errors:   []int{codes.NotFound, codes.AccountInactive},
+	},
+	"POST /userinfo/totp": {
+		Summary:  "Generate a TOTP secret to confirm with /userinfo/totp/enable.",
+		Response: enrollTOTPResponse{},
+		Errors:   []int{codes.NotFound, codes.TwoFactorEnabled, codes.AccountInactive},
+	},
+	"POST /userinfo/totp/enable": {
+		Summary:  "Turn on two-factor authentication, returning recovery codes.",
+		Request:  totpCodeRequest{},
+		Response: recoveryCodesResponse{},
+		Errors:   []int{codes.WrongCode, codes.TwoFactorEnabled, codes.AccountInactive},
+	},
+	"POST /userinfo/totp/disable": {
+		Summary:  "Turn off two-factor authentication, given a current or recovery code.",
+		Request:  totpCodeRequest{},
+		Response: disableTOTPResponse{},
+		Errors:   []int{codes.WrongCode, codes.TooManyAttempts, codes.AccountInactive},
+	},
+	"POST /userinfo/totp/recovery-codes": {
+		Summary:  "Replace the user's recovery codes, given a current or recovery code.",
+		Request:  totpCodeRequest{},
+		Response: recoveryCodesResponse{},
+		Errors:   []int{codes.WrongCode, codes.TooManyAttempts, codes.AccountInactive},
+	},
+	"POST /userinfo/passkeys/options": {
+		Summary:  "Start registering a passkey, returning the options for navigator.credentials.create.",
+		Response: beginPasskeyRegistrationResponse{},
+		Errors:   []int{codes.NotFound, codes.AccountInactive},
+	},
+	"POST /userinfo/passkeys": {
+		Summary:  "Finish registering a passkey.",
+		Request:  finishPasskeyRegistrationRequest{},
+		Response: finishPasskeyRegistrationResponse{},
+		Errors:   []int{codes.InvalidCredential, codes.AccountInactive},
+	},
+	"GET /userinfo/identities": {
+		Summary:  "List the external accounts linked to the user.",
+		Response: listIdentitiesResponse{},
+	},
+	"DELETE /userinfo/identities/{provider}": {
+		Summary:  "Unlink the user's account from a provider.",
+		Response: unlinkIdentityResponse{},
+		Errors:   []int{codes.NotFound, codes.LastIdentity, codes.AccountInactive},
+	},
+	"GET /sessions": {
+		Summary:  "List the browsers the user is logged in with, most recently seen first.",
+		Response: listSessionsResponse{},
+	},
+	"DELETE /sessions/{sessionID}": {
+		Summary:  "Log the user out of one of their browsers.",
+		Response: revokeSessionResponse{},
+		Errors:   []int{codes.NotFound},
+	},
+	"GET /apps": {
+		Summary:  "List the clients the user has granted access to, most recently granted first.",
+		Response: listAppsResponse{},
+	},
+	"DELETE /apps/{clientID}": {
+		Summary:  "Forget the scopes the user granted a client, and tell the client to log them out.",
+		Response: revokeAppResponse{},
+		Errors:   []int{codes.NotFound},
+	},
+	"POST /userinfo/export": {
+		Summary:  "Start making a ZIP of everything held about the user, or return the one being made.",
+		Response: exportResponse{},
+	},
+	"GET /userinfo/export/{exportID}": {
+		Summary:  "Check on an export. Once it is ready, url downloads it until expires_at.",
+		Response: exportResponse{},
+		Errors:   []int{codes.NotFound},
+	},
+
+	"POST /users:batchGet": {
+		Summary:  "Get the profiles of up to 500 users. IDs that don't belong to a user are returned in missing.",
+		Request:  getProfilesRequest{},
+		Response: getProfilesResponse{},
+	},
+	"GET /users:search": {
+		Summary:  "Search active users by name, or by exact email address.",
+		Query:    []apiParam{{"q", "string"}, {"limit", "integer"}, {"cursor", "string"}},
+		Response: searchUsersResponse{},
+		Errors:   []int{codes.BadRequest},
+	},
+	"GET /users/{userID}": {
+		Summary:  "Get a user's profile.",
+		Response: getProfileResponse{},
+		Errors:   []int{codes.NotFound},
+	},
+
+	"GET /admin/users": {
+		Summary:  "List users, optionally filtered by email, name, whether they are active or their state.",
+		Query:    []apiParam{{"email", "string"}, {"name", "string"}, {"active", "boolean"}, {"state", "string"}, {"limit", "integer"}, {"cursor", "string"}},
+		Response: listUsersResponse{},
+		Errors:   []int{codes.BadRequest},
+	},
+	"GET /admin/users/{userID}": {
+		Summary:  "Get any user.",
+		Response: getUserInfoResponse{},
+		Errors:   []int{codes.NotFound},
+	},
+	"POST /admin/users/{userID}/reset-password": {
+		Summary:  "Lock a user's password and email them a link to choose a new one.",
+		Response: adminUserResponse{},
+		Errors:   []int{codes.NotFound},
+	},
+	"POST /admin/users/{userID}/deactivate": {
+		Summary:  "Suspend a user, giving the reason for the record.",
+		Request:  setUserActiveRequest{},
+		Response: adminUserResponse{},
+		Errors:   []int{codes.NotFound, codes.InvalidTransition},
+	},
+	"POST /admin/users/{userID}/reactivate": {
+		Summary:  "Reactivate a suspended user, returning them to the state they were in before.",
+		Request:  setUserActiveRequest{},
+		Response: adminUserResponse{},
+		Errors:   []int{codes.NotFound, codes.InvalidTransition},
+	},
+	"DELETE /admin/users/{userID}": {
+		Summary:  "Permanently delete a user and everything that belongs to them.",
+		Response: adminUserResponse{},
+		Errors:   []int{codes.NotFound, codes.DeleteOwner},
+	},
+	"GET /admin/users/{userID}/state-changes": {
+		Summary:  "List the changes to a user's state, oldest first. They are kept after the user is purged.",
+		Response: listStateChangesResponse{},
+	},
+	"GET /admin/audit-events": {
+		Summary:  "List the audit log, newest first, optionally filtered by the user who performed or was subject to each event, its type, or when it happened.",
+		Query:    []apiParam{{"user", "string"}, {"type", "string"}, {"since", "string"}, {"until", "string"}, {"limit", "integer"}, {"cursor", "string"}},
+		Response: listAuditEventsResponse{},
+		Errors:   []int{codes.BadRequest},
+	},
+	"GET /admin/audit-events:verify": {
+		Summary:  "Check that no event in the audit log has been altered, removed or inserted out of order.",
+		Response: verifyAuditLogResponse{},
+	},
+
+	"GET /avatars/{userID}/{size:[0-9]+}": {
+		Summary:  "Get a user's picture, a square JPEG 512, 256, 128 or 64 pixels across.",
+		Produces: "image/jpeg",
+	},
+
+	"GET /exports/{exportID}": {
+		Summary:  "Download an export, as linked to by its url.",
+		Query:    []apiParam{{"token", "string"}},
+		Produces: "application/zip",
+	},
+
+	// The rest are the pages users log in through.
+	"GET /register": {
+		Summary:  "Registration page.",
+		Query:    []apiParam{{"challenge", "string"}},
+		Produces: "text/html",
+	},
+	"POST /register": {
+		Summary:  "Register, then continue to the login page.",
+		Query:    []apiParam{{"challenge", "string"}},
+		Form:     []string{"name", "email", "password"},
+		Produces: "text/html",
+	},
+	"GET /login": {
+		Summary:  "Login page.",
+		Query:    []apiParam{{"challenge", "string"}, {"email", "string"}},
+		Produces: "text/html",
+	},
+	"POST /login": {
+		Summary:  "Log in with a password or passkey, then continue to two-factor authentication or consent.",
+		Form:     []string{"challenge", "email", "password", "passkey"},
+		Produces: "text/html",
+	},
+	"GET /login/passkey": {
+		Summary:  "Start logging in with a passkey.",
+		Produces: "text/html",
+	},
+	"GET /login/2fa": {
+		Summary:  "Two-factor authentication page.",
+		Query:    []apiParam{{"challenge", "string"}},
+		Produces: "text/html",
+	},
+	"POST /login/2fa": {
+		Summary:  "Enter a TOTP or recovery code, then continue to consent.",
+		Query:    []apiParam{{"challenge", "string"}},
+		Form:     []string{"code"},
+		Produces: "text/html",
+	},
+	"GET /oidc/{provider}": {
+		Summary:  "Log in with, or link, an external account.",
+		Query:    []apiParam{{"challenge", "string"}, {"link", "boolean"}},
+		Produces: "text/html",
+	},
+	"GET /oidc/{provider}/callback": {
+		Summary:  "Where external providers redirect back to.",
+		Query:    []apiParam{{"state", "string"}, {"code", "string"}, {"error", "string"}, {"error_description", "string"}},
+		Produces: "text/html",
+	},
+	"GET /reset": {
+		Summary:  "Password reset page.",
+		Query:    []apiParam{{"challenge", "string"}, {"email", "string"}},
+		Produces: "text/html",
+	},
+	"POST /reset": {
+		Summary:  "Email a password reset link.",
+		Query:    []apiParam{{"challenge", "string"}},
+		Form:     []string{"email"},
+		Produces: "text/html",
+	},
+	"GET /reset/confirm": {
+		Summary:  "Page for choosing a new password, linked to from the reset email.",
+		Query:    []apiParam{{"token", "string"}},
+		Produces: "text/html",
+	},
+	"POST /reset/confirm": {
+		Summary:  "Choose a new password.",
+		Form:     []string{"token", "password"},
+		Produces: "text/html",
+	},
+	"GET /verify": {
+		Summary:  "Verify an email address, linked to from the verification email.",
+		Query:    []apiParam{{"token", "string"}},
+		Produces: "text/html",
+	},
+	"GET /consent": {
+		Summary:  "Consent page, which Hydra redirects to.",
+		Query:    []apiParam{{"challenge", "string"}, {"error", "string"}, {"error_description", "string"}},
+		Produces: "text/html",
+	},
+	"POST /consent": {
+		Summary:  "Grant the scopes that are checked, then return to Hydra.",
+		Query:    []apiParam{{"challenge", "string"}},
+		Produces: "text/html",
+	},
+	"GET /logout": {
+		Summary: "Log out of the session and the clients used during it. Goes to post_logout_redirect_uri, with state, " +
+			"if the client named by client_id or id_token_hint registered it.",
+		Query: []apiParam{{"id_token_hint", "string"}, {"client_id", "string"}, {"post_logout_redirect_uri", "string"},
+			{"state", "string"}},
+		Produces: "text/html",
+	},
+}
+
+// codeNames names the error codes in the codes package.
+var codeNames = map[int]string{
+	codes.Nil:               "Nil",
+	codes.BadRouting:        "BadRouting",
+	codes.HashFailed:        "HashFailed",
+	codes.UserExists:        "UserExists",
+	codes.WrongEmail:        "WrongEmail",
+	codes.WrongPassword:     "WrongPassword",
+	codes.BadRequest:        "BadRequest",
+	codes.NotFound:          "NotFound",
+	codes.DeleteOwner:       "DeleteOwner",
+	codes.InvalidToken:      "InvalidToken",
+	codes.EmailInUse:        "EmailInUse",
+	codes.TooManyAttempts:   "TooManyAttempts",
+	codes.WrongCode:         "WrongCode",
+	codes.TwoFactorEnabled:  "TwoFactorEnabled",
+	codes.InvalidCredential: "InvalidCredential",
+	codes.IdentityInUse:     "IdentityInUse",
+	codes.LastIdentity:      "LastIdentity",
+	codes.LinkRequired:      "LinkRequired",
+	codes.AccountInactive:   "AccountInactive",
+	codes.InvalidTransition: "InvalidTransition",
+}
+
+// MakeGetOpenAPI serves an OpenAPI document.
+func MakeGetOpenAPI(doc []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(doc)
+	})
+}
+
+// describeAPI generates the OpenAPI 3 document for the routes of r from apiOperations, and the OAuth2 scopes that
+// routes require from scopes, both keyed by method and path template. Routes without a scope are public. Any route
+// that is undocumented, or documentation for a route that doesn't exist, is reported in the error, but the rest are
+// still described.
+func describeAPI(r *mux.Router, scopes map[string]string) ([]byte, error) {
+	g := schemaGenerator{schemas: map[string]interface{}{}}
+	paths := map[string]map[string]interface{}{}
+	documented := map[string]bool{}
+	var problems []string
+	r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			problems = append(problems, err.Error())
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			problems = append(problems, tpl+": "+err.Error())
+			return nil
+		}
+		path := pathParam.ReplaceAllString(tpl, "{$1}")
+		for _, method := range methods {
+			key := method + " " + tpl
+			op, ok := apiOperations[key]
+			if !ok {
+				problems = append(problems, "no entry in apiOperations for "+key)
+			}
+			documented[key] = true
+			if paths[path] == nil {
+				paths[path] = map[string]interface{}{}
+			}
+			paths[path][strings.ToLower(method)] = g.operation(op, tpl, scopes[key])
+		}
+		return nil
+	})
+	for key := range apiOperations {
+		if !documented[key] {
+			problems = append(problems, "apiOperations documents "+key+", which has no route")
+		}
+	}
+
+	var codeList []string
+	for code := 0; code < len(codeNames); code++ {
+		codeList = append(codeList, fmt.Sprintf("%d %s", code, codeNames[code]))
+	}
+	g.schemas["Error"] = map[string]interface{}{
+		"type":     "object",
+		"required": []string{"error_code", "error"},
+		"properties": map[string]interface{}{
+			"error_code": map[string]interface{}{
+				"type":        "integer",
+				"description": "One of " + strings.Join(codeList, ", ") + ".",
+			},
+			"error": map[string]interface{}{"type": "string"},
+		},
+	}
+	hydra := strings.TrimRight(env.Getenv("HYDRA_CLUSTER_URL", ""), "/")
+	doc, err := json.Marshal(map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "usersvc",
+			"version": "1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": g.schemas,
+			"securitySchemes": map[string]interface{}{
+				"oauth2": map[string]interface{}{
+					"type":        "oauth2",
+					"description": "Access tokens issued by Hydra.",
+					"flows": map[string]interface{}{
+						"authorizationCode": map[string]interface{}{
+							"authorizationUrl": hydra + "/oauth2/auth",
+							"tokenUrl":         hydra + "/oauth2/token",
+							"scopes":           apiScopes,
+						},
+						"clientCredentials": map[string]interface{}{
+							"tokenUrl": hydra + "/oauth2/token",
+							"scopes":   apiScopes,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if problems != nil {
+		sort.Strings(problems)
+		return doc, errors.New("usersvc: " + strings.Join(problems, "; "))
+	}
+	return doc, nil
+}
+
+var apiScopes = map[string]string{
+	"users.get":    "Read the user's own information and other users' profiles.",
+	"users.update": "Change the user's own information.",
+	"users.delete": "Delete the user.",
+	"users.search": "Search for users.",
+	"users.admin":  "Manage any user.",
+}
+
+// pathParam matches variables in mux path templates, which may have a pattern that OpenAPI paths don't.
+var pathParam = regexp.MustCompile(`\{([^}:]+)(?::[^}]*)?\}`)
+
+var (
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaGenerator describes Go types as OpenAPI schemas, the way encoding/json encodes them. Exported struct types are
+// added to schemas and referred to by name.
+type schemaGenerator struct {
+	schemas map[string]interface{}
+}
+
+func (g schemaGenerator) operation(op apiOperation, tpl, scope string) map[string]interface{} {
+	o := map[string]interface{}{"summary": op.Summary}
+	var params []interface{}
+	for _, m := range pathParam.FindAllStringSubmatch(tpl, -1) {
+		params = append(params, map[string]interface{}{
+			"name":     m[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		})
+	}
+	for _, p := range op.Query {
+		params = append(params, map[string]interface{}{
+			"name":   p.Name,
+			"in":     "query",
+			"schema": map[string]interface{}{"type": p.Type},
+		})
+	}
+	if params != nil {
+		o["parameters"] = params
+	}
+	if scope != "" {
+		o["security"] = []interface{}{map[string]interface{}{"oauth2": []string{scope}}}
+	}
+
+	switch {
+	case op.Request != nil:
+		o["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": g.schema(reflect.TypeOf(op.Request))},
+			},
+		}
+	case op.Upload != "":
+		o["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"multipart/form-data": map[string]interface{}{"schema": map[string]interface{}{
+					"type":       "object",
+					"required":   []string{op.Upload},
+					"properties": map[string]interface{}{op.Upload: map[string]interface{}{"type": "string", "format": "binary"}},
+				}},
+			},
+		}
+	case op.Form != nil:
+		fields := map[string]interface{}{}
+		for _, f := range op.Form {
+			fields[f] = map[string]interface{}{"type": "string"}
+		}
+		o["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"application/x-www-form-urlencoded": map[string]interface{}{"schema": map[string]interface{}{
+					"type":       "object",
+					"properties": fields,
+				}},
+			},
+		}
+	}
+
+	responses := map[string]interface{}{}
+	switch {
+	case op.Response != nil:
+		responses["200"] = map[string]interface{}{
+			"description": "OK",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": g.schema(reflect.TypeOf(op.Response))},
+			},
+		}
+	case op.Produces == "text/html":
+		responses["200"] = map[string]interface{}{
+			"description": "A page",
+			"content":     map[string]interface{}{"text/html": map[string]interface{}{}},
+		}
+		responses["302"] = map[string]interface{}{"description": "A redirect to the next step"}
+	default:
+		responses["200"] = map[string]interface{}{
+			"description": "OK",
+			"content":     map[string]interface{}{op.Produces: map[string]interface{}{}},
+		}
+	}
+	if op.Produces == "" {
+		errs := append([]int{codes.Nil}, op.Errors...)
+		if op.Request != nil || op.Upload != "" {
+			errs = append(errs, codes.BadRequest)
+		}
+		byStatus := map[int][]string{}
+		seen := map[int]bool{}
+		for _, code := range errs {
+			if seen[code] {
+				continue
+			}
+			seen[code] = true
+			status := httpStatusFrom(code)
+			byStatus[status] = append(byStatus[status], fmt.Sprintf("%d (%s)", code, codeNames[code]))
+		}
+		for status, list := range byStatus {
+			sort.Strings(list)
+			responses[fmt.Sprint(status)] = map[string]interface{}{
+				"description": "error_code " + strings.Join(list, ", "),
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": map[string]interface{}{"$ref": "#/components/schemas/Error"},
+					},
+				},
+			}
+		}
+	} else if op.Produces == "image/jpeg" {
+		responses["404"] = map[string]interface{}{"description": "The user has no picture"}
+	} else if op.Produces == "application/zip" {
+		responses["404"] = map[string]interface{}{"description": "The link is wrong or has expired"}
+	}
+	o["responses"] = responses
+	return o
+}
+
+func (g schemaGenerator) schema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == reflect.TypeOf(uuid.UUID{}):
+		return map[string]interface{}{"type": "string", "format": "uuid"}
+	case t == reflect.TypeOf(time.Time{}):
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType):
+		return map[string]interface{}{"type": "string"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		name := t.Name()
+		if name == "" || !unicode.IsUpper(rune(name[0])) {
+			return g.object(t)
+		}
+		if _, ok := g.schemas[name]; !ok {
+			// Store a placeholder first, in case the type refers to itself.
+			g.schemas[name] = nil
+			g.schemas[name] = g.object(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]interface{}{}
+}
+
+// object describes the fields of a struct, including those of embedded structs. Error fields are left out, as errors
+// are sent as an Error rather than as part of the response.
+func (g schemaGenerator) object(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" || f.Type == errorType {
+				continue
+			}
+			name, opts := tag, ""
+			if i := strings.Index(tag, ","); i >= 0 {
+				name, opts = tag[:i], tag[i:]
+			}
+			if f.Anonymous && name == "" {
+				ft := f.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft)
+					continue
+				}
+			}
+			if f.PkgPath != "" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			properties[name] = g.schema(f.Type)
+			if !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+			}
+		}
+	}
+	walk(t)
+	o := map[string]interface{}{"type": "object", "properties": properties}
+	if required != nil {
+		o["required"] = required
+	}
+	return o
+}
@@ -0,0 +1,80 @@
+package usersvc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+	"github.com/ory/hydra/sdk"
+)
+
+func TestOpenAPI(t *testing.T) {
+	r := MakeHTTPHandler(nil, &sdk.Client{}, log.NewNopLogger()).(*mux.Router)
+	routed := map[string]bool{}
+	err := r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+		for _, method := range methods {
+			key := method + " " + tpl
+			routed[key] = true
+			if _, ok := apiOperations[key]; !ok {
+				t.Errorf("%s has no entry in apiOperations", key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key := range apiOperations {
+		if !routed[key] {
+			t.Errorf("apiOperations documents %s, which has no route", key)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json = %d", w.Code)
+	}
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Security []map[string][]string `json:"security"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		path, method, scope string
+	}{
+		{"/userinfo", "get", "users.get"},
+		{"/userinfo", "delete", "users.delete"},
+		{"/users:search", "get", "users.search"},
+		{"/admin/users/{userID}", "delete", "users.admin"},
+		{"/avatars/{userID}/{size}", "get", ""},
+		{"/login", "post", ""},
+	} {
+		op, ok := doc.Paths[tc.path][tc.method]
+		if !ok {
+			t.Errorf("%s %s is missing from the document", tc.method, tc.path)
+			continue
+		}
+		var got string
+		if len(op.Security) == 1 && len(op.Security[0]["oauth2"]) == 1 {
+			got = op.Security[0]["oauth2"][0]
+		}
+		if got != tc.scope {
+			t.Errorf("%s %s requires scope %q, want %q", tc.method, tc.path, got, tc.scope)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package usersvc
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/studiously/usersvc/models"
+	"github.com/studiously/usersvc/webauthn"
+)
+
+// defaultRelyingParty scopes passkeys to the host usersvc is served from.
+func defaultRelyingParty(publicURL string) webauthn.RelyingParty {
+	rp := webauthn.RelyingParty{Name: "Studiously"}
+	if u, err := url.Parse(publicURL); err == nil {
+		rp.ID = u.Hostname()
+		rp.Origins = []string{u.Scheme + "://" + u.Host}
+	}
+	return rp
+}
+
+func hashChallenge(challenge []byte) string {
+	sum := sha256.Sum256(challenge)
+	return hex.EncodeToString(sum[:])
+}
+
+// newChallenge stores a challenge for a ceremony by the given user, or by nobody yet if userID is nil.
+func newChallenge(db models.XODB, userID *uuid.UUID) ([]byte, error) {
+	// Abandoned ceremonies are never cleaned up otherwise.
+	if _, err := db.Exec(`DELETE FROM webauthn_challenges WHERE expires_at < now()`); err != nil {
+		return nil, err
+	}
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return nil, err
+	}
+	wc := &models.WebauthnChallenge{
+		ChallengeHash: hashChallenge(challenge),
+		UserID:        userID,
+		ExpiresAt:     time.Now().Add(webauthn.Timeout),
+	}
+	return challenge, wc.Insert(db)
+}
+
+// consumeChallenge redeems the challenge a response answers, so that it can't be answered again. It returns
+// ErrInvalidCredential unless the challenge was issued to the given user, or to nobody if userID is nil, and is
+// still current.
+func consumeChallenge(db models.XODB, clientDataJSON []byte, userID *uuid.UUID) ([]byte, error) {
+	challenge, err := webauthn.Challenge(clientDataJSON)
+	if err != nil {
+		return nil, ErrInvalidCredential
+	}
+	var owner *uuid.UUID
+	var expiresAt time.Time
+	err = db.QueryRow(`DELETE FROM webauthn_challenges WHERE challenge_hash = $1 RETURNING user_id, expires_at`,
+		hashChallenge(challenge)).Scan(&owner, &expiresAt)
+	switch err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		return nil, ErrInvalidCredential
+	default:
+		return nil, err
+	}
+	if (owner == nil) != (userID == nil) || owner != nil && *owner != *userID || time.Now().After(expiresAt) {
+		return nil, ErrInvalidCredential
+	}
+	return challenge, nil
+}
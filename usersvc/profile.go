@@ -0,0 +1,101 @@
+package usersvc
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/studiously/usersvc/models"
+	"golang.org/x/text/language"
+)
+
+// profileColumns selects the columns of users that make up a Profile, in the order of its fields.
+const profileColumns = `id, name, given_name, family_name, pronouns, picture, locale, zoneinfo`
+
+// Profile is what other users and services may see about a user. Fields other than ID and Name are empty until the
+// user fills them in.
+type Profile struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	GivenName  string    `json:"given_name"`
+	FamilyName string    `json:"family_name"`
+	Pronouns   string    `json:"pronouns"`
+	// Picture is the URL of the picture the user uploaded, a 512 pixel square JPEG. The same picture is available
+	// 256, 128 and 64 pixels across by replacing the size at the end of the path.
+	Picture string `json:"picture"`
+	// Locale is a BCP 47 language tag, such as "en-US".
+	Locale string `json:"locale"`
+	// Zoneinfo is an IANA time zone, such as "America/New_York".
+	Zoneinfo string `json:"zoneinfo"`
+}
+
+func profileOf(u *models.User) *Profile {
+	return &Profile{
+		ID:         u.ID,
+		Name:       u.Name,
+		GivenName:  u.GivenName,
+		FamilyName: u.FamilyName,
+		Pronouns:   u.Pronouns,
+		Picture:    u.Picture,
+		Locale:     u.Locale,
+		Zoneinfo:   u.Zoneinfo,
+	}
+}
+
+// ProfileUpdate changes the fields of a user's profile that are not nil. Empty strings clear them.
+type ProfileUpdate struct {
+	GivenName  *string
+	FamilyName *string
+	Pronouns   *string
+	Locale     *string
+	Zoneinfo   *string
+}
+
+// apply validates the update and applies it to u.
+func (p ProfileUpdate) apply(u *models.User) error {
+	locale := u.Locale
+	if p.Locale != nil {
+		var ok bool
+		if locale, ok = canonicalLocale(*p.Locale); !ok {
+			return ErrInvalidLocale
+		}
+	}
+	if p.Zoneinfo != nil && !validZoneinfo(*p.Zoneinfo) {
+		return ErrInvalidZoneinfo
+	}
+	set := func(dst *string, src *string) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	set(&u.GivenName, p.GivenName)
+	set(&u.FamilyName, p.FamilyName)
+	set(&u.Pronouns, p.Pronouns)
+	set(&u.Zoneinfo, p.Zoneinfo)
+	u.Locale = locale
+	return nil
+}
+
+// canonicalLocale returns the canonical form of a BCP 47 language tag, so that equal tags are stored alike.
+func canonicalLocale(s string) (string, bool) {
+	if s == "" {
+		return "", true
+	}
+	tag, err := language.Parse(s)
+	if err != nil {
+		return "", false
+	}
+	return tag.String(), true
+}
+
+// validZoneinfo reports whether s names a time zone in the IANA database.
+func validZoneinfo(s string) bool {
+	if s == "" {
+		return true
+	}
+	// LoadLocation also accepts "Local", which means nothing to anyone but us.
+	if s == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(s)
+	return err == nil
+}
@@ -0,0 +1,60 @@
+package usersvc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/studiously/usersvc/models"
+)
+
+// signResetToken issues a password reset token for the given local identity. The token carries the user ID and an
+// expiry, and is signed over the identity's current password hash. Rotating the hash therefore invalidates every
+// token issued before it, which makes tokens single-use.
+func signResetToken(secret []byte, li *models.LocalIdentity, expires time.Time) string {
+	payload := make([]byte, 24)
+	copy(payload, li.UserID[:])
+	binary.BigEndian.PutUint64(payload[16:], uint64(expires.Unix()))
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(resetMAC(secret, payload, li.Password))
+}
+
+// parseResetToken extracts the user ID from a token without verifying it.
+func parseResetToken(token string) (userID uuid.UUID, payload, mac []byte, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return uuid.Nil, nil, nil, ErrInvalidToken
+	}
+	payload, err = base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || len(payload) != 24 {
+		return uuid.Nil, nil, nil, ErrInvalidToken
+	}
+	mac, err = base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return uuid.Nil, nil, nil, ErrInvalidToken
+	}
+	copy(userID[:], payload[:16])
+	return userID, payload, mac, nil
+}
+
+// verifyResetToken checks the signature and expiry of a token previously parsed with parseResetToken.
+func verifyResetToken(secret []byte, li *models.LocalIdentity, payload, mac []byte, now time.Time) error {
+	if !hmac.Equal(mac, resetMAC(secret, payload, li.Password)) {
+		return ErrInvalidToken
+	}
+	if now.Unix() > int64(binary.BigEndian.Uint64(payload[16:])) {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func resetMAC(secret, payload []byte, hash string) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write(payload)
+	h.Write([]byte(hash))
+	return h.Sum(nil)
+}
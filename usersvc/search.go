@@ -0,0 +1,58 @@
+package usersvc
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// minSearchLength is the shortest query SearchUsers accepts. Shorter ones match too many users to be useful, and
+// are too short for the trigram index.
+const minSearchLength = 3
+
+// searchQuery returns the SQL and arguments for a page of up to limit active users whose names contain q, or whose
+// email is exactly q, ordered by name.
+func searchQuery(q string, limit int, cursor string) (string, []interface{}, error) {
+	q = strings.TrimSpace(q)
+	if utf8.RuneCountInString(q) < minSearchLength {
+		return "", nil, ErrQueryTooShort
+	}
+	sql := `SELECT ` + profileColumns + ` FROM users ` +
+		`WHERE state = 'active' AND (name ILIKE $1 OR lower(email) = lower($2))`
+	args := []interface{}{"%" + escapeLike(q) + "%", q}
+	if cursor != "" {
+		name, id, err := decodeSearchCursor(cursor)
+		if err != nil {
+			return "", nil, ErrBadRequest
+		}
+		sql += ` AND (name, id) > ($3, $4)`
+		args = append(args, name, id)
+	}
+	sql += fmt.Sprintf(` ORDER BY name, id LIMIT $%d`, len(args)+1)
+	return sql, append(args, limit), nil
+}
+
+// nextSearchPage trims a page fetched with one row more than limit, returning the cursor that continues after it,
+// or "" if there is nothing more.
+func nextSearchPage(page []*Profile, limit int) ([]*Profile, string) {
+	if len(page) <= limit {
+		return page, ""
+	}
+	page = page[:limit]
+	last := page[limit-1]
+	return page, base64.RawURLEncoding.EncodeToString([]byte(last.ID.String() + last.Name))
+}
+
+// decodeSearchCursor returns the name and ID of the last user on the previous page, which the cursor holds as the ID
+// followed by the name.
+func decodeSearchCursor(cursor string) (string, uuid.UUID, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(b) < 36 {
+		return "", uuid.UUID{}, ErrBadRequest
+	}
+	id, err := uuid.Parse(string(b[:36]))
+	return string(b[36:]), id, err
+}
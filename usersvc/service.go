@@ -2,30 +2,221 @@ package usersvc
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/studiously/svcerror"
 	"github.com/studiously/usersvc/codes"
 	"github.com/studiously/usersvc/models"
+	"github.com/studiously/usersvc/oidc"
+	"github.com/studiously/usersvc/webauthn"
 )
 
 var (
-	ErrHashFailed    = svcerror.New(codes.HashFailed, "bcrypt hash failed")
+	ErrHashFailed    = svcerror.New(codes.HashFailed, "password hash failed")
 	ErrUserExists    = svcerror.New(codes.UserExists, "user already exists")
 	ErrWrongEmail    = svcerror.New(codes.WrongEmail, "wrong email")
 	ErrWrongPassword = svcerror.New(codes.WrongPassword, "wrong password")
 	ErrNotFound      = svcerror.New(codes.NotFound, "not found")
 	ErrDeleteOwner   = svcerror.New(codes.DeleteOwner, "cannot delete user while it is an owner")
+	ErrInvalidToken  = svcerror.New(codes.InvalidToken, "link is invalid or has expired")
+	ErrEmailInUse    = svcerror.New(codes.EmailInUse, "email address is already in use")
+	// ErrTooManyAttempts is returned by Authenticate instead of checking the password while the email or client
+	// address is locked out.
+	ErrTooManyAttempts   = svcerror.New(codes.TooManyAttempts, "too many failed login attempts, try again later")
+	ErrWrongCode         = svcerror.New(codes.WrongCode, "invalid authentication code")
+	ErrTwoFactorEnabled  = svcerror.New(codes.TwoFactorEnabled, "two-factor authentication is already enabled")
+	ErrInvalidCredential = svcerror.New(codes.InvalidCredential, "passkey could not be verified")
+	ErrIdentityInUse     = svcerror.New(codes.IdentityInUse, "that account is already linked to a user")
+	ErrLastIdentity      = svcerror.New(codes.LastIdentity, "cannot remove the only way to log in")
+	ErrLinkRequired      = svcerror.New(codes.LinkRequired, "a user with this email address already exists, log in and link the account instead")
+	ErrEmailRequired     = svcerror.New(codes.BadRequest, "the provider did not share an email address")
+	ErrInvalidLocale     = svcerror.New(codes.BadRequest, "locale is not a valid BCP 47 language tag")
+	ErrInvalidZoneinfo   = svcerror.New(codes.BadRequest, "time zone is not in the IANA time zone database")
+	ErrInvalidAvatar     = svcerror.New(codes.BadRequest, "picture must be a JPEG, PNG or GIF image")
+	ErrAvatarTooLarge    = svcerror.New(codes.BadRequest, "picture is too large")
+	ErrQueryTooShort     = svcerror.New(codes.BadRequest, "search query is too short")
+	ErrEmailNotVerified  = svcerror.New(codes.AccountInactive, "verify your email address before logging in")
+	ErrAccountSuspended  = svcerror.New(codes.AccountInactive, "account is suspended")
+	ErrPendingDeletion   = svcerror.New(codes.AccountInactive, "account is scheduled for deletion, restore it first")
+	ErrInvalidTransition = svcerror.New(codes.InvalidTransition, "account cannot change to that state")
 )
 
+type contextKey int
+
+const (
+	// RemoteAddrContextKey holds the IP address of the client a request came from, as a string. Transports should
+	// set it so that Authenticate can throttle password guessing per address, and the audit log can say where
+	// events came from.
+	RemoteAddrContextKey contextKey = iota
+	// UserAgentContextKey holds the User-Agent a request was made with, as a string, for the audit log.
+	UserAgentContextKey
+)
+
+// IdentityProvider is an external OpenID Connect provider users can log in with.
+type IdentityProvider struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Service manages users. Methods that change a user's account only do so while the user is active, and otherwise
+// return ErrEmailNotVerified, ErrAccountSuspended or ErrPendingDeletion.
 type Service interface {
-	GetProfile(ctx context.Context, userID uuid.UUID) (name string, err error)
+	// GetProfile returns a user's profile. Users who are suspended or pending deletion are hidden, and ErrNotFound is
+	// returned for them as for users who don't exist.
+	GetProfile(ctx context.Context, userID uuid.UUID) (profile *Profile, err error)
+	// GetProfiles looks up many users' profiles at once, as for a class roster. IDs that don't belong to a user, or
+	// that belong to a hidden one, are returned in missing rather than causing an error.
+	GetProfiles(ctx context.Context, userIDs []uuid.UUID) (profiles map[uuid.UUID]*Profile, missing []uuid.UUID, err error)
+	// SearchUsers returns a page of active users whose names contain query, or whose email address is exactly query,
+	// for finding people to invite. The cursor for the next page is empty after the last one.
+	SearchUsers(ctx context.Context, query string, limit int, cursor string) (profiles []*Profile, next string, err error)
 	GetUserInfo(ctx context.Context) (user *models.User, err error)
 	CreateUser(name, email, password string) error
 	SetName(ctx context.Context, name string) error
+	// SetProfile updates the rest of the user's profile.
+	SetProfile(ctx context.Context, update ProfileUpdate) error
+	// SetAvatar replaces the user's picture with an image of the given content type, returning its URL.
+	SetAvatar(ctx context.Context, contentType string, image io.Reader) (picture string, err error)
+	// DeleteAvatar removes the user's picture.
+	DeleteAvatar(ctx context.Context) error
+	// GetAvatar opens any user's picture at one of the sizes it is available in. Hidden users' pictures aren't found.
+	GetAvatar(ctx context.Context, userID uuid.UUID, size int) (io.ReadCloser, error)
+	// SetEmail sends a verification link to a new address. The user's email only changes once the link is followed.
 	SetEmail(ctx context.Context, email string) error
+	// SetPassword changes the user's password, logging them out everywhere.
 	SetPassword(ctx context.Context, password string) error
-	Authenticate(email string, password string) (uuid.UUID, error)
+	// Authenticate checks a user's password. After repeated failures for the same email or from the same address,
+	// it refuses to check any more passwords for a while and returns ErrTooManyAttempts. Users who aren't active
+	// can't log in, except that users pending deletion can, so that they can restore their accounts.
+	Authenticate(ctx context.Context, email string, password string) (uuid.UUID, error)
+	// DeleteUser deactivates the user and schedules them to be purged. Their picture is removed straight away, but
+	// everything else is kept until then, in case they restore their account.
 	DeleteUser(ctx context.Context) error
+	// RestoreUser cancels the user's deletion, as long as they haven't been purged yet.
+	RestoreUser(ctx context.Context) error
+	// ResetPassword emails a password reset link to the user with the given email, in the background. It does not
+	// report whether such a user exists, whether by its result or by how long it takes.
 	ResetPassword(ctx context.Context, email string) error
+	// ConfirmPasswordReset sets a new password for the user a reset token was issued to, logging them out everywhere.
+	ConfirmPasswordReset(ctx context.Context, token, password string) error
+	// VerifyEmail confirms ownership of the address a verification token was sent to and marks it verified.
+	VerifyEmail(ctx context.Context, token string) error
+	// EnrollTOTP generates a new TOTP secret for the user, returning it along with an otpauth:// URI to show as a QR
+	// code. The secret isn't used until EnableTOTP confirms it.
+	EnrollTOTP(ctx context.Context) (secret, uri string, err error)
+	// EnableTOTP turns on two-factor authentication once the user proves their app produces the right codes. It
+	// returns a fresh set of recovery codes, which are only ever available in plain text here.
+	EnableTOTP(ctx context.Context, code string) (recoveryCodes []string, err error)
+	// DisableTOTP turns off two-factor authentication, given a current code or a recovery code.
+	DisableTOTP(ctx context.Context, code string) error
+	// RegenerateRecoveryCodes replaces the user's recovery codes, given a current code or a recovery code.
+	RegenerateRecoveryCodes(ctx context.Context, code string) (recoveryCodes []string, err error)
+	// TwoFactorEnabled reports whether a user must pass VerifyTwoFactor to log in.
+	TwoFactorEnabled(ctx context.Context, userID uuid.UUID) (bool, error)
+	// VerifyTwoFactor checks the second step of a login, accepting either a TOTP code or an unused recovery code.
+	// Each code only works once, and repeated failures are throttled like passwords are.
+	VerifyTwoFactor(ctx context.Context, userID uuid.UUID, code string) error
+	// BeginPasskeyRegistration starts registering a passkey for the user, returning the options to pass to
+	// navigator.credentials.create.
+	BeginPasskeyRegistration(ctx context.Context) (*webauthn.CreationOptions, error)
+	// FinishPasskeyRegistration stores the passkey created in response to BeginPasskeyRegistration under a name the
+	// user will recognise it by.
+	FinishPasskeyRegistration(ctx context.Context, name string, response webauthn.AttestationResponse) error
+	// BeginPasskeyLogin starts logging in with a passkey, returning the options to pass to navigator.credentials.get.
+	BeginPasskeyLogin(ctx context.Context) (*webauthn.RequestOptions, error)
+	// AuthenticatePasskey checks the passkey assertion made in response to BeginPasskeyLogin and returns the user it
+	// belongs to. Passkeys verify the user themselves, so no second factor is needed.
+	AuthenticatePasskey(ctx context.Context, response webauthn.AssertionResponse) (uuid.UUID, error)
+	// IdentityProviders lists the external providers users can log in with.
+	IdentityProviders(ctx context.Context) []IdentityProvider
+	// BeginFederatedLogin starts an authorization request to an external provider, for either FederatedLogin or
+	// LinkIdentity.
+	BeginFederatedLogin(ctx context.Context, provider string) (*oidc.AuthRequest, error)
+	// FederatedLogin completes an authorization request and returns the user the external account is linked to. The
+	// first time an account is used, a new user is created for it, unless its email address already belongs to a
+	// user, in which case ErrLinkRequired is returned.
+	FederatedLogin(ctx context.Context, provider string, req oidc.AuthRequest, code string) (uuid.UUID, error)
+	// LinkIdentity completes an authorization request and links the external account to the given user.
+	LinkIdentity(ctx context.Context, userID uuid.UUID, provider string, req oidc.AuthRequest, code string) error
+	// ListIdentities returns the external accounts linked to the user.
+	ListIdentities(ctx context.Context) ([]*models.FederatedIdentity, error)
+	// UnlinkIdentity removes the user's account from the given provider, as long as they have another way to log in.
+	UnlinkIdentity(ctx context.Context, provider string) error
+	// StartExport starts making a copy of everything held about the user, returning the export to check on with
+	// GetExport. While one is being made, it is returned rather than starting another.
+	StartExport(ctx context.Context) (*Export, error)
+	// GetExport returns one of the user's exports, with a link to download it once it is ready.
+	GetExport(ctx context.Context, exportID uuid.UUID) (*Export, error)
+	// DownloadExport opens a finished export, given the token from its download link.
+	DownloadExport(ctx context.Context, exportID uuid.UUID, token string) (io.ReadCloser, error)
+	// CreateSession logs a user in to the browser, returning the token for its cookie and the latest the session can
+	// last. Sessions the user asked to remember last longer, as set by SessionLifetimes. The client's address and
+	// user agent are taken from ctx.
+	CreateSession(ctx context.Context, userID uuid.UUID, remember bool) (token string, expiresAt time.Time, err error)
+	// CheckSession returns the user a session token belongs to and when they logged in, or ErrNotFound once the
+	// session has ended, whether by timing out or being revoked.
+	CheckSession(ctx context.Context, token string) (userID uuid.UUID, authTime time.Time, err error)
+	// EndSession ends the session with the given token, if it hasn't ended already, as when logging in again replaces
+	// it. The clients used during the session are left alone.
+	EndSession(ctx context.Context, token string) error
+	// AddSessionClient records that a client is being given tokens with the given scopes during the session with the
+	// given token, so that logging out of the session logs out of the client too. It returns the session's ID, for the
+	// sid claim of ID tokens. Users pending deletion only log in to restore their accounts, so it returns
+	// ErrPendingDeletion for them unless the client is an official one, asking for nonconsentual.
+	AddSessionClient(ctx context.Context, token, clientID string, scopes []string) (sessionID uuid.UUID, err error)
+	// LogOut ends the session with the given token, like EndSession, and logs the user out of the clients used during
+	// it, revoking their consent at Hydra and sending a logout token for the session to those with a back-channel
+	// logout URI.
+	LogOut(ctx context.Context, token string) error
+	// CheckLogoutRedirect returns ErrBadRequest unless redirectURI is one of a client's redirect URIs, or is
+	// configured for it to be sent back to after logging a user out.
+	CheckLogoutRedirect(ctx context.Context, clientID, redirectURI string) error
+	// RememberedScopes returns the scopes a user has already granted a client, which they needn't be asked for again.
+	RememberedScopes(ctx context.Context, userID uuid.UUID, clientID string) ([]string, error)
+	// RememberConsent records that a user granted a client scopes, in addition to any they granted it before.
+	RememberConsent(ctx context.Context, userID uuid.UUID, clientID string, scopes []string) error
+	// ListApps returns the clients the user has granted access to, most recently granted first. Their names and URIs
+	// are left out if Hydra can't be reached.
+	ListApps(ctx context.Context) ([]*App, error)
+	// RevokeApp forgets the scopes the user granted a client, so that it has to ask for them again, revokes the
+	// consent at Hydra along with the client's tokens, and sends the client a logout token for all of the user's
+	// sessions.
+	RevokeApp(ctx context.Context, clientID string) error
+	// ListSessions returns the browsers the user is logged in with, most recently seen first.
+	ListSessions(ctx context.Context) ([]*Session, error)
+	// RevokeSession logs the user out of one of their sessions, such as on a lost device, and of the clients used
+	// during it, as LogOut does.
+	RevokeSession(ctx context.Context, sessionID uuid.UUID) error
+
+	// The following are for administrators, and transports must only allow them with the users.admin scope.
+
+	// ListUsers returns a page of users matching filter, and the cursor for the next page, which is empty after the
+	// last one.
+	ListUsers(ctx context.Context, filter UserFilter) (users []*models.User, cursor string, err error)
+	// GetUser returns any user.
+	GetUser(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	// ForcePasswordReset locks a user's password and emails them a link to choose a new one, logging them out
+	// everywhere.
+	ForcePasswordReset(ctx context.Context, userID uuid.UUID) error
+	// SetUserActive suspends a user, logging them out everywhere, or reactivates a suspended user, giving the reason
+	// for the record.
+	SetUserActive(ctx context.Context, userID uuid.UUID, active bool, reason string) error
+	// PurgeUser permanently deletes a user and everything that belongs to them.
+	PurgeUser(ctx context.Context, userID uuid.UUID) error
+	// ListStateChanges returns the changes to a user's state, oldest first, including those of users who have since
+	// been purged.
+	ListStateChanges(ctx context.Context, userID uuid.UUID) ([]*models.UserStateChange, error)
+	// ListAuditEvents returns a page of the audit log matching filter, newest first, and the cursor for the next page,
+	// which is empty after the last one.
+	ListAuditEvents(ctx context.Context, filter AuditFilter) (events []*models.AuditEvent, cursor string, err error)
+	// VerifyAuditLog checks that the audit log hasn't been tampered with, returning the ID of the first event that
+	// was changed or follows a removed one, or 0 if it is intact.
+	VerifyAuditLog(ctx context.Context) (brokenAt int64, err error)
+
+	// PurgeDeletedUsers permanently deletes the users who deleted their accounts longer ago than they can be restored.
+	// It is meant to be called periodically, not by a transport. It returns the IDs of the users it purged, even if it
+	// fails part way.
+	PurgeDeletedUsers(ctx context.Context) (purged []uuid.UUID, err error)
 }
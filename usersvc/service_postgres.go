@@ -1,74 +1,376 @@
 package usersvc
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/studiously/classsvc/classsvc"
 	"github.com/studiously/introspector"
+	"github.com/studiously/usersvc/blobstore"
+	"github.com/studiously/usersvc/hydra"
+	"github.com/studiously/usersvc/mailer"
 	"github.com/studiously/usersvc/models"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/studiously/usersvc/oidc"
+	"github.com/studiously/usersvc/totp"
+	"github.com/studiously/usersvc/webauthn"
 )
 
-func New(db *sql.DB, cs classsvc.Service) Service {
-	return &postgresService{
-		db,
-		cs,
+// Option configures the service returned by New.
+type Option func(*postgresService)
+
+// Mailer sets the mailer used to deliver password reset and email verification links. Defaults to printing messages to stdout.
+func Mailer(m mailer.Mailer) Option {
+	return func(s *postgresService) {
+		s.mailer = m
+	}
+}
+
+// PublicURL sets the externally reachable base URL of usersvc, used to build links in emails.
+func PublicURL(u string) Option {
+	return func(s *postgresService) {
+		s.publicURL = u
+	}
+}
+
+// ResetSecret sets the key used to sign password reset tokens, and from which the keys for export tokens and for
+// email addresses in the audit log are derived.
+// Defaults to a random key, which invalidates outstanding tokens whenever the service restarts, and which other
+// instances of the service don't share.
+func ResetSecret(secret []byte) Option {
+	return func(s *postgresService) {
+		s.resetSecret = secret
+	}
+}
+
+// Hasher sets the algorithm used to hash new passwords. Existing hashes made by other supported algorithms still
+// verify, and are upgraded the next time their owner logs in. Defaults to DefaultArgon2idHasher.
+func Hasher(h PasswordHasher) Option {
+	return func(s *postgresService) {
+		s.hasher = h
+	}
+}
+
+// Attempts sets the store used to track failed logins. Defaults to the login_attempts table.
+func Attempts(store AttemptStore) Option {
+	return func(s *postgresService) {
+		s.attempts = store
+	}
+}
+
+// Sessions sets the store used to keep browser sessions. Defaults to the sessions table.
+func Sessions(store SessionStore) Option {
+	return func(s *postgresService) {
+		s.sessions = store
+	}
+}
+
+// Hydra sets the client for Hydra's administrative API, which logging out uses to look up clients and sign logout
+// tokens. Without it, logging out only ends the session, and clients can't be sent back to after logging out.
+func Hydra(admin *hydra.Admin) Option {
+	return func(s *postgresService) {
+		s.hydra = admin
+	}
+}
+
+// LogoutClients sets, by client ID, where clients may be sent back to and sent logout tokens when a user logs out,
+// which the version of Hydra in use doesn't keep.
+func LogoutClients(clients map[string]ClientLogout) Option {
+	return func(s *postgresService) {
+		s.logoutClients = clients
+	}
+}
+
+// SessionLifetimes sets how long sessions last. Fields left blank take the defaults described on SessionLifetime.
+func SessionLifetimes(l SessionLifetime) Option {
+	return func(s *postgresService) {
+		s.sessionLifetime = l
+	}
+}
+
+// RelyingParty sets the domain and origins passkeys are registered for. Fields left blank default to the host and
+// origin of the public URL.
+func RelyingParty(rp webauthn.RelyingParty) Option {
+	return func(s *postgresService) {
+		s.rp = &rp
+	}
+}
+
+// Providers sets the external OpenID Connect providers users can log in with. Providers without a redirect URL are
+// given one under the public URL.
+func Providers(providers ...*oidc.Provider) Option {
+	return func(s *postgresService) {
+		s.providers = providers
+	}
+}
+
+// Blobs sets where uploaded files, such as pictures, are stored. Defaults to a directory under the system's temporary
+// directory, which is only fit for development.
+func Blobs(store blobstore.BlobStore) Option {
+	return func(s *postgresService) {
+		s.blobs = store
+	}
+}
+
+// ResetTTL sets how long password reset tokens remain valid. Defaults to one hour.
+func ResetTTL(ttl time.Duration) Option {
+	return func(s *postgresService) {
+		s.resetTTL = ttl
+	}
+}
+
+// ExportTTL sets how long a finished export can be downloaded for. Defaults to 24 hours.
+func ExportTTL(ttl time.Duration) Option {
+	return func(s *postgresService) {
+		s.exportTTL = ttl
+	}
+}
+
+// DeletionRetention sets how long deleted users are kept, during which they can restore their accounts, before
+// PurgeDeletedUsers removes them for good. Defaults to 30 days.
+func DeletionRetention(retention time.Duration) Option {
+	return func(s *postgresService) {
+		s.deletionRetention = retention
+	}
+}
+
+// ExportSections adds sections to users' exports, such as data kept by other parts of the service.
+func ExportSections(sections ...ExportSection) Option {
+	return func(s *postgresService) {
+		s.exportSections = append(s.exportSections, sections...)
+	}
+}
+
+func New(db *sql.DB, cs classsvc.Service, options ...Option) Service {
+	s := &postgresService{
+		DB:        db,
+		cs:        cs,
+		mailer:    mailer.NewWriter(os.Stdout),
+		publicURL: "http://localhost:8080",
+		resetTTL:  time.Hour,
+		exportTTL: 24 * time.Hour,
+		hasher:    DefaultArgon2idHasher,
+
+		deletionRetention: 30 * 24 * time.Hour,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	if s.blobs == nil {
+		s.blobs = blobstore.NewDir(filepath.Join(os.TempDir(), "usersvc"))
+	}
+	if s.attempts == nil {
+		s.attempts = NewPostgresAttemptStore(db)
+	}
+	if s.sessions == nil {
+		s.sessions = NewPostgresSessionStore(db)
+	}
+	if s.sessionLifetime.Absolute == 0 {
+		s.sessionLifetime.Absolute = defaultSessionLifetime.Absolute
+	}
+	if s.sessionLifetime.Idle == 0 {
+		s.sessionLifetime.Idle = defaultSessionLifetime.Idle
+	}
+	if s.sessionLifetime.RememberAbsolute == 0 {
+		s.sessionLifetime.RememberAbsolute = defaultSessionLifetime.RememberAbsolute
+	}
+	if s.sessionLifetime.RememberIdle == 0 {
+		s.sessionLifetime.RememberIdle = defaultSessionLifetime.RememberIdle
+	}
+	rp := defaultRelyingParty(s.publicURL)
+	if s.rp == nil {
+		s.rp = &rp
+	}
+	if s.rp.ID == "" {
+		s.rp.ID = rp.ID
+	}
+	if s.rp.Name == "" {
+		s.rp.Name = rp.Name
+	}
+	if len(s.rp.Origins) == 0 {
+		s.rp.Origins = rp.Origins
+	}
+	for _, p := range s.providers {
+		if p.RedirectURL == "" {
+			p.RedirectURL = s.publicURL + "/oidc/" + p.ID + "/callback"
+		}
 	}
+	if len(s.resetSecret) == 0 {
+		s.resetSecret = make([]byte, 32)
+		// Signing with a predictable key would let anyone forge reset links.
+		if _, err := rand.Read(s.resetSecret); err != nil {
+			panic("usersvc: cannot generate reset secret: " + err.Error())
+		}
+	}
+	s.exportSecret = deriveSecret(s.resetSecret, "export")
+	s.auditSecret = deriveSecret(s.resetSecret, "audit")
+	return s
+}
+
+// deriveSecret derives a key for one purpose from the reset secret, so that the reset secret itself never signs
+// anything a user can see, and a token made for one purpose can't pass for another.
+func deriveSecret(resetSecret []byte, purpose string) []byte {
+	h := hmac.New(sha256.New, resetSecret)
+	h.Write([]byte(purpose))
+	return h.Sum(nil)
 }
 
 type postgresService struct {
 	*sql.DB
-	cs classsvc.Service
+	cs          classsvc.Service
+	mailer      mailer.Mailer
+	publicURL   string
+	resetSecret []byte
+	resetTTL    time.Duration
+	hasher      PasswordHasher
+	attempts    AttemptStore
+	sessions    SessionStore
+	// sessionLifetime is how long sessions last.
+	sessionLifetime SessionLifetime
+	hydra           *hydra.Admin
+	logoutClients   map[string]ClientLogout
+	rp              *webauthn.RelyingParty
+	providers       []*oidc.Provider
+	blobs           blobstore.BlobStore
+	exportTTL       time.Duration
+	exportSecret    []byte
+	auditSecret     []byte
+	// deletionRetention is how long deleted users are kept before they are purged.
+	deletionRetention time.Duration
+	// exportSections are added to the built-in sections of every export.
+	exportSections []ExportSection
 }
 
-func (s *postgresService) GetProfile(ctx context.Context, userID uuid.UUID) (string, error) {
+func (s *postgresService) GetProfile(ctx context.Context, userID uuid.UUID) (*Profile, error) {
 	user, err := models.UserByID(s.DB, userID)
-	if err != nil {
-		return "", err
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
 	}
-	return user.Name, nil
+	if !visible(user.State) {
+		return nil, ErrNotFound
+	}
+	return profileOf(user), nil
 }
 
-func (s *postgresService) GetUserInfo(ctx context.Context) (*models.User, error) {
-	return models.UserByID(s, subj(ctx))
-}
+// maxBatchSize caps how many users GetProfiles looks up at once.
+const maxBatchSize = 500
 
-func (s *postgresService) CreateUser(name, email, password string) (err error) {
-	if u, _ := models.UserByEmail(s, email); u != nil && u.Exists() {
-		return ErrUserExists
+func (s *postgresService) GetProfiles(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*Profile, []uuid.UUID, error) {
+	if len(userIDs) > maxBatchSize {
+		return nil, nil, ErrBadRequest
 	}
-	tx, err := s.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault, ReadOnly: false})
-	u := &models.User{
-		ID:     uuid.New(),
-		Name:   name,
-		Email:  email,
-		Active: true,
+	ids := make(pq.StringArray, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
 	}
-	err = u.Insert(tx)
+	rows, err := s.Query(`SELECT `+profileColumns+` FROM users WHERE id = ANY($1::uuid[]) AND state <> ALL($2::text[])`,
+		ids, pq.StringArray(hiddenStates))
 	if err != nil {
-		tx.Rollback()
-		return err
+		return nil, nil, err
 	}
-	li, err := models.LocalIdentityByUserID(s.DB, u.ID)
+	defer rows.Close()
+	profiles := make(map[uuid.UUID]*Profile, len(userIDs))
+	for rows.Next() {
+		var p Profile
+		if err := rows.Scan(&p.ID, &p.Name, &p.GivenName, &p.FamilyName, &p.Pronouns, &p.Picture, &p.Locale, &p.Zoneinfo); err != nil {
+			return nil, nil, err
+		}
+		profiles[p.ID] = &p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	missing := []uuid.UUID{}
+	for _, id := range userIDs {
+		if _, ok := profiles[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return profiles, missing, nil
+}
+
+func (s *postgresService) SearchUsers(ctx context.Context, query string, limit int, cursor string) ([]*Profile, string, error) {
+	limit = pageLimit(limit)
+	q, args, err := searchQuery(query, limit+1, cursor)
 	if err != nil {
-		li = &models.LocalIdentity{
-			UserID: u.ID,
+		return nil, "", err
+	}
+	rows, err := s.Query(q, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	profiles := []*Profile{}
+	for rows.Next() {
+		var p Profile
+		if err := rows.Scan(&p.ID, &p.Name, &p.GivenName, &p.FamilyName, &p.Pronouns, &p.Picture, &p.Locale, &p.Zoneinfo); err != nil {
+			return nil, "", err
 		}
+		profiles = append(profiles, &p)
 	}
-	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+	profiles, next := nextSearchPage(profiles, limit)
+	return profiles, next, nil
+}
+
+func (s *postgresService) GetUserInfo(ctx context.Context) (*models.User, error) {
+	return models.UserByID(s, subj(ctx))
+}
+
+func (s *postgresService) CreateUser(name, email, password string) error {
+	hashed, err := s.hasher.Hash(password)
 	if err != nil {
-		tx.Rollback()
 		return ErrHashFailed
 	}
-	li.Password = string(hashed)
-	err = li.Upsert(s)
+	u := &models.User{
+		ID:    uuid.New(),
+		Name:  name,
+		Email: email,
+		State: StatePendingVerification,
+	}
+	var token string
+	err = transact(context.Background(), s.DB, func(tx *sql.Tx) error {
+		// The unique index on email decides whether the address is taken; checking beforehand would race.
+		if err := u.Insert(tx); err != nil {
+			if _, ok := uniqueViolation(err); ok {
+				return ErrUserExists
+			}
+			return err
+		}
+		li := &models.LocalIdentity{
+			UserID:   u.ID,
+			Password: hashed,
+		}
+		if err := li.Upsert(tx); err != nil {
+			return err
+		}
+		var err error
+		if token, err = s.createVerification(tx, u.ID, email); err != nil {
+			return err
+		}
+		return writeAudit(context.Background(), tx, AuditUserCreated, &u.ID, &u.ID, map[string]string{"method": "password"})
+	})
 	if err != nil {
-		tx.Rollback()
 		return err
 	}
-	return tx.Commit()
+	return s.sendVerification(name, email, token)
 }
 
 func (s *postgresService) SetName(ctx context.Context, name string) error {
@@ -76,51 +378,236 @@ func (s *postgresService) SetName(ctx context.Context, name string) error {
 	if err != nil {
 		return err
 	}
+	if err := stateErr(user.State); err != nil {
+		return err
+	}
 	user.Name = name
 	return user.Update(s)
 }
 
-func (s *postgresService) SetEmail(ctx context.Context, email string) error {
+func (s *postgresService) SetProfile(ctx context.Context, update ProfileUpdate) error {
 	user, err := models.UserByID(s, subj(ctx))
 	if err != nil {
 		return err
 	}
-	user.Email = email
+	if err := stateErr(user.State); err != nil {
+		return err
+	}
+	if err := update.apply(user); err != nil {
+		return err
+	}
 	return user.Update(s)
 }
 
+func (s *postgresService) SetAvatar(ctx context.Context, contentType string, image io.Reader) (string, error) {
+	if err := s.requireActive(subj(ctx)); err != nil {
+		return "", err
+	}
+	avatars, err := makeAvatars(contentType, image)
+	if err != nil {
+		return "", err
+	}
+	userID := subj(ctx)
+	for i, size := range avatarSizes {
+		if err := s.blobs.Put(ctx, avatarKey(userID, size), bytes.NewReader(avatars[i])); err != nil {
+			return "", err
+		}
+	}
+	// The version stops browsers and proxies from showing a cached copy of the old picture.
+	picture := fmt.Sprintf("%s/avatars/%s/%d?v=%d", s.publicURL, userID, avatarSizes[0], time.Now().Unix())
+	if _, err := s.Exec(`UPDATE users SET picture = $1 WHERE id = $2`, picture, userID); err != nil {
+		return "", err
+	}
+	return picture, nil
+}
+
+func (s *postgresService) DeleteAvatar(ctx context.Context) error {
+	if err := s.requireActive(subj(ctx)); err != nil {
+		return err
+	}
+	if _, err := s.Exec(`UPDATE users SET picture = '' WHERE id = $1`, subj(ctx)); err != nil {
+		return err
+	}
+	return s.deleteAvatar(ctx, subj(ctx))
+}
+
+func (s *postgresService) GetAvatar(ctx context.Context, userID uuid.UUID, size int) (io.ReadCloser, error) {
+	for _, sz := range avatarSizes {
+		if sz != size {
+			continue
+		}
+		state, err := s.state(userID)
+		if err != nil {
+			return nil, err
+		}
+		if !visible(state) {
+			return nil, ErrNotFound
+		}
+		avatar, err := s.blobs.Get(ctx, avatarKey(userID, size))
+		if err == blobstore.ErrNotFound {
+			return nil, ErrNotFound
+		}
+		return avatar, err
+	}
+	return nil, ErrNotFound
+}
+
+// deleteAvatar removes every size of a user's picture from storage.
+func (s *postgresService) deleteAvatar(ctx context.Context, userID uuid.UUID) error {
+	for _, size := range avatarSizes {
+		if err := s.blobs.Delete(ctx, avatarKey(userID, size)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *postgresService) SetEmail(ctx context.Context, email string) error {
+	user, err := models.UserByID(s, subj(ctx))
+	if err != nil {
+		return err
+	}
+	if err := stateErr(user.State); err != nil {
+		return err
+	}
+	if user.Email == email {
+		return nil
+	}
+	// Fail early if the address is taken. The unique index is still the final word when the change is confirmed.
+	if other, err := models.UserByEmail(s, email); err == nil && other.ID != user.ID {
+		return ErrEmailInUse
+	} else if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	var token string
+	err = transact(ctx, s.DB, func(tx *sql.Tx) error {
+		// Only the most recently requested address can be confirmed.
+		if _, err := tx.Exec(`DELETE FROM email_verifications WHERE user_id = $1`, user.ID); err != nil {
+			return err
+		}
+		var err error
+		if token, err = s.createVerification(tx, user.ID, email); err != nil {
+			return err
+		}
+		return writeAudit(ctx, tx, AuditEmailChangeRequested, &user.ID, &user.ID, map[string]string{
+			"from": s.auditEmail(user.Email),
+			"to":   s.auditEmail(email),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return s.sendVerification(user.Name, email, token)
+}
+
 func (s *postgresService) SetPassword(ctx context.Context, password string) error {
+	if err := s.requireActive(subj(ctx)); err != nil {
+		return err
+	}
 	li, err := models.LocalIdentityByUserID(s.DB, subj(ctx))
 	if err != nil {
 		li = &models.LocalIdentity{
 			UserID: subj(ctx),
 		}
 	}
-	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashed, err := s.hasher.Hash(password)
 	if err != nil {
 		return ErrHashFailed
 	}
-	li.Password = string(hashed)
-	err = li.Upsert(s)
-	return err
+	li.Password = hashed
+	err = transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := li.Upsert(tx); err != nil {
+			return err
+		}
+		return writeAudit(ctx, tx, AuditPasswordChanged, &li.UserID, &li.UserID, nil)
+	})
+	if err != nil {
+		return err
+	}
+	return s.sessions.DeleteAll(li.UserID)
 }
 
-func (s *postgresService) Authenticate(email string, password string) (userID uuid.UUID, err error) {
-	u, err := models.UserByEmail(s.DB, email)
-	if err != nil {
-		return
+func (s *postgresService) Authenticate(ctx context.Context, email string, password string) (userID uuid.UUID, err error) {
+	type limit struct {
+		t   throttle
+		key string
 	}
-	if !u.Exists() {
+	var subject *uuid.UUID
+	defer func() {
+		if aerr := s.auditLogin(ctx, "password", subject, map[string]string{"email": s.auditEmail(email)}, err); aerr != nil && err == nil {
+			userID, err = uuid.Nil, aerr
+		}
+	}()
+	now := time.Now()
+	limits := []limit{{accountThrottle, accountKey(email)}}
+	if ip := remoteAddr(ctx); ip != "" {
+		limits = append(limits, limit{addrThrottle, addrKey(ip)})
+	}
+	for _, l := range limits {
+		wait, err := l.t.wait(s.attempts, l.key, now)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if wait > 0 {
+			return uuid.Nil, ErrTooManyAttempts
+		}
+	}
+	defer func() {
+		switch err {
+		case ErrWrongEmail, ErrWrongPassword:
+			// Count unknown addresses against the email too, so lockouts don't reveal which accounts exist.
+			for _, l := range limits {
+				l.t.fail(s.attempts, l.key, now)
+			}
+		case nil:
+			// Only the account is forgiven. Logging into one's own account shouldn't clear an address that is
+			// guessing at others.
+			s.attempts.Reset(limits[0].key)
+		}
+	}()
+
+	u, err := models.UserByEmail(s.DB, email)
+	switch err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		// Spend about as long as checking a real password would, so response times don't give the game away.
+		s.hasher.Hash(password)
 		return uuid.Nil, ErrWrongEmail
+	default:
+		return uuid.Nil, err
 	}
+	subject = &u.ID
 	li, err := models.LocalIdentityByUserID(s.DB, u.ID)
-	if err != nil {
-		return
+	switch err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		// Users who signed up with another provider have no password until they set one.
+		s.hasher.Hash(password)
+		return uuid.Nil, ErrWrongPassword
+	default:
+		return uuid.Nil, err
 	}
-	err = bcrypt.CompareHashAndPassword([]byte(li.Password), []byte(password))
+	ok, rehash, err := verifyPassword(s.hasher, li.Password, password)
 	if err != nil {
+		return uuid.Nil, err
+	}
+	if !ok {
 		return uuid.Nil, ErrWrongPassword
 	}
+	// Only once the password is known to be right, so the state of an account isn't given away to anyone who asks.
+	if err := loginErr(u.State); err != nil {
+		return uuid.Nil, err
+	}
+	if rehash {
+		// The password is known to be right, so this is our only chance to upgrade the hash to the current
+		// algorithm and costs. Failing to do so shouldn't stop the user from logging in.
+		if hashed, err := s.hasher.Hash(password); err == nil {
+			s.Exec(`UPDATE local_identities SET password = $1 WHERE user_id = $2 AND password = $3`,
+				hashed, li.UserID, li.Password)
+		}
+	}
 	return u.ID, nil
 }
 
@@ -148,24 +635,1294 @@ func (s *postgresService) DeleteUser(ctx context.Context) error {
 		}
 	}
 
-	u.Active = false
-	return u.Save(s)
+	switch u.State {
+	case StatePendingDeletion:
+		// Deleting again mustn't put off the purge.
+		return nil
+	case StateSuspended:
+		return ErrInvalidTransition
+	}
+	err = transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if _, err := changeState(ctx, tx, u.ID, StatePendingDeletion, "deleted by the user", actorOf(ctx)); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`UPDATE users SET deleted_at = now(), picture = '' WHERE id = $1`, u.ID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return s.deleteAvatar(ctx, u.ID)
+}
+
+func (s *postgresService) RestoreUser(ctx context.Context) error {
+	userID := subj(ctx)
+	return transact(ctx, s.DB, func(tx *sql.Tx) error {
+		var deletedAt *time.Time
+		var state string
+		err := tx.QueryRow(`SELECT deleted_at, state FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&deletedAt, &state)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		} else if err != nil {
+			return err
+		}
+		if deletedAt == nil || !deletedAt.After(time.Now().Add(-s.deletionRetention)) {
+			return ErrNotFound
+		}
+		if state == StateSuspended {
+			return ErrAccountSuspended
+		}
+		to, err := previousState(tx, userID, StatePendingDeletion)
+		if err != nil {
+			return err
+		}
+		if _, err := changeState(ctx, tx, userID, to, "restored by the user", actorOf(ctx)); err != nil {
+			return err
+		}
+		_, err = tx.Exec(`UPDATE users SET deleted_at = NULL WHERE id = $1`, userID)
+		return err
+	})
 }
 
 func (s *postgresService) ResetPassword(ctx context.Context, email string) error {
-	//u, err := models.UserByEmail(s, email)
-	//switch err {
-	//case nil:
-	//	break
-	//case sql.ErrNoRows:
-	//	return nil
-	//default:
-	//	return err
-	//}
-	panic("unimplemented")
+	// The link is made and mailed in the background, or else how long this takes would tell the caller whether the
+	// address is registered. Failing to send it can't be reported for the same reason.
+	go s.mailPasswordReset(detached{ctx}, email)
 	return nil
 }
 
-func subj(ctx context.Context) uuid.UUID {
-	return ctx.Value(introspector.SubjectContextKey).(uuid.UUID)
+// mailPasswordReset emails a password reset link to the user with the given email, if they can use one.
+func (s *postgresService) mailPasswordReset(ctx context.Context, email string) error {
+	u, err := models.UserByEmail(s, email)
+	switch err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		return nil
+	default:
+		return err
+	}
+	if u.State == StateSuspended {
+		// A new password wouldn't let them log in.
+		return nil
+	}
+	li, err := models.LocalIdentityByUserID(s, u.ID)
+	switch err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		return nil
+	default:
+		return err
+	}
+	// Anyone can ask, so there is no actor.
+	if err := s.audit(ctx, AuditPasswordResetRequested, nil, &u.ID, nil); err != nil {
+		return err
+	}
+	token := signResetToken(s.resetSecret, li, time.Now().Add(s.resetTTL))
+	return s.mailer.Send(mailer.Message{
+		To:      u.Email,
+		Subject: "Reset your Studiously password",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\n"+
+			"Someone asked to reset the password for your Studiously account. If it was you, follow the link below "+
+			"within %s to choose a new one:\r\n\r\n%s/reset/confirm?token=%s\r\n\r\n"+
+			"If it wasn't you, you can ignore this email and your password will stay the same.",
+			u.Name, s.resetTTL, s.publicURL, url.QueryEscape(token)),
+	})
+}
+
+func (s *postgresService) ConfirmPasswordReset(ctx context.Context, token, password string) error {
+	userID, payload, mac, err := parseResetToken(token)
+	if err != nil {
+		return err
+	}
+	li, err := models.LocalIdentityByUserID(s, userID)
+	switch err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		return ErrInvalidToken
+	default:
+		return err
+	}
+	if err := verifyResetToken(s.resetSecret, li, payload, mac, time.Now()); err != nil {
+		return err
+	}
+	hashed, err := s.hasher.Hash(password)
+	if err != nil {
+		return ErrHashFailed
+	}
+	err = transact(ctx, s.DB, func(tx *sql.Tx) error {
+		// Only rotate the hash if nobody else has redeemed the token in the meantime.
+		res, err := tx.Exec(`UPDATE local_identities SET password = $1 WHERE user_id = $2 AND password = $3`,
+			hashed, li.UserID, li.Password)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return ErrInvalidToken
+		}
+		return writeAudit(ctx, tx, AuditPasswordChanged, &li.UserID, &li.UserID, map[string]string{"method": "reset"})
+	})
+	if err != nil {
+		return err
+	}
+	return s.sessions.DeleteAll(li.UserID)
+}
+
+func (s *postgresService) VerifyEmail(ctx context.Context, token string) error {
+	ev, err := models.EmailVerificationByTokenHash(s, hashVerificationToken(token))
+	switch err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		return ErrInvalidToken
+	default:
+		return err
+	}
+	if time.Now().After(ev.ExpiresAt) {
+		ev.Delete(s)
+		return ErrInvalidToken
+	}
+	return transact(ctx, s.DB, func(tx *sql.Tx) error {
+		u, err := ev.User(tx)
+		if err != nil {
+			return err
+		}
+		u.Email = ev.Email
+		u.EmailVerified = true
+		if err := u.Update(tx); err != nil {
+			return translateUserErr(err)
+		}
+		if err := writeAudit(ctx, tx, AuditEmailVerified, &u.ID, &u.ID, map[string]string{"email": s.auditEmail(ev.Email)}); err != nil {
+			return err
+		}
+		if u.State == StatePendingVerification {
+			if _, err := changeState(ctx, tx, u.ID, StateActive, "verified their email address", &u.ID); err != nil {
+				return err
+			}
+		}
+		_, err = tx.Exec(`DELETE FROM email_verifications WHERE user_id = $1`, u.ID)
+		return err
+	})
+}
+
+func (s *postgresService) EnrollTOTP(ctx context.Context) (string, string, error) {
+	user, err := models.UserByID(s, subj(ctx))
+	if err != nil {
+		return "", "", err
+	}
+	if err := stateErr(user.State); err != nil {
+		return "", "", err
+	}
+	tc, err := models.TotpCredentialByUserID(s, user.ID)
+	switch err {
+	case nil:
+		if tc.Enabled {
+			return "", "", ErrTwoFactorEnabled
+		}
+	case sql.ErrNoRows:
+		tc = &models.TotpCredential{
+			UserID: user.ID,
+		}
+	default:
+		return "", "", err
+	}
+	// Starting over with a new secret is fine until one has been confirmed.
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return "", "", err
+	}
+	tc.Secret = secret
+	tc.LastStep = 0
+	if err := tc.Save(s); err != nil {
+		return "", "", err
+	}
+	return secret, totp.URI(secret, totpIssuer, user.Email), nil
+}
+
+func (s *postgresService) EnableTOTP(ctx context.Context, code string) ([]string, error) {
+	if err := s.requireActive(subj(ctx)); err != nil {
+		return nil, err
+	}
+	tc, err := models.TotpCredentialByUserID(s, subj(ctx))
+	switch err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+	if tc.Enabled {
+		return nil, ErrTwoFactorEnabled
+	}
+	if err := s.checkSecondFactor(tc, code); err != nil {
+		return nil, err
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	err = transact(ctx, s.DB, func(tx *sql.Tx) error {
+		// The secret that was checked must still be the one being enabled, in case of a concurrent EnrollTOTP.
+		res, err := tx.Exec(`UPDATE totp_credentials SET enabled = TRUE WHERE user_id = $1 AND secret = $2`,
+			tc.UserID, tc.Secret)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return ErrWrongCode
+		}
+		if err := replaceRecoveryCodes(tx, tc.UserID, hashes); err != nil {
+			return err
+		}
+		return writeAudit(ctx, tx, AuditTwoFactorEnabled, &tc.UserID, &tc.UserID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *postgresService) DisableTOTP(ctx context.Context, code string) error {
+	if err := s.requireActive(subj(ctx)); err != nil {
+		return err
+	}
+	tc, err := s.enabledTOTP(subj(ctx))
+	if err != nil {
+		return err
+	}
+	if err := s.checkSecondFactor(tc, code); err != nil {
+		return err
+	}
+	return transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM recovery_codes WHERE user_id = $1`, tc.UserID); err != nil {
+			return err
+		}
+		if err := tc.Delete(tx); err != nil {
+			return err
+		}
+		return writeAudit(ctx, tx, AuditTwoFactorDisabled, &tc.UserID, &tc.UserID, nil)
+	})
+}
+
+func (s *postgresService) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	if err := s.requireActive(subj(ctx)); err != nil {
+		return nil, err
+	}
+	tc, err := s.enabledTOTP(subj(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSecondFactor(tc, code); err != nil {
+		return nil, err
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	err = transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := replaceRecoveryCodes(tx, tc.UserID, hashes); err != nil {
+			return err
+		}
+		return writeAudit(ctx, tx, AuditRecoveryCodesRegenerated, &tc.UserID, &tc.UserID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *postgresService) TwoFactorEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	tc, err := models.TotpCredentialByUserID(s, userID)
+	switch err {
+	case nil:
+		return tc.Enabled, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (s *postgresService) VerifyTwoFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	tc, err := s.enabledTOTP(userID)
+	if err != nil {
+		return err
+	}
+	err = s.checkSecondFactor(tc, code)
+	if aerr := s.auditLogin(ctx, "totp", &userID, nil, err); aerr != nil && err == nil {
+		return aerr
+	}
+	return err
+}
+
+func (s *postgresService) BeginPasskeyRegistration(ctx context.Context) (*webauthn.CreationOptions, error) {
+	user, err := models.UserByID(s, subj(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if err := stateErr(user.State); err != nil {
+		return nil, err
+	}
+	creds, err := models.WebauthnCredentialsByUserID(s, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	var exclude [][]byte
+	for _, cred := range creds {
+		exclude = append(exclude, cred.ID)
+	}
+	challenge, err := newChallenge(s, &user.ID)
+	if err != nil {
+		return nil, err
+	}
+	opts := s.rp.CreationOptions(webauthn.User{
+		ID:          user.ID[:],
+		Name:        user.Email,
+		DisplayName: user.Name,
+	}, challenge, exclude)
+	return &opts, nil
+}
+
+func (s *postgresService) FinishPasskeyRegistration(ctx context.Context, name string, response webauthn.AttestationResponse) error {
+	userID := subj(ctx)
+	if err := s.requireActive(userID); err != nil {
+		return err
+	}
+	challenge, err := consumeChallenge(s, response.Response.ClientDataJSON, &userID)
+	if err != nil {
+		return err
+	}
+	cred, err := s.rp.VerifyRegistration(response, challenge)
+	if err != nil {
+		return ErrInvalidCredential
+	}
+	wc := &models.WebauthnCredential{
+		ID:        cred.ID,
+		UserID:    userID,
+		PublicKey: cred.PublicKey,
+		SignCount: int64(cred.SignCount),
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	return transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := wc.Insert(tx); err != nil {
+			if _, ok := uniqueViolation(err); ok {
+				return ErrInvalidCredential
+			}
+			return err
+		}
+		return writeAudit(ctx, tx, AuditPasskeyAdded, &userID, &userID, map[string]string{"name": name})
+	})
+}
+
+func (s *postgresService) BeginPasskeyLogin(ctx context.Context) (*webauthn.RequestOptions, error) {
+	challenge, err := newChallenge(s, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Passkeys are discoverable, so there's no need to ask who the user is first.
+	opts := s.rp.RequestOptions(challenge, nil)
+	return &opts, nil
+}
+
+func (s *postgresService) AuthenticatePasskey(ctx context.Context, response webauthn.AssertionResponse) (userID uuid.UUID, err error) {
+	var subject *uuid.UUID
+	defer func() {
+		if aerr := s.auditLogin(ctx, "passkey", subject, nil, err); aerr != nil && err == nil {
+			userID, err = uuid.Nil, aerr
+		}
+	}()
+	challenge, err := consumeChallenge(s, response.Response.ClientDataJSON, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	wc, err := models.WebauthnCredentialByID(s, response.RawID)
+	switch err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		return uuid.Nil, ErrInvalidCredential
+	default:
+		return uuid.Nil, err
+	}
+	if len(response.Response.UserHandle) > 0 && !bytes.Equal(response.Response.UserHandle, wc.UserID[:]) {
+		return uuid.Nil, ErrInvalidCredential
+	}
+	subject = &wc.UserID
+	count, err := s.rp.VerifyAssertion(response, challenge, webauthn.Credential{
+		ID:        wc.ID,
+		PublicKey: wc.PublicKey,
+		SignCount: uint32(wc.SignCount),
+	})
+	if err != nil {
+		return uuid.Nil, ErrInvalidCredential
+	}
+	// Losing a race with another login using the same credential means one of them is a clone.
+	res, err := s.Exec(`UPDATE webauthn_credentials SET sign_count = $1 WHERE id = $2 AND sign_count = $3`,
+		int64(count), wc.ID, wc.SignCount)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return uuid.Nil, err
+	} else if n == 0 {
+		return uuid.Nil, ErrInvalidCredential
+	}
+	if err := s.canLogIn(wc.UserID); err != nil {
+		return uuid.Nil, err
+	}
+	return wc.UserID, nil
+}
+
+func (s *postgresService) IdentityProviders(ctx context.Context) []IdentityProvider {
+	var providers []IdentityProvider
+	for _, p := range s.providers {
+		providers = append(providers, IdentityProvider{ID: p.ID, Name: p.Name})
+	}
+	return providers
+}
+
+func (s *postgresService) BeginFederatedLogin(ctx context.Context, provider string) (*oidc.AuthRequest, error) {
+	p := s.provider(provider)
+	if p == nil {
+		return nil, ErrNotFound
+	}
+	return p.NewAuthRequest(ctx)
+}
+
+func (s *postgresService) FederatedLogin(ctx context.Context, provider string, req oidc.AuthRequest, code string) (userID uuid.UUID, err error) {
+	p := s.provider(provider)
+	if p == nil {
+		return uuid.Nil, ErrNotFound
+	}
+	var subject *uuid.UUID
+	defer func() {
+		if aerr := s.auditLogin(ctx, "oidc", subject, map[string]string{"provider": provider}, err); aerr != nil && err == nil {
+			userID, err = uuid.Nil, aerr
+		}
+	}()
+	claims, err := p.Exchange(ctx, req, code)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	fi, err := models.FederatedIdentityByProviderSubject(s, provider, claims.Subject)
+	switch err {
+	case nil:
+		subject = &fi.UserID
+		if err := s.canLogIn(fi.UserID); err != nil {
+			return uuid.Nil, err
+		}
+		return fi.UserID, nil
+	case sql.ErrNoRows:
+		break
+	default:
+		return uuid.Nil, err
+	}
+
+	// First time with this account, so sign up.
+	if claims.Email == "" {
+		return uuid.Nil, ErrEmailRequired
+	}
+	if _, err := models.UserByEmail(s, claims.Email); err == nil {
+		// Taking over an existing user just because a provider claims the same address would let anyone who can
+		// register that address elsewhere into the account.
+		return uuid.Nil, ErrLinkRequired
+	} else if err != sql.ErrNoRows {
+		return uuid.Nil, err
+	}
+	name := claims.Name
+	if name == "" {
+		name = claims.Email
+	}
+	u := &models.User{
+		ID:            uuid.New(),
+		Name:          name,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		State:         StateActive,
+	}
+	if !u.EmailVerified {
+		u.State = StatePendingVerification
+	}
+	var token string
+	err = transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := u.Insert(tx); err != nil {
+			if _, ok := uniqueViolation(err); ok {
+				return ErrLinkRequired
+			}
+			return err
+		}
+		fi := &models.FederatedIdentity{
+			Provider:  provider,
+			Subject:   claims.Subject,
+			UserID:    u.ID,
+			Email:     claims.Email,
+			CreatedAt: time.Now(),
+		}
+		if err := fi.Insert(tx); err != nil {
+			if _, ok := uniqueViolation(err); ok {
+				return ErrIdentityInUse
+			}
+			return err
+		}
+		if err := writeAudit(ctx, tx, AuditUserCreated, &u.ID, &u.ID, map[string]string{
+			"method":   "oidc",
+			"provider": provider,
+		}); err != nil {
+			return err
+		}
+		if u.EmailVerified {
+			return nil
+		}
+		var err error
+		token, err = s.createVerification(tx, u.ID, u.Email)
+		return err
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	subject = &u.ID
+	if token != "" {
+		if err := s.sendVerification(u.Name, u.Email, token); err != nil {
+			return uuid.Nil, err
+		}
+		return uuid.Nil, ErrEmailNotVerified
+	}
+	return u.ID, nil
+}
+
+func (s *postgresService) LinkIdentity(ctx context.Context, userID uuid.UUID, provider string, req oidc.AuthRequest, code string) error {
+	if err := s.requireActive(userID); err != nil {
+		return err
+	}
+	p := s.provider(provider)
+	if p == nil {
+		return ErrNotFound
+	}
+	claims, err := p.Exchange(ctx, req, code)
+	if err != nil {
+		return err
+	}
+	fi, err := models.FederatedIdentityByProviderSubject(s, provider, claims.Subject)
+	switch err {
+	case nil:
+		if fi.UserID == userID {
+			return nil
+		}
+		return ErrIdentityInUse
+	case sql.ErrNoRows:
+		break
+	default:
+		return err
+	}
+	fi = &models.FederatedIdentity{
+		Provider:  provider,
+		Subject:   claims.Subject,
+		UserID:    userID,
+		Email:     claims.Email,
+		CreatedAt: time.Now(),
+	}
+	return transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := fi.Insert(tx); err != nil {
+			// Either someone else linked the account first, or the user already has a different one from this
+			// provider.
+			if _, ok := uniqueViolation(err); ok {
+				return ErrIdentityInUse
+			}
+			return err
+		}
+		return writeAudit(ctx, tx, AuditIdentityLinked, &userID, &userID, map[string]string{"provider": provider})
+	})
+}
+
+func (s *postgresService) ListIdentities(ctx context.Context) ([]*models.FederatedIdentity, error) {
+	return models.FederatedIdentitiesByUserID(s, subj(ctx))
+}
+
+func (s *postgresService) UnlinkIdentity(ctx context.Context, provider string) error {
+	userID := subj(ctx)
+	if err := s.requireActive(userID); err != nil {
+		return err
+	}
+	return transact(ctx, s.DB, func(tx *sql.Tx) error {
+		// Lock the user so that two concurrent unlinks can't each leave the other as the last way in.
+		if _, err := tx.Exec(`SELECT 1 FROM users WHERE id = $1 FOR UPDATE`, userID); err != nil {
+			return err
+		}
+		fi, err := models.FederatedIdentityByUserIDProvider(tx, userID, provider)
+		switch err {
+		case nil:
+			break
+		case sql.ErrNoRows:
+			return ErrNotFound
+		default:
+			return err
+		}
+		var others int
+		err = tx.QueryRow(`SELECT `+
+			`(SELECT count(*) FROM local_identities WHERE user_id = $1) + `+
+			`(SELECT count(*) FROM webauthn_credentials WHERE user_id = $1) + `+
+			`(SELECT count(*) FROM federated_identities WHERE user_id = $1 AND provider <> $2)`,
+			userID, provider).Scan(&others)
+		if err != nil {
+			return err
+		}
+		if others == 0 {
+			return ErrLastIdentity
+		}
+		if err := fi.Delete(tx); err != nil {
+			return err
+		}
+		return writeAudit(ctx, tx, AuditIdentityUnlinked, &userID, &userID, map[string]string{"provider": provider})
+	})
+}
+
+func (s *postgresService) provider(id string) *oidc.Provider {
+	for _, p := range s.providers {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *postgresService) CreateSession(ctx context.Context, userID uuid.UUID, remember bool) (string, time.Time, error) {
+	now := time.Now()
+	if err := s.deleteExpiredSessions(now); err != nil {
+		return "", time.Time{}, err
+	}
+	token, hash, err := newVerificationToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	ms := &models.Session{
+		ID:         uuid.New(),
+		UserID:     userID,
+		TokenHash:  hash,
+		IP:         remoteAddr(ctx),
+		UserAgent:  userAgent(ctx),
+		CreatedAt:  now,
+		LastSeenAt: now,
+		Remember:   remember,
+	}
+	if err := s.sessions.Create(ms); err != nil {
+		return "", time.Time{}, err
+	}
+	return token, s.sessionLifetime.expiresAt(ms), nil
+}
+
+func (s *postgresService) CheckSession(ctx context.Context, token string) (uuid.UUID, time.Time, error) {
+	ms, err := s.sessions.Get(hashVerificationToken(token))
+	if err != nil {
+		return uuid.Nil, time.Time{}, err
+	}
+	if ms == nil {
+		return uuid.Nil, time.Time{}, ErrNotFound
+	}
+	now := time.Now()
+	if s.sessionLifetime.expired(ms, now) {
+		if _, err := s.sessions.Delete(ms.UserID, ms.ID); err != nil {
+			return uuid.Nil, time.Time{}, err
+		}
+		return uuid.Nil, time.Time{}, ErrNotFound
+	}
+	ip, ua := remoteAddr(ctx), userAgent(ctx)
+	if now.Sub(ms.LastSeenAt) > touchInterval || ip != ms.IP || ua != ms.UserAgent {
+		if err := s.sessions.Touch(ms.ID, now, ip, ua); err != nil {
+			return uuid.Nil, time.Time{}, err
+		}
+	}
+	return ms.UserID, ms.CreatedAt, nil
+}
+
+func (s *postgresService) EndSession(ctx context.Context, token string) error {
+	ms, err := s.sessions.Get(hashVerificationToken(token))
+	if err != nil || ms == nil {
+		return err
+	}
+	_, err = s.sessions.Delete(ms.UserID, ms.ID)
+	return err
+}
+
+func (s *postgresService) AddSessionClient(ctx context.Context, token, clientID string, scopes []string) (uuid.UUID, error) {
+	ms, err := s.sessions.Get(hashVerificationToken(token))
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if ms == nil {
+		return uuid.Nil, ErrNotFound
+	}
+	if !grantsAll(scopes, []string{"nonconsentual"}) {
+		state, err := s.state(ms.UserID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if state == StatePendingDeletion {
+			return uuid.Nil, ErrPendingDeletion
+		}
+	}
+	if err := s.sessions.AddClient(ms.ID, clientID); err != nil {
+		return uuid.Nil, err
+	}
+	return ms.ID, nil
+}
+
+func (s *postgresService) LogOut(ctx context.Context, token string) error {
+	ms, err := s.sessions.Get(hashVerificationToken(token))
+	if err != nil || ms == nil {
+		return err
+	}
+	clients, err := s.sessions.Clients(ms.ID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.sessions.Delete(ms.UserID, ms.ID); err != nil {
+		return err
+	}
+	return s.logOutClients(ctx, ms.UserID, ms.ID, clients)
+}
+
+func (s *postgresService) CheckLogoutRedirect(ctx context.Context, clientID, redirectURI string) error {
+	if s.hydra == nil || clientID == "" || redirectURI == "" {
+		return ErrBadRequest
+	}
+	c, err := s.hydra.GetClient(ctx, clientID)
+	if err == hydra.ErrNotFound {
+		return ErrBadRequest
+	}
+	if err != nil {
+		return err
+	}
+	if !s.allowsPostLogoutRedirect(c, redirectURI) {
+		return ErrBadRequest
+	}
+	return nil
+}
+
+func (s *postgresService) RememberedScopes(ctx context.Context, userID uuid.UUID, clientID string) ([]string, error) {
+	cg, err := models.ConsentGrantByUserIDClientID(s, userID, clientID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return splitScopes(cg.Scopes), nil
+}
+
+func (s *postgresService) RememberConsent(ctx context.Context, userID uuid.UUID, clientID string, scopes []string) error {
+	return transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := rememberConsent(tx, userID, clientID, scopes); err != nil {
+			return err
+		}
+		return writeAudit(ctx, tx, AuditConsentGranted, &userID, &userID, map[string]string{
+			"client": clientID,
+			"scopes": joinScopes(nil, scopes),
+		})
+	})
+}
+
+func (s *postgresService) ListApps(ctx context.Context) ([]*App, error) {
+	apps, err := s.consentGrants(ctx, subj(ctx))
+	if err != nil {
+		return nil, err
+	}
+	s.describeApps(ctx, apps)
+	return apps, nil
+}
+
+func (s *postgresService) RevokeApp(ctx context.Context, clientID string) error {
+	userID := subj(ctx)
+	if _, err := models.ConsentGrantByUserIDClientID(s, userID, clientID); err == sql.ErrNoRows {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	err := transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM consent_grants WHERE user_id = $1 AND client_id = $2`, userID, clientID); err != nil {
+			return err
+		}
+		return writeAudit(ctx, tx, AuditConsentRevoked, &userID, &userID, map[string]string{"client": clientID})
+	})
+	if err != nil {
+		return err
+	}
+	// The grant is forgotten even if Hydra or the client can't be reached, as users must always be able to withdraw
+	// consent. Revoking it at Hydra stops the client's tokens from working, and the client is then told to log the
+	// user out of every session. Either failing is returned, so that the user can try again.
+	return s.logOutClients(ctx, userID, uuid.Nil, []string{clientID})
+}
+
+func (s *postgresService) ListSessions(ctx context.Context) ([]*Session, error) {
+	return s.listSessions(subj(ctx))
+}
+
+func (s *postgresService) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	userID := subj(ctx)
+	clients, err := s.sessions.Clients(sessionID)
+	if err != nil {
+		return err
+	}
+	ok, err := s.sessions.Delete(userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	if err := s.audit(ctx, AuditSessionRevoked, &userID, &userID, map[string]string{"session": sessionID.String()}); err != nil {
+		return err
+	}
+	return s.logOutClients(ctx, userID, sessionID, clients)
+}
+
+func (s *postgresService) ListUsers(ctx context.Context, filter UserFilter) ([]*models.User, string, error) {
+	limit := pageLimit(filter.Limit)
+	q, args, err := filter.query(limit + 1)
+	if err != nil {
+		return nil, "", err
+	}
+	rows, err := s.Query(q, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	users := []*models.User{}
+	for rows.Next() {
+		var u models.User
+		if err := rows.Scan(&u.ID, &u.Name, &u.Email, &u.EmailVerified,
+			&u.GivenName, &u.FamilyName, &u.Pronouns, &u.Picture, &u.Locale, &u.Zoneinfo, &u.DeletedAt, &u.State); err != nil {
+			return nil, "", err
+		}
+		users = append(users, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+	users, cursor := nextPage(users, limit)
+	return users, cursor, nil
+}
+
+func (s *postgresService) GetUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	u, err := models.UserByID(s, userID)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return u, err
+}
+
+func (s *postgresService) ForcePasswordReset(ctx context.Context, userID uuid.UUID) error {
+	u, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	lock := make([]byte, 16)
+	if _, err := rand.Read(lock); err != nil {
+		return err
+	}
+	li := &models.LocalIdentity{
+		UserID:   u.ID,
+		Password: lockedPrefix + hex.EncodeToString(lock),
+	}
+	err = transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := li.Upsert(tx); err != nil {
+			return err
+		}
+		return writeAudit(ctx, tx, AuditPasswordResetRequested, actorOf(ctx), &u.ID, map[string]string{"forced": "true"})
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.sessions.DeleteAll(u.ID); err != nil {
+		return err
+	}
+	token := signResetToken(s.resetSecret, li, time.Now().Add(s.resetTTL))
+	return s.mailer.Send(mailer.Message{
+		To:      u.Email,
+		Subject: "Choose a new Studiously password",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\n"+
+			"An administrator has reset the password for your Studiously account. Follow the link below within %s "+
+			"to choose a new one:\r\n\r\n%s/reset/confirm?token=%s\r\n\r\n"+
+			"After that, you can ask for a new link from the login page.",
+			u.Name, s.resetTTL, s.publicURL, url.QueryEscape(token)),
+	})
+}
+
+func (s *postgresService) SetUserActive(ctx context.Context, userID uuid.UUID, active bool, reason string) error {
+	err := transact(ctx, s.DB, func(tx *sql.Tx) error {
+		to := StateSuspended
+		if active {
+			var err error
+			if to, err = previousState(tx, userID, StateSuspended); err != nil {
+				return err
+			}
+		}
+		_, err := changeState(ctx, tx, userID, to, reason, actorOf(ctx))
+		return err
+	})
+	if err != nil || active {
+		return err
+	}
+	return s.sessions.DeleteAll(userID)
+}
+
+func (s *postgresService) PurgeUser(ctx context.Context, userID uuid.UUID) error {
+	return s.purgeUser(ctx, userID, time.Time{}, "purged by an administrator", actorOf(ctx))
+}
+
+func (s *postgresService) ListStateChanges(ctx context.Context, userID uuid.UUID) ([]*models.UserStateChange, error) {
+	changes, err := models.UserStateChangesByUserID(s, userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].CreatedAt.Before(changes[j].CreatedAt)
+	})
+	return changes, nil
+}
+
+func (s *postgresService) ListAuditEvents(ctx context.Context, filter AuditFilter) ([]*models.AuditEvent, string, error) {
+	limit := pageLimit(filter.Limit)
+	q, args, err := filter.query(limit + 1)
+	if err != nil {
+		return nil, "", err
+	}
+	rows, err := s.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	events, err := scanAuditEvents(rows)
+	if err != nil {
+		return nil, "", err
+	}
+	events, cursor := nextAuditPage(events, limit)
+	return events, cursor, nil
+}
+
+func (s *postgresService) VerifyAuditLog(ctx context.Context) (int64, error) {
+	const batch = 1000
+	var prevID int64
+	var prevHash string
+	for {
+		rows, err := s.QueryContext(ctx, `SELECT `+auditColumns+` FROM audit_events WHERE id > $1 ORDER BY id LIMIT $2`,
+			prevID, batch)
+		if err != nil {
+			return 0, err
+		}
+		events, err := scanAuditEvents(rows)
+		if err != nil {
+			return 0, err
+		}
+		for _, ae := range events {
+			if ae.ID != prevID+1 {
+				return ae.ID, nil
+			}
+			hash, err := auditHash(prevHash, ae)
+			if err != nil || hash != ae.Hash {
+				return ae.ID, nil
+			}
+			prevID, prevHash = ae.ID, ae.Hash
+		}
+		if len(events) < batch {
+			return 0, nil
+		}
+	}
+}
+
+func (s *postgresService) PurgeDeletedUsers(ctx context.Context) ([]uuid.UUID, error) {
+	before := time.Now().Add(-s.deletionRetention)
+	rows, err := s.Query(`SELECT id FROM users WHERE deleted_at < $1`, before)
+	if err != nil {
+		return nil, err
+	}
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	var purged []uuid.UUID
+	for _, id := range userIDs {
+		switch err := s.purgeUser(ctx, id, before, "could no longer be restored", nil); err {
+		case nil:
+			purged = append(purged, id)
+		case ErrNotFound:
+			// Restored since, or purged by another replica.
+		default:
+			return purged, err
+		}
+	}
+	return purged, nil
+}
+
+// purgeUser deletes a user, their files and, by cascade, everything else that belongs to them, recording why and
+// who did it. Unless deletedBefore is zero, the user is only deleted if they deleted their account before then.
+func (s *postgresService) purgeUser(ctx context.Context, userID uuid.UUID, deletedBefore time.Time, reason string, actor *uuid.UUID) error {
+	exports, err := models.DataExportsByUserID(s, userID)
+	if err != nil {
+		return err
+	}
+	q, args := `DELETE FROM users WHERE id = $1`, []interface{}{userID}
+	if !deletedBefore.IsZero() {
+		q += ` AND deleted_at < $2`
+		args = append(args, deletedBefore)
+	}
+	err = transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if _, err := changeState(ctx, tx, userID, StateDeleted, reason, actor); err != nil {
+			return err
+		}
+		res, err := tx.Exec(q, args...)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.attempts.Reset(codeKey(userID.String()))
+	// The sessions table cascades, but other stores don't.
+	if err := s.sessions.DeleteAll(userID); err != nil {
+		return err
+	}
+	for _, de := range exports {
+		if err := s.blobs.Delete(ctx, exportKey(de.ID)); err != nil {
+			return err
+		}
+	}
+	return s.deleteAvatar(ctx, userID)
+}
+
+func (s *postgresService) StartExport(ctx context.Context) (*Export, error) {
+	now := time.Now()
+	if err := s.deleteExpiredExports(ctx, now); err != nil {
+		return nil, err
+	}
+	exports, err := models.DataExportsByUserID(s, subj(ctx))
+	if err != nil {
+		return nil, err
+	}
+	for _, de := range exports {
+		if de.Status == ExportPending {
+			return s.exportOf(de, now), nil
+		}
+	}
+	de := &models.DataExport{
+		ID:        uuid.New(),
+		UserID:    subj(ctx),
+		Status:    ExportPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = transact(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := de.Insert(tx); err != nil {
+			return err
+		}
+		return writeAudit(ctx, tx, AuditExportStarted, &de.UserID, &de.UserID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	go s.runExport(de)
+	return s.exportOf(de, now), nil
+}
+
+func (s *postgresService) GetExport(ctx context.Context, exportID uuid.UUID) (*Export, error) {
+	de, err := models.DataExportByID(s, exportID)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if de.UserID != subj(ctx) {
+		return nil, ErrNotFound
+	}
+	return s.exportOf(de, time.Now()), nil
+}
+
+func (s *postgresService) DownloadExport(ctx context.Context, exportID uuid.UUID, token string) (io.ReadCloser, error) {
+	if err := verifyExportToken(s.exportSecret, exportID, token, time.Now()); err != nil {
+		return nil, err
+	}
+	export, err := s.blobs.Get(ctx, exportKey(exportID))
+	if err == blobstore.ErrNotFound {
+		return nil, ErrNotFound
+	}
+	return export, err
+}
+
+// deleteExpiredExports removes every user's exports that can no longer be downloaded, and those that were never
+// finished, which by then must have been abandoned.
+func (s *postgresService) deleteExpiredExports(ctx context.Context, now time.Time) error {
+	rows, err := s.Query(`DELETE FROM data_exports WHERE (status <> $1 AND updated_at < $2) OR (status = $1 AND created_at < $3) `+
+		`RETURNING id`, ExportPending, now.Add(-s.exportTTL), now.Add(-exportTimeout))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		if err := s.blobs.Delete(ctx, exportKey(id)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// enabledTOTP returns the user's TOTP credential, or ErrNotFound if two-factor authentication isn't turned on.
+func (s *postgresService) enabledTOTP(userID uuid.UUID) (*models.TotpCredential, error) {
+	tc, err := models.TotpCredentialByUserID(s, userID)
+	switch err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+	if !tc.Enabled {
+		return nil, ErrNotFound
+	}
+	return tc, nil
+}
+
+// checkSecondFactor redeems code for the owner of tc, throttling repeated failures.
+func (s *postgresService) checkSecondFactor(tc *models.TotpCredential, code string) (err error) {
+	key := codeKey(tc.UserID.String())
+	now := time.Now()
+	wait, err := codeThrottle.wait(s.attempts, key, now)
+	if err != nil {
+		return err
+	}
+	if wait > 0 {
+		return ErrTooManyAttempts
+	}
+	defer func() {
+		switch err {
+		case ErrWrongCode:
+			codeThrottle.fail(s.attempts, key, now)
+		case nil:
+			s.attempts.Reset(key)
+		}
+	}()
+
+	if isTOTPCode(code) {
+		step, ok := totp.Validate(tc.Secret, normalizeCode(code), now, totpSkew)
+		if !ok {
+			return ErrWrongCode
+		}
+		// Moving last_step forward only succeeds once per code, so a code seen over someone's shoulder is useless.
+		res, err := s.Exec(`UPDATE totp_credentials SET last_step = $1 WHERE user_id = $2 AND last_step < $1`,
+			step, tc.UserID)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return ErrWrongCode
+		}
+		tc.LastStep = step
+		return nil
+	}
+	if !tc.Enabled {
+		// Any recovery codes left over belong to an earlier enrollment.
+		return ErrWrongCode
+	}
+	res, err := s.Exec(`DELETE FROM recovery_codes WHERE code_hash = $1 AND user_id = $2`,
+		hashRecoveryCode(code), tc.UserID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrWrongCode
+	}
+	return nil
+}
+
+// replaceRecoveryCodes swaps all of a user's recovery codes for the given hashes.
+func replaceRecoveryCodes(db models.XODB, userID uuid.UUID, hashes []string) error {
+	if _, err := db.Exec(`DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	for _, hash := range hashes {
+		rc := &models.RecoveryCode{
+			CodeHash: hash,
+			UserID:   userID,
+		}
+		if err := rc.Insert(db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createVerification stores a pending verification of email for the given user and returns the token to send.
+func (s *postgresService) createVerification(db models.XODB, userID uuid.UUID, email string) (string, error) {
+	token, hash, err := newVerificationToken()
+	if err != nil {
+		return "", err
+	}
+	ev := &models.EmailVerification{
+		TokenHash: hash,
+		UserID:    userID,
+		Email:     email,
+		ExpiresAt: time.Now().Add(verificationTTL),
+	}
+	return token, ev.Insert(db)
+}
+
+func (s *postgresService) sendVerification(name, email, token string) error {
+	return s.mailer.Send(mailer.Message{
+		To:      email,
+		Subject: "Confirm your email address for Studiously",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\n"+
+			"Please confirm that this is your email address by following the link below within %s:\r\n\r\n"+
+			"%s/verify?token=%s\r\n\r\n"+
+			"If you didn't sign up for Studiously or change your address, you can ignore this email.",
+			name, verificationTTL, s.publicURL, url.QueryEscape(token)),
+	})
+}
+
+func subj(ctx context.Context) uuid.UUID {
+	return ctx.Value(introspector.SubjectContextKey).(uuid.UUID)
+}
+
+// detached is a request's context, with its values but without its deadline or cancellation, for work that carries
+// on after the request has been answered.
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detached) Done() <-chan struct{}       { return nil }
+func (detached) Err() error                  { return nil }
+
+func remoteAddr(ctx context.Context) string {
+	ip, _ := ctx.Value(RemoteAddrContextKey).(string)
+	return ip
 }
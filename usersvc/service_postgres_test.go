@@ -0,0 +1,111 @@
+package usersvc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/studiously/usersvc/oidc"
+	"github.com/studiously/usersvc/oidc/oidctest"
+)
+
+// federatedLogin logs in with the issuer as subject, whose ID token has the given extra claims.
+func federatedLogin(t *testing.T, s Service, iss *oidctest.Issuer, subject string, extra map[string]interface{}) (uuid.UUID, error) {
+	ctx := context.Background()
+	req, err := s.BeginFederatedLogin(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := iss.Claims(*req, subject)
+	for k, v := range extra {
+		claims[k] = v
+	}
+	return s.FederatedLogin(ctx, "test", *req, iss.Code(*req, claims))
+}
+
+func TestFederatedLoginUnknownProvider(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	s := New(nil, nil, Providers(iss.Provider("test")))
+	if _, err := s.BeginFederatedLogin(context.Background(), "other"); err != ErrNotFound {
+		t.Errorf("BeginFederatedLogin = %v, want ErrNotFound", err)
+	}
+	if _, err := s.FederatedLogin(context.Background(), "other", oidc.AuthRequest{}, "code"); err != ErrNotFound {
+		t.Errorf("FederatedLogin = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFederatedLoginInvalidToken(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	s := New(nil, nil, Providers(iss.Provider("test")))
+	// An ID token issued for another login is never looked up, so no database is needed.
+	if _, err := federatedLogin(t, s, iss, "alice", map[string]interface{}{"nonce": "replayed"}); err != oidc.ErrInvalidToken {
+		t.Errorf("FederatedLogin = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestFederatedLoginSignUp(t *testing.T) {
+	db := testDB(t)
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	s := New(db, nil, Providers(iss.Provider("test")))
+	verified := map[string]interface{}{"email": "alice@example.com", "email_verified": true, "name": "Alice"}
+	id, err := federatedLogin(t, s, iss, "alice", verified)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := federatedLogin(t, s, iss, "alice", verified)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != id {
+		t.Errorf("second login = %s, want %s", again, id)
+	}
+	unverified := map[string]interface{}{"email": "bob@example.com", "name": "Bob"}
+	if _, err := federatedLogin(t, s, iss, "bob", unverified); err != ErrEmailNotVerified {
+		t.Errorf("unverified sign-up = %v, want ErrEmailNotVerified", err)
+	}
+}
+
+func TestFederatedLoginExistingEmail(t *testing.T) {
+	db := testDB(t)
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	s := New(db, nil, Providers(iss.Provider("test")))
+	testUser(t, db, "alice@example.com")
+	claims := map[string]interface{}{"email": "alice@example.com", "email_verified": true}
+	if _, err := federatedLogin(t, s, iss, "alice", claims); err != ErrLinkRequired {
+		t.Errorf("FederatedLogin = %v, want ErrLinkRequired", err)
+	}
+}
+
+func TestLinkIdentity(t *testing.T) {
+	db := testDB(t)
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+	s := New(db, nil, Providers(iss.Provider("test")))
+	ctx := context.Background()
+	link := func(userID uuid.UUID, subject string) error {
+		req, err := s.BeginFederatedLogin(ctx, "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s.LinkIdentity(ctx, userID, "test", *req, iss.Code(*req, iss.Claims(*req, subject)))
+	}
+	alice := testUser(t, db, "alice@example.com")
+	if err := link(alice.ID, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	id, err := federatedLogin(t, s, iss, "alice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != alice.ID {
+		t.Errorf("FederatedLogin = %s, want %s", id, alice.ID)
+	}
+	bob := testUser(t, db, "bob@example.com")
+	if err := link(bob.ID, "alice"); err != ErrIdentityInUse {
+		t.Errorf("linking another user's identity = %v, want ErrIdentityInUse", err)
+	}
+}
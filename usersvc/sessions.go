@@ -0,0 +1,333 @@
+package usersvc
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/studiously/usersvc/models"
+)
+
+// touchInterval is how long a session goes between updates to when it was last seen, so that checking a session on
+// every request doesn't write to the store every time.
+const touchInterval = time.Minute
+
+// SessionLifetime sets how long sessions last. Fields left zero take their defaults.
+type SessionLifetime struct {
+	// Absolute is how long a session lasts after the user logs in, however much it is used. Defaults to 12 hours.
+	Absolute time.Duration
+	// Idle is how long a session lasts without being used. Defaults to one hour. Use is only recorded once every
+	// minute or so, so it should be far longer than that.
+	Idle time.Duration
+	// RememberAbsolute and RememberIdle replace Absolute and Idle for sessions the user asked to stay signed in to.
+	// They default to 30 days and 7 days.
+	RememberAbsolute time.Duration
+	RememberIdle     time.Duration
+}
+
+var defaultSessionLifetime = SessionLifetime{
+	Absolute:         12 * time.Hour,
+	Idle:             time.Hour,
+	RememberAbsolute: 30 * 24 * time.Hour,
+	RememberIdle:     7 * 24 * time.Hour,
+}
+
+// limits returns how long a session lasts in all, and without being used.
+func (l SessionLifetime) limits(remember bool) (absolute, idle time.Duration) {
+	if remember {
+		return l.RememberAbsolute, l.RememberIdle
+	}
+	return l.Absolute, l.Idle
+}
+
+func (l SessionLifetime) expiresAt(ms *models.Session) time.Time {
+	absolute, _ := l.limits(ms.Remember)
+	return ms.CreatedAt.Add(absolute)
+}
+
+func (l SessionLifetime) expired(ms *models.Session, now time.Time) bool {
+	_, idle := l.limits(ms.Remember)
+	return !now.Before(l.expiresAt(ms)) || now.Sub(ms.LastSeenAt) > idle
+}
+
+// Session is a browser a user is logged in with, as shown to the user.
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	// ExpiresAt is when the session ends, unless it is left unused long enough to end sooner.
+	ExpiresAt time.Time `json:"expires_at"`
+	// Remember is whether the user asked to stay signed in, which makes the session last longer.
+	Remember bool `json:"remember"`
+	// IP and UserAgent are those the session was last seen from.
+	IP        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+}
+
+func (l SessionLifetime) sessionOf(ms *models.Session) *Session {
+	return &Session{
+		ID:         ms.ID,
+		CreatedAt:  ms.CreatedAt,
+		LastSeenAt: ms.LastSeenAt,
+		ExpiresAt:  l.expiresAt(ms),
+		Remember:   ms.Remember,
+		IP:         ms.IP,
+		UserAgent:  ms.UserAgent,
+	}
+}
+
+// SessionStore keeps the sessions users are logged in to the browser with. Sessions are looked up by the hash of
+// their token, which only the browser holds.
+type SessionStore interface {
+	// Create records a new session.
+	Create(session *models.Session) error
+	// Get returns the session with the given token hash, or nil if there is none.
+	Get(tokenHash string) (*models.Session, error)
+	// Touch records that a session was seen again, at the given time and from the given client.
+	Touch(id uuid.UUID, at time.Time, ip, userAgent string) error
+	// List returns a user's sessions.
+	List(userID uuid.UUID) ([]*models.Session, error)
+	// Delete ends one of a user's sessions, reporting whether they had it.
+	Delete(userID, id uuid.UUID) (bool, error)
+	// DeleteAll ends all of a user's sessions.
+	DeleteAll(userID uuid.UUID) error
+	// DeleteExpired removes the sessions, remembered or not, that were created before createdBefore or last seen
+	// before seenBefore.
+	DeleteExpired(remember bool, createdBefore, seenBefore time.Time) error
+	// AddClient records that a client was given tokens during a session. Adding a client twice has no effect.
+	AddClient(id uuid.UUID, clientID string) error
+	// Clients returns the clients that were given tokens during a session, in the order they were added.
+	Clients(id uuid.UUID) ([]string, error)
+}
+
+// NewMemorySessionStore returns a SessionStore that keeps sessions in memory. It is only suitable for a single
+// instance of usersvc, and everyone is logged out when it restarts.
+func NewMemorySessionStore() SessionStore {
+	return &memorySessionStore{sessions: make(map[uuid.UUID]models.Session), clients: make(map[uuid.UUID][]string)}
+}
+
+type memorySessionStore struct {
+	mtx      sync.Mutex
+	sessions map[uuid.UUID]models.Session
+	clients  map[uuid.UUID][]string
+}
+
+func (m *memorySessionStore) Create(session *models.Session) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.sessions[session.ID] = *session
+	return nil
+}
+
+func (m *memorySessionStore) Get(tokenHash string) (*models.Session, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, ms := range m.sessions {
+		if ms.TokenHash == tokenHash {
+			return &ms, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySessionStore) Touch(id uuid.UUID, at time.Time, ip, userAgent string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	ms, ok := m.sessions[id]
+	if !ok {
+		return nil
+	}
+	ms.LastSeenAt, ms.IP, ms.UserAgent = at, ip, userAgent
+	m.sessions[id] = ms
+	return nil
+}
+
+func (m *memorySessionStore) List(userID uuid.UUID) ([]*models.Session, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	var sessions []*models.Session
+	for _, ms := range m.sessions {
+		if ms.UserID == userID {
+			ms := ms
+			sessions = append(sessions, &ms)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *memorySessionStore) Delete(userID, id uuid.UUID) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if ms, ok := m.sessions[id]; !ok || ms.UserID != userID {
+		return false, nil
+	}
+	delete(m.sessions, id)
+	delete(m.clients, id)
+	return true, nil
+}
+
+func (m *memorySessionStore) DeleteAll(userID uuid.UUID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for id, ms := range m.sessions {
+		if ms.UserID == userID {
+			delete(m.sessions, id)
+			delete(m.clients, id)
+		}
+	}
+	return nil
+}
+
+func (m *memorySessionStore) DeleteExpired(remember bool, createdBefore, seenBefore time.Time) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for id, ms := range m.sessions {
+		if ms.Remember == remember && (ms.CreatedAt.Before(createdBefore) || ms.LastSeenAt.Before(seenBefore)) {
+			delete(m.sessions, id)
+			delete(m.clients, id)
+		}
+	}
+	return nil
+}
+
+func (m *memorySessionStore) AddClient(id uuid.UUID, clientID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.sessions[id]; !ok {
+		return nil
+	}
+	for _, c := range m.clients[id] {
+		if c == clientID {
+			return nil
+		}
+	}
+	m.clients[id] = append(m.clients[id], clientID)
+	return nil
+}
+
+func (m *memorySessionStore) Clients(id uuid.UUID) ([]string, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return append([]string(nil), m.clients[id]...), nil
+}
+
+// NewPostgresSessionStore returns a SessionStore backed by the sessions table, shared by all instances of usersvc
+// using the same database.
+func NewPostgresSessionStore(db *sql.DB) SessionStore {
+	return postgresSessionStore{db}
+}
+
+type postgresSessionStore struct {
+	*sql.DB
+}
+
+func (s postgresSessionStore) Create(session *models.Session) error {
+	return session.Insert(s)
+}
+
+func (s postgresSessionStore) Get(tokenHash string) (*models.Session, error) {
+	ms, err := models.SessionByTokenHash(s, tokenHash)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return ms, err
+}
+
+func (s postgresSessionStore) Touch(id uuid.UUID, at time.Time, ip, userAgent string) error {
+	_, err := s.Exec(`UPDATE sessions SET last_seen_at = $2, ip = $3, user_agent = $4 WHERE id = $1`,
+		id, at, ip, userAgent)
+	return err
+}
+
+func (s postgresSessionStore) List(userID uuid.UUID) ([]*models.Session, error) {
+	return models.SessionsByUserID(s, userID)
+}
+
+func (s postgresSessionStore) Delete(userID, id uuid.UUID) (bool, error) {
+	res, err := s.Exec(`DELETE FROM sessions WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s postgresSessionStore) DeleteAll(userID uuid.UUID) error {
+	_, err := s.Exec(`DELETE FROM sessions WHERE user_id = $1`, userID)
+	return err
+}
+
+func (s postgresSessionStore) DeleteExpired(remember bool, createdBefore, seenBefore time.Time) error {
+	_, err := s.Exec(`DELETE FROM sessions WHERE remember = $1 AND (created_at < $2 OR last_seen_at < $3)`,
+		remember, createdBefore, seenBefore)
+	return err
+}
+
+func (s postgresSessionStore) AddClient(id uuid.UUID, clientID string) error {
+	sc := &models.SessionClient{
+		ID:        uuid.New(),
+		SessionID: id,
+		ClientID:  clientID,
+		CreatedAt: time.Now(),
+	}
+	err := sc.Insert(s)
+	if _, ok := uniqueViolation(err); ok {
+		return nil
+	}
+	return err
+}
+
+func (s postgresSessionStore) Clients(id uuid.UUID) ([]string, error) {
+	rows, err := s.Query(`SELECT client_id FROM session_clients WHERE session_id = $1 ORDER BY created_at`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var clients []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+	return clients, rows.Err()
+}
+
+// deleteExpiredSessions clears out sessions that have ended, which are otherwise only removed when they are next
+// used.
+func (s *postgresService) deleteExpiredSessions(now time.Time) error {
+	for _, remember := range []bool{false, true} {
+		absolute, idle := s.sessionLifetime.limits(remember)
+		if err := s.sessions.DeleteExpired(remember, now.Add(-absolute), now.Add(-idle)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listSessions returns a user's sessions that haven't ended, most recently seen first.
+func (s *postgresService) listSessions(userID uuid.UUID) ([]*Session, error) {
+	stored, err := s.sessions.List(userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(stored, func(i, j int) bool {
+		return stored[i].LastSeenAt.After(stored[j].LastSeenAt)
+	})
+	now := time.Now()
+	sessions := []*Session{}
+	for _, ms := range stored {
+		if !s.sessionLifetime.expired(ms, now) {
+			sessions = append(sessions, s.sessionLifetime.sessionOf(ms))
+		}
+	}
+	return sessions, nil
+}
+
+func (s *postgresService) exportSessions(ctx context.Context, userID uuid.UUID) (interface{}, error) {
+	return s.listSessions(userID)
+}
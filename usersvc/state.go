@@ -0,0 +1,174 @@
+package usersvc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/studiously/usersvc/models"
+)
+
+// The states a user's account can be in.
+const (
+	// StatePendingVerification users have registered, but have yet to follow the link sent to their email address.
+	StatePendingVerification = "pending_verification"
+	StateActive              = "active"
+	// StateSuspended users have been deactivated by an administrator.
+	StateSuspended = "suspended"
+	// StatePendingDeletion users have deleted their accounts, and can restore them until they are purged.
+	StatePendingDeletion = "pending_deletion"
+	// StateDeleted users have been purged. Only the record of how they got there is left.
+	StateDeleted = "deleted"
+)
+
+// transitions lists the states each state can change to. Users pending deletion can be suspended, and return to
+// pending deletion when reactivated. Suspended users mustn't delete or restore their own accounts, though, or else
+// restoring them would undo the suspension, so DeleteUser and RestoreUser check for that themselves.
+var transitions = map[string][]string{
+	StatePendingVerification: {StateActive, StateSuspended, StatePendingDeletion, StateDeleted},
+	StateActive:              {StateSuspended, StatePendingDeletion, StateDeleted},
+	StateSuspended:           {StateActive, StatePendingVerification, StatePendingDeletion, StateDeleted},
+	StatePendingDeletion:     {StateActive, StatePendingVerification, StateSuspended, StateDeleted},
+}
+
+// hiddenStates are the states in which a user's profile and picture aren't shown to anyone, as though they didn't
+// exist.
+var hiddenStates = []string{StateSuspended, StatePendingDeletion}
+
+// visible reports whether others can see the profile and picture of a user in state.
+func visible(state string) bool {
+	for _, hidden := range hiddenStates {
+		if state == hidden {
+			return false
+		}
+	}
+	return true
+}
+
+func canTransition(from, to string) bool {
+	for _, state := range transitions[from] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+// changeState moves a user into another state and records the change, in the audit log too, returning the state they
+// were in. The actor is whoever made the change, or nil if usersvc made it on its own. Moving a user to StateDeleted
+// only records it, and the caller must delete the user in the same transaction.
+func changeState(ctx context.Context, tx *sql.Tx, userID uuid.UUID, to, reason string, actor *uuid.UUID) (from string, err error) {
+	err = tx.QueryRow(`SELECT state FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&from)
+	switch err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		return "", ErrNotFound
+	default:
+		return "", err
+	}
+	if !canTransition(from, to) {
+		return from, ErrInvalidTransition
+	}
+	if to != StateDeleted {
+		if _, err := tx.Exec(`UPDATE users SET state = $1 WHERE id = $2`, to, userID); err != nil {
+			return from, err
+		}
+	}
+	sc := &models.UserStateChange{
+		ID:        uuid.New(),
+		UserID:    userID,
+		FromState: from,
+		ToState:   to,
+		Reason:    reason,
+		ActorID:   actor,
+		CreatedAt: time.Now(),
+	}
+	if err := sc.Insert(tx); err != nil {
+		return from, err
+	}
+	return from, writeAudit(ctx, tx, AuditStateChanged, actor, &userID, map[string]string{
+		"from":   from,
+		"to":     to,
+		"reason": reason,
+	})
+}
+
+// previousState returns the state a user was in before they entered their current one, which restoring or
+// reactivating them returns them to. Users who have been in their current state since before states were recorded
+// were active.
+func previousState(tx *sql.Tx, userID uuid.UUID, current string) (string, error) {
+	var from string
+	err := tx.QueryRow(`SELECT from_state FROM user_state_changes WHERE user_id = $1 AND to_state = $2 `+
+		`ORDER BY created_at DESC LIMIT 1`, userID, current).Scan(&from)
+	switch err {
+	case nil:
+		return from, nil
+	case sql.ErrNoRows:
+		return StateActive, nil
+	default:
+		return "", err
+	}
+}
+
+// stateErr returns the error for a user who can't do what they asked because of the state they are in, or nil if
+// they are active.
+func stateErr(state string) error {
+	switch state {
+	case StateActive:
+		return nil
+	case StatePendingVerification:
+		return ErrEmailNotVerified
+	case StateSuspended:
+		return ErrAccountSuspended
+	case StatePendingDeletion:
+		return ErrPendingDeletion
+	default:
+		return ErrNotFound
+	}
+}
+
+// loginErr returns the error for a user who can't log in because of the state they are in, or nil if they can.
+// Users pending deletion can log in, but only so that they can restore their accounts: AddSessionClient refuses to
+// let them give tokens to any client but official ones.
+func loginErr(state string) error {
+	if state == StatePendingDeletion {
+		return nil
+	}
+	return stateErr(state)
+}
+
+// requireActive returns the error for a user who can't make changes to their account because of the state they are
+// in, or nil if they are active.
+func (s *postgresService) requireActive(userID uuid.UUID) error {
+	state, err := s.state(userID)
+	if err != nil {
+		return err
+	}
+	return stateErr(state)
+}
+
+// canLogIn returns the error for a user who can't log in because of the state they are in, or nil if they can.
+func (s *postgresService) canLogIn(userID uuid.UUID) error {
+	state, err := s.state(userID)
+	if err != nil {
+		return err
+	}
+	return loginErr(state)
+}
+
+func (s *postgresService) state(userID uuid.UUID) (string, error) {
+	var state string
+	err := s.QueryRow(`SELECT state FROM users WHERE id = $1`, userID).Scan(&state)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+	return state, err
+}
+
+// actorOf returns the user making a request, to record as the actor of the changes it makes.
+func actorOf(ctx context.Context) *uuid.UUID {
+	userID := subj(ctx)
+	return &userID
+}
@@ -0,0 +1,113 @@
+package usersvc
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/studiously/introspector"
+	"github.com/studiously/usersvc/blobstore"
+)
+
+func TestSuspendPendingDeletion(t *testing.T) {
+	db := testDB(t)
+	s := New(db, nil, Sessions(NewMemorySessionStore()))
+	u := testUser(t, db, "leaving@example.com")
+	if _, err := db.Exec(`UPDATE users SET state = $1 WHERE id = $2`, StatePendingDeletion, u.ID); err != nil {
+		t.Fatal(err)
+	}
+	admin := uuid.New()
+	ctx := context.WithValue(context.Background(), introspector.SubjectContextKey, admin)
+	if err := s.SetUserActive(ctx, u.ID, false, "spam"); err != nil {
+		t.Fatalf("SetUserActive: %v", err)
+	}
+	if state, err := s.(*postgresService).state(u.ID); err != nil || state != StateSuspended {
+		t.Fatalf("state = %q, %v, want %q", state, err, StateSuspended)
+	}
+	changes, err := s.ListStateChanges(ctx, u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("%d state changes recorded, want 1", len(changes))
+	}
+	c := changes[0]
+	if c.FromState != StatePendingDeletion || c.ToState != StateSuspended {
+		t.Errorf("recorded %s -> %s, want %s -> %s", c.FromState, c.ToState, StatePendingDeletion, StateSuspended)
+	}
+	if c.Reason != "spam" {
+		t.Errorf("reason = %q, want %q", c.Reason, "spam")
+	}
+	if c.ActorID == nil || *c.ActorID != admin {
+		t.Errorf("actor = %v, want %s", c.ActorID, admin)
+	}
+}
+
+func TestPendingDeletionConsent(t *testing.T) {
+	db := testDB(t)
+	s := New(db, nil, Sessions(NewMemorySessionStore()))
+	u := testUser(t, db, "leaving@example.com")
+	if _, err := db.Exec(`UPDATE users SET state = $1 WHERE id = $2`, StatePendingDeletion, u.ID); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	token, _, err := s.CreateSession(ctx, u.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddSessionClient(ctx, token, "third-party", []string{"openid"}); err != ErrPendingDeletion {
+		t.Errorf("third-party client given tokens: %v", err)
+	}
+	// They can still restore their accounts through an official client.
+	if _, err := s.AddSessionClient(ctx, token, "official", []string{"openid", "nonconsentual"}); err != nil {
+		t.Errorf("official client refused: %v", err)
+	}
+}
+
+func TestHiddenUsers(t *testing.T) {
+	db := testDB(t)
+	dir, err := ioutil.TempDir("", "blobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := New(db, nil, Blobs(blobstore.NewDir(dir)))
+	ps := s.(*postgresService)
+	ctx := context.Background()
+	users := map[string]uuid.UUID{}
+	for _, state := range []string{StateActive, StatePendingVerification, StateSuspended, StatePendingDeletion} {
+		u := testUser(t, db, state+"@example.com")
+		if _, err := db.Exec(`UPDATE users SET state = $1 WHERE id = $2`, state, u.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := ps.blobs.Put(ctx, avatarKey(u.ID, 64), bytes.NewReader([]byte("jpeg"))); err != nil {
+			t.Fatal(err)
+		}
+		users[state] = u.ID
+	}
+	var ids []uuid.UUID
+	for _, id := range users {
+		ids = append(ids, id)
+	}
+	profiles, _, err := s.GetProfiles(ctx, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for state, id := range users {
+		_, profileErr := s.GetProfile(ctx, id)
+		avatar, avatarErr := s.GetAvatar(ctx, id, 64)
+		if avatar != nil {
+			avatar.Close()
+		}
+		if visible(state) {
+			if profileErr != nil || avatarErr != nil || profiles[id] == nil {
+				t.Errorf("%s user is hidden: GetProfile = %v, GetAvatar = %v", state, profileErr, avatarErr)
+			}
+		} else if profileErr != ErrNotFound || avatarErr != ErrNotFound || profiles[id] != nil {
+			t.Errorf("%s user is shown: GetProfile = %v, GetAvatar = %v", state, profileErr, avatarErr)
+		}
+	}
+}
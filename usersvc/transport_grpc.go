@@ -0,0 +1,898 @@
+package usersvc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/google/uuid"
+	"github.com/ory/hydra/sdk"
+	"github.com/studiously/introspector"
+	"github.com/studiously/svcerror"
+	"github.com/studiously/usersvc/codes"
+	"github.com/studiously/usersvc/models"
+	"github.com/studiously/usersvc/pb"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type grpcServer struct {
+	getUserInfo             grpctransport.Handler
+	updateUser              grpctransport.Handler
+	deleteUser              grpctransport.Handler
+	setAvatar               grpctransport.Handler
+	deleteAvatar            grpctransport.Handler
+	enrollTOTP              grpctransport.Handler
+	enableTOTP              grpctransport.Handler
+	disableTOTP             grpctransport.Handler
+	regenerateRecoveryCodes grpctransport.Handler
+	listIdentities          grpctransport.Handler
+	unlinkIdentity          grpctransport.Handler
+	listSessions            grpctransport.Handler
+	revokeSession           grpctransport.Handler
+	listApps                grpctransport.Handler
+	revokeApp               grpctransport.Handler
+	getProfile              grpctransport.Handler
+	getProfiles             grpctransport.Handler
+	searchUsers             grpctransport.Handler
+	listUsers               grpctransport.Handler
+	getUser                 grpctransport.Handler
+	forcePasswordReset      grpctransport.Handler
+	setUserActive           grpctransport.Handler
+	purgeUser               grpctransport.Handler
+	listStateChanges        grpctransport.Handler
+	listAuditEvents         grpctransport.Handler
+	verifyAuditLog          grpctransport.Handler
+}
+
+// MakeGRPCServer makes the service endpoints available as a gRPC UsersServer, authenticated in the same way as
+// over HTTP.
+func MakeGRPCServer(s Service, client *sdk.Client, logger log.Logger) pb.UsersServer {
+	e := MakeServerEndpoints(s)
+	options := []grpctransport.ServerOption{
+		grpctransport.ServerErrorLogger(logger),
+		grpctransport.ServerBefore(toGRPCContext),
+	}
+	return &grpcServer{
+		getUserInfo: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.get")(e.GetUserInfoEndpoint),
+			decodeGRPCEmptyRequest,
+			encodeGRPCUser,
+			options...,
+		),
+		updateUser: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.update")(e.UpdateUserEndpoint),
+			decodeGRPCUpdateUserRequest,
+			encodeGRPCEmptyReply(func() interface{} { return &pb.UpdateUserReply{} }),
+			options...,
+		),
+		deleteUser: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.delete")(e.DeleteUserEndpoint),
+			decodeGRPCEmptyRequest,
+			encodeGRPCEmptyReply(func() interface{} { return &pb.DeleteUserReply{} }),
+			options...,
+		),
+		setAvatar: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.update")(e.SetAvatarEndpoint),
+			decodeGRPCSetAvatarRequest,
+			encodeGRPCSetAvatarResponse,
+			options...,
+		),
+		deleteAvatar: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.update")(e.DeleteAvatarEndpoint),
+			decodeGRPCEmptyRequest,
+			encodeGRPCEmptyReply(func() interface{} { return &pb.DeleteAvatarReply{} }),
+			options...,
+		),
+		enrollTOTP: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.update")(e.EnrollTOTPEndpoint),
+			decodeGRPCEmptyRequest,
+			encodeGRPCEnrollTOTPResponse,
+			options...,
+		),
+		enableTOTP: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.update")(e.EnableTOTPEndpoint),
+			decodeGRPCTOTPCodeRequest,
+			encodeGRPCRecoveryCodesResponse,
+			options...,
+		),
+		disableTOTP: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.update")(e.DisableTOTPEndpoint),
+			decodeGRPCTOTPCodeRequest,
+			encodeGRPCEmptyReply(func() interface{} { return &pb.DisableTOTPReply{} }),
+			options...,
+		),
+		regenerateRecoveryCodes: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.update")(e.RegenerateRecoveryCodesEndpoint),
+			decodeGRPCTOTPCodeRequest,
+			encodeGRPCRecoveryCodesResponse,
+			options...,
+		),
+		listIdentities: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.get")(e.ListIdentitiesEndpoint),
+			decodeGRPCEmptyRequest,
+			encodeGRPCListIdentitiesResponse,
+			options...,
+		),
+		unlinkIdentity: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.update")(e.UnlinkIdentityEndpoint),
+			decodeGRPCUnlinkIdentityRequest,
+			encodeGRPCEmptyReply(func() interface{} { return &pb.UnlinkIdentityReply{} }),
+			options...,
+		),
+		listSessions: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.get")(e.ListSessionsEndpoint),
+			decodeGRPCEmptyRequest,
+			encodeGRPCListSessionsResponse,
+			options...,
+		),
+		revokeSession: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.update")(e.RevokeSessionEndpoint),
+			decodeGRPCRevokeSessionRequest,
+			encodeGRPCEmptyReply(func() interface{} { return &pb.RevokeSessionReply{} }),
+			options...,
+		),
+		listApps: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.get")(e.ListAppsEndpoint),
+			decodeGRPCEmptyRequest,
+			encodeGRPCListAppsResponse,
+			options...,
+		),
+		revokeApp: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.update")(e.RevokeAppEndpoint),
+			decodeGRPCRevokeAppRequest,
+			encodeGRPCEmptyReply(func() interface{} { return &pb.RevokeAppReply{} }),
+			options...,
+		),
+		getProfile: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.get")(e.GetProfileEndpoint),
+			decodeGRPCGetProfileRequest,
+			encodeGRPCGetProfileResponse,
+			options...,
+		),
+		getProfiles: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.get")(e.GetProfilesEndpoint),
+			decodeGRPCGetProfilesRequest,
+			encodeGRPCGetProfilesResponse,
+			options...,
+		),
+		searchUsers: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.search")(e.SearchUsersEndpoint),
+			decodeGRPCSearchUsersRequest,
+			encodeGRPCSearchUsersResponse,
+			options...,
+		),
+		listUsers: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.admin")(e.ListUsersEndpoint),
+			decodeGRPCListUsersRequest,
+			encodeGRPCListUsersResponse,
+			options...,
+		),
+		getUser: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.admin")(e.GetUserEndpoint),
+			decodeGRPCAdminUserRequest,
+			encodeGRPCUser,
+			options...,
+		),
+		forcePasswordReset: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.admin")(e.ForcePasswordResetEndpoint),
+			decodeGRPCAdminUserRequest,
+			encodeGRPCEmptyReply(func() interface{} { return &pb.ForcePasswordResetReply{} }),
+			options...,
+		),
+		setUserActive: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.admin")(e.SetUserActiveEndpoint),
+			decodeGRPCSetUserActiveRequest,
+			encodeGRPCEmptyReply(func() interface{} { return &pb.SetUserActiveReply{} }),
+			options...,
+		),
+		purgeUser: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.admin")(e.PurgeUserEndpoint),
+			decodeGRPCAdminUserRequest,
+			encodeGRPCEmptyReply(func() interface{} { return &pb.PurgeUserReply{} }),
+			options...,
+		),
+		listStateChanges: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.admin")(e.ListStateChangesEndpoint),
+			decodeGRPCAdminUserRequest,
+			encodeGRPCListStateChangesResponse,
+			options...,
+		),
+		listAuditEvents: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.admin")(e.ListAuditEventsEndpoint),
+			decodeGRPCListAuditEventsRequest,
+			encodeGRPCListAuditEventsResponse,
+			options...,
+		),
+		verifyAuditLog: grpctransport.NewServer(
+			introspector.New(client.Introspection, "users.admin")(e.VerifyAuditLogEndpoint),
+			decodeGRPCEmptyRequest,
+			encodeGRPCVerifyAuditLogResponse,
+			options...,
+		),
+	}
+}
+
+func (s *grpcServer) GetUserInfo(ctx context.Context, req *pb.GetUserInfoRequest) (*pb.User, error) {
+	_, rep, err := s.getUserInfo.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.User), nil
+}
+
+func (s *grpcServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserReply, error) {
+	_, rep, err := s.updateUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.UpdateUserReply), nil
+}
+
+func (s *grpcServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserReply, error) {
+	_, rep, err := s.deleteUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.DeleteUserReply), nil
+}
+
+func (s *grpcServer) SetAvatar(ctx context.Context, req *pb.SetAvatarRequest) (*pb.SetAvatarReply, error) {
+	_, rep, err := s.setAvatar.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.SetAvatarReply), nil
+}
+
+func (s *grpcServer) DeleteAvatar(ctx context.Context, req *pb.DeleteAvatarRequest) (*pb.DeleteAvatarReply, error) {
+	_, rep, err := s.deleteAvatar.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.DeleteAvatarReply), nil
+}
+
+func (s *grpcServer) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPReply, error) {
+	_, rep, err := s.enrollTOTP.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.EnrollTOTPReply), nil
+}
+
+func (s *grpcServer) EnableTOTP(ctx context.Context, req *pb.TOTPCodeRequest) (*pb.RecoveryCodesReply, error) {
+	_, rep, err := s.enableTOTP.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.RecoveryCodesReply), nil
+}
+
+func (s *grpcServer) DisableTOTP(ctx context.Context, req *pb.TOTPCodeRequest) (*pb.DisableTOTPReply, error) {
+	_, rep, err := s.disableTOTP.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.DisableTOTPReply), nil
+}
+
+func (s *grpcServer) RegenerateRecoveryCodes(ctx context.Context, req *pb.TOTPCodeRequest) (*pb.RecoveryCodesReply, error) {
+	_, rep, err := s.regenerateRecoveryCodes.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.RecoveryCodesReply), nil
+}
+
+func (s *grpcServer) ListIdentities(ctx context.Context, req *pb.ListIdentitiesRequest) (*pb.ListIdentitiesReply, error) {
+	_, rep, err := s.listIdentities.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.ListIdentitiesReply), nil
+}
+
+func (s *grpcServer) UnlinkIdentity(ctx context.Context, req *pb.UnlinkIdentityRequest) (*pb.UnlinkIdentityReply, error) {
+	_, rep, err := s.unlinkIdentity.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.UnlinkIdentityReply), nil
+}
+
+func (s *grpcServer) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsReply, error) {
+	_, rep, err := s.listSessions.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.ListSessionsReply), nil
+}
+
+func (s *grpcServer) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionReply, error) {
+	_, rep, err := s.revokeSession.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.RevokeSessionReply), nil
+}
+
+func (s *grpcServer) ListApps(ctx context.Context, req *pb.ListAppsRequest) (*pb.ListAppsReply, error) {
+	_, rep, err := s.listApps.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.ListAppsReply), nil
+}
+
+func (s *grpcServer) RevokeApp(ctx context.Context, req *pb.RevokeAppRequest) (*pb.RevokeAppReply, error) {
+	_, rep, err := s.revokeApp.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.RevokeAppReply), nil
+}
+
+func (s *grpcServer) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.Profile, error) {
+	_, rep, err := s.getProfile.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.Profile), nil
+}
+
+func (s *grpcServer) GetProfiles(ctx context.Context, req *pb.GetProfilesRequest) (*pb.GetProfilesReply, error) {
+	_, rep, err := s.getProfiles.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.GetProfilesReply), nil
+}
+
+func (s *grpcServer) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.SearchUsersReply, error) {
+	_, rep, err := s.searchUsers.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.SearchUsersReply), nil
+}
+
+func (s *grpcServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersReply, error) {
+	_, rep, err := s.listUsers.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.ListUsersReply), nil
+}
+
+func (s *grpcServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	_, rep, err := s.getUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.User), nil
+}
+
+func (s *grpcServer) ForcePasswordReset(ctx context.Context, req *pb.ForcePasswordResetRequest) (*pb.ForcePasswordResetReply, error) {
+	_, rep, err := s.forcePasswordReset.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.ForcePasswordResetReply), nil
+}
+
+func (s *grpcServer) SetUserActive(ctx context.Context, req *pb.SetUserActiveRequest) (*pb.SetUserActiveReply, error) {
+	_, rep, err := s.setUserActive.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.SetUserActiveReply), nil
+}
+
+func (s *grpcServer) PurgeUser(ctx context.Context, req *pb.PurgeUserRequest) (*pb.PurgeUserReply, error) {
+	_, rep, err := s.purgeUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.PurgeUserReply), nil
+}
+
+func (s *grpcServer) ListStateChanges(ctx context.Context, req *pb.ListStateChangesRequest) (*pb.ListStateChangesReply, error) {
+	_, rep, err := s.listStateChanges.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.ListStateChangesReply), nil
+}
+
+func (s *grpcServer) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsReply, error) {
+	_, rep, err := s.listAuditEvents.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.ListAuditEventsReply), nil
+}
+
+func (s *grpcServer) VerifyAuditLog(ctx context.Context, req *pb.VerifyAuditLogRequest) (*pb.VerifyAuditLogReply, error) {
+	_, rep, err := s.verifyAuditLog.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return rep.(*pb.VerifyAuditLogReply), nil
+}
+
+// toGRPCContext moves the bearer token from the authorization metadata into the context, where introspector expects
+// to find it, as introspector.ToHTTPContext does for the Authorization header. The client's address and user agent
+// go in the context too, as they do over HTTP.
+func toGRPCContext(ctx context.Context, md metadata.MD) context.Context {
+	if p, ok := peer.FromContext(ctx); ok {
+		ip := p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		ctx = context.WithValue(ctx, RemoteAddrContextKey, ip)
+	}
+	if ua := md.Get("user-agent"); len(ua) > 0 {
+		ctx = context.WithValue(ctx, UserAgentContextKey, ua[0])
+	}
+	for _, v := range md.Get("authorization") {
+		if len(v) > 7 && strings.EqualFold(v[:7], "bearer ") {
+			return context.WithValue(ctx, introspector.OAuth2TokenContextKey, v[7:])
+		}
+	}
+	return ctx
+}
+
+// grpcError converts an error to a gRPC status. The usersvc error code, as sent in the error_code field over HTTP,
+// goes in the error-code trailer.
+func grpcError(ctx context.Context, err error) error {
+	e, ok := err.(svcerror.Error)
+	if !ok {
+		e = svcerror.Wrap(codes.Nil, err)
+	}
+	grpc.SetTrailer(ctx, metadata.Pairs("error-code", strconv.Itoa(e.Status())))
+	return status.Error(grpcCodeFrom(e.Status()), e.Error())
+}
+
+func grpcCodeFrom(code int) grpccodes.Code {
+	switch code {
+	case codes.Nil:
+		return grpccodes.Internal
+	case codes.BadRequest:
+		return grpccodes.InvalidArgument
+	case codes.WrongPassword:
+		return grpccodes.InvalidArgument
+	case codes.WrongEmail:
+		return grpccodes.InvalidArgument
+	case codes.HashFailed:
+		return grpccodes.Internal
+	case codes.UserExists:
+		return grpccodes.AlreadyExists
+	case codes.InvalidToken:
+		return grpccodes.InvalidArgument
+	case codes.NotFound:
+		return grpccodes.NotFound
+	case codes.DeleteOwner:
+		return grpccodes.FailedPrecondition
+	case codes.EmailInUse:
+		return grpccodes.AlreadyExists
+	case codes.TooManyAttempts:
+		return grpccodes.ResourceExhausted
+	case codes.WrongCode:
+		return grpccodes.InvalidArgument
+	case codes.TwoFactorEnabled:
+		return grpccodes.FailedPrecondition
+	case codes.InvalidCredential:
+		return grpccodes.InvalidArgument
+	case codes.IdentityInUse:
+		return grpccodes.AlreadyExists
+	case codes.LastIdentity:
+		return grpccodes.FailedPrecondition
+	case codes.LinkRequired:
+		return grpccodes.FailedPrecondition
+	case codes.AccountInactive:
+		return grpccodes.PermissionDenied
+	case codes.InvalidTransition:
+		return grpccodes.FailedPrecondition
+	default:
+		return grpccodes.Internal
+	}
+}
+
+func decodeGRPCEmptyRequest(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeGRPCUpdateUserRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdateUserRequest)
+	return updateUserRequest{
+		Name:       stringValue(req.Name),
+		Email:      stringValue(req.Email),
+		Password:   stringValue(req.Password),
+		GivenName:  stringValue(req.GivenName),
+		FamilyName: stringValue(req.FamilyName),
+		Pronouns:   stringValue(req.Pronouns),
+		Locale:     stringValue(req.Locale),
+		Zoneinfo:   stringValue(req.Zoneinfo),
+	}, nil
+}
+
+func decodeGRPCSetAvatarRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SetAvatarRequest)
+	return setAvatarRequest{ContentType: req.ContentType, Image: bytes.NewReader(req.Image)}, nil
+}
+
+func decodeGRPCTOTPCodeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return totpCodeRequest{Code: grpcReq.(*pb.TOTPCodeRequest).Code}, nil
+}
+
+func decodeGRPCUnlinkIdentityRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return unlinkIdentityRequest{Provider: grpcReq.(*pb.UnlinkIdentityRequest).Provider}, nil
+}
+
+func decodeGRPCRevokeSessionRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	id, err := uuid.Parse(grpcReq.(*pb.RevokeSessionRequest).SessionId)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return revokeSessionRequest{SessionID: id}, nil
+}
+
+func decodeGRPCRevokeAppRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return revokeAppRequest{ClientID: grpcReq.(*pb.RevokeAppRequest).ClientId}, nil
+}
+
+func decodeGRPCGetProfileRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	id, err := uuid.Parse(grpcReq.(*pb.GetProfileRequest).UserId)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return getProfileRequest{UserID: id}, nil
+}
+
+func decodeGRPCGetProfilesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GetProfilesRequest)
+	ids := make([]uuid.UUID, len(req.UserIds))
+	for i, s := range req.UserIds {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, ErrBadRequest
+		}
+		ids[i] = id
+	}
+	return getProfilesRequest{UserIDs: ids}, nil
+}
+
+func decodeGRPCSearchUsersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SearchUsersRequest)
+	return searchUsersRequest{Query: req.Query, Limit: int(req.Limit), Cursor: req.Cursor}, nil
+}
+
+func decodeGRPCListUsersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListUsersRequest)
+	if _, ok := transitions[req.State]; req.State != "" && !ok {
+		return nil, ErrBadRequest
+	}
+	return UserFilter{
+		Email:  req.Email,
+		Name:   req.Name,
+		Active: boolValue(req.Active),
+		State:  req.State,
+		Limit:  int(req.Limit),
+		Cursor: req.Cursor,
+	}, nil
+}
+
+func decodeGRPCListAuditEventsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListAuditEventsRequest)
+	filter := AuditFilter{
+		Type:   req.Type,
+		Limit:  int(req.Limit),
+		Cursor: req.Cursor,
+	}
+	if req.UserId != "" {
+		id, err := uuid.Parse(req.UserId)
+		if err != nil {
+			return nil, ErrBadRequest
+		}
+		filter.UserID = &id
+	}
+	var err error
+	if req.Since != nil {
+		if filter.Since, err = ptypes.Timestamp(req.Since); err != nil {
+			return nil, ErrBadRequest
+		}
+	}
+	if req.Until != nil {
+		if filter.Until, err = ptypes.Timestamp(req.Until); err != nil {
+			return nil, ErrBadRequest
+		}
+	}
+	return filter, nil
+}
+
+// decodeGRPCAdminUserRequest decodes any request that only names the user an administrator acts on.
+func decodeGRPCAdminUserRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	id, err := uuid.Parse(grpcReq.(interface{ GetUserId() string }).GetUserId())
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return adminUserRequest{UserID: id}, nil
+}
+
+func decodeGRPCSetUserActiveRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SetUserActiveRequest)
+	id, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return setUserActiveRequest{UserID: id, Active: req.Active, Reason: req.Reason}, nil
+}
+
+// encodeGRPCEmptyReply returns an encoder for calls whose reply carries nothing but success.
+func encodeGRPCEmptyReply(reply func() interface{}) grpctransport.EncodeResponseFunc {
+	return func(_ context.Context, response interface{}) (interface{}, error) {
+		if err := response.(errorer).error(); err != nil {
+			return nil, err
+		}
+		return reply(), nil
+	}
+}
+
+func encodeGRPCUser(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(getUserInfoResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return grpcUser(resp.User), nil
+}
+
+func encodeGRPCSetAvatarResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(setAvatarResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return &pb.SetAvatarReply{Picture: resp.Picture}, nil
+}
+
+func encodeGRPCEnrollTOTPResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(enrollTOTPResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return &pb.EnrollTOTPReply{Secret: resp.Secret, Uri: resp.URI}, nil
+}
+
+func encodeGRPCRecoveryCodesResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(recoveryCodesResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return &pb.RecoveryCodesReply{RecoveryCodes: resp.RecoveryCodes}, nil
+}
+
+func encodeGRPCListIdentitiesResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(listIdentitiesResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	reply := &pb.ListIdentitiesReply{}
+	for _, fi := range resp.Identities {
+		reply.Identities = append(reply.Identities, &pb.Identity{
+			Provider:  fi.Provider,
+			Subject:   fi.Subject,
+			Email:     fi.Email,
+			CreatedAt: timestampProto(fi.CreatedAt),
+		})
+	}
+	return reply, nil
+}
+
+func encodeGRPCListSessionsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(listSessionsResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	reply := &pb.ListSessionsReply{}
+	for _, session := range resp.Sessions {
+		reply.Sessions = append(reply.Sessions, &pb.Session{
+			Id:         session.ID.String(),
+			CreatedAt:  timestampProto(session.CreatedAt),
+			LastSeenAt: timestampProto(session.LastSeenAt),
+			Ip:         session.IP,
+			UserAgent:  session.UserAgent,
+		})
+	}
+	return reply, nil
+}
+
+func encodeGRPCListAppsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(listAppsResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	reply := &pb.ListAppsReply{}
+	for _, app := range resp.Apps {
+		reply.Apps = append(reply.Apps, &pb.App{
+			ClientId:  app.ClientID,
+			Name:      app.Name,
+			ClientUri: app.ClientURI,
+			LogoUri:   app.LogoURI,
+			Scopes:    app.Scopes,
+			GrantedAt: timestampProto(app.GrantedAt),
+			UpdatedAt: timestampProto(app.UpdatedAt),
+		})
+	}
+	return reply, nil
+}
+
+func encodeGRPCGetProfileResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(getProfileResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return grpcProfile(resp.Profile), nil
+}
+
+func encodeGRPCGetProfilesResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(getProfilesResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	reply := &pb.GetProfilesReply{Profiles: make(map[string]*pb.Profile, len(resp.Profiles))}
+	for id, p := range resp.Profiles {
+		reply.Profiles[id.String()] = grpcProfile(p)
+	}
+	for _, id := range resp.Missing {
+		reply.Missing = append(reply.Missing, id.String())
+	}
+	return reply, nil
+}
+
+func encodeGRPCSearchUsersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(searchUsersResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	reply := &pb.SearchUsersReply{Cursor: resp.Cursor}
+	for _, p := range resp.Profiles {
+		reply.Profiles = append(reply.Profiles, grpcProfile(p))
+	}
+	return reply, nil
+}
+
+func encodeGRPCListUsersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(listUsersResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	reply := &pb.ListUsersReply{Cursor: resp.Cursor}
+	for _, u := range resp.Users {
+		reply.Users = append(reply.Users, grpcUser(u))
+	}
+	return reply, nil
+}
+
+func grpcUser(u *models.User) *pb.User {
+	return &pb.User{
+		Id:            u.ID.String(),
+		Name:          u.Name,
+		Email:         u.Email,
+		Active:        u.State == StateActive,
+		EmailVerified: u.EmailVerified,
+		GivenName:     u.GivenName,
+		FamilyName:    u.FamilyName,
+		Pronouns:      u.Pronouns,
+		Picture:       u.Picture,
+		Locale:        u.Locale,
+		Zoneinfo:      u.Zoneinfo,
+		State:         u.State,
+	}
+}
+
+func grpcProfile(p *Profile) *pb.Profile {
+	return &pb.Profile{
+		Id:         p.ID.String(),
+		Name:       p.Name,
+		GivenName:  p.GivenName,
+		FamilyName: p.FamilyName,
+		Pronouns:   p.Pronouns,
+		Picture:    p.Picture,
+		Locale:     p.Locale,
+		Zoneinfo:   p.Zoneinfo,
+	}
+}
+
+func encodeGRPCListStateChangesResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(listStateChangesResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	reply := &pb.ListStateChangesReply{}
+	for _, sc := range resp.Changes {
+		c := &pb.StateChange{
+			Id:        sc.ID.String(),
+			UserId:    sc.UserID.String(),
+			FromState: sc.FromState,
+			ToState:   sc.ToState,
+			Reason:    sc.Reason,
+			CreatedAt: timestampProto(sc.CreatedAt),
+		}
+		if sc.ActorID != nil {
+			c.ActorId = sc.ActorID.String()
+		}
+		reply.Changes = append(reply.Changes, c)
+	}
+	return reply, nil
+}
+
+func encodeGRPCListAuditEventsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(listAuditEventsResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	reply := &pb.ListAuditEventsReply{Cursor: resp.Cursor}
+	for _, ae := range resp.Events {
+		e := &pb.AuditEvent{
+			Id:        ae.ID,
+			Type:      ae.Type,
+			ClientId:  ae.ClientID,
+			Ip:        ae.IP,
+			UserAgent: ae.UserAgent,
+			CreatedAt: timestampProto(ae.CreatedAt),
+			Hash:      ae.Hash,
+		}
+		if ae.ActorID != nil {
+			e.ActorId = ae.ActorID.String()
+		}
+		if ae.SubjectID != nil {
+			e.SubjectId = ae.SubjectID.String()
+		}
+		if err := json.Unmarshal(ae.Metadata, &e.Metadata); err != nil {
+			return nil, err
+		}
+		reply.Events = append(reply.Events, e)
+	}
+	return reply, nil
+}
+
+func encodeGRPCVerifyAuditLogResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(verifyAuditLogResponse)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return &pb.VerifyAuditLogReply{Intact: resp.Intact, BrokenAt: resp.BrokenAt}, nil
+}
+
+// stringValue returns the value of an optional string, or nil if it wasn't set.
+func stringValue(v *wrappers.StringValue) *string {
+	if v == nil {
+		return nil
+	}
+	return &v.Value
+}
+
+// boolValue returns the value of an optional bool, or nil if it wasn't set.
+func boolValue(v *wrappers.BoolValue) *bool {
+	if v == nil {
+		return nil
+	}
+	return &v.Value
+}
+
+// timestampProto converts t for a reply. Every time usersvc keeps is within the range a Timestamp can hold.
+func timestampProto(t time.Time) *timestamp.Timestamp {
+	return &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
@@ -2,12 +2,20 @@ package usersvc
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/json"
 	"html/template"
+	"io"
+	"mime"
+	"net"
 	"net/http"
-	"sort"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/log"
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/google/uuid"
@@ -21,14 +29,20 @@ import (
 	"github.com/studiously/svcerror"
 	"github.com/studiously/usersvc/codes"
 	"github.com/studiously/usersvc/ddl"
+	"github.com/studiously/usersvc/oidc"
 	"github.com/studiously/usersvc/templates"
+	"github.com/studiously/usersvc/webauthn"
 )
 
 var (
-	tmpls     = templates.NewBinTemplate(ddl.Asset, ddl.AssetDir).MustLoadDirectory("tmpl")
+	tmpls = templates.NewBinTemplate(ddl.Asset, ddl.AssetDir).MustLoadDirectory("tmpl")
+	// store keeps the cookie with the session token and any login in progress. Sessions themselves are kept by the
+	// service, so that they can be revoked.
 	store     = sessions.NewCookieStore([]byte(env.Getenv("COOKIE_SECRET", string(securecookie.GenerateRandomKey(32)))))
 	secure, _ = strconv.ParseBool(env.Getenv("SECURE_CSRF", "false"))
-	CSRF      = csrf.Protect([]byte("aNdRgUkXp2r5u8x/A?D(G+KbPeShVmYq"), csrf.Secure(secure))
+	// trustProxy controls whether the client address is taken from X-Forwarded-For rather than the connection.
+	trustProxy, _ = strconv.ParseBool(env.Getenv("TRUST_PROXY", "false"))
+	CSRF          = csrf.Protect([]byte("aNdRgUkXp2r5u8x/A?D(G+KbPeShVmYq"), csrf.Secure(secure))
 	//ErrBadRouting    = errors.New("Inconsistent mapping between route and handler (programmer error).")
 	//ErrPersistCookie = errors.New("Failed to add a cookie. Make sure to enable cookies.")
 	//ErrInternal      = errors.New("We're having a problem on our end. Hang tight and we'll get it fixed.")
@@ -43,6 +57,8 @@ var (
 
 const (
 	sessionName = "authentication"
+	// pendingTTL is how long a user has to enter their second factor after their password.
+	pendingTTL = 5 * time.Minute
 )
 
 // MakeHTTPHandler mounts all of the service endpoints into an http.Handler.
@@ -54,43 +70,155 @@ func MakeHTTPHandler(s Service, client *sdk.Client, logger log.Logger) http.Hand
 	options := []httptransport.ServerOption{
 		httptransport.ServerErrorLogger(logger),
 		httptransport.ServerErrorEncoder(encodeError),
-		httptransport.ServerBefore(introspector.ToHTTPContext()),
+		httptransport.ServerBefore(introspector.ToHTTPContext(), clientToContext),
 	}
 
-	r.Methods("GET").Path("/userinfo").Handler(httptransport.NewServer(
-		introspector.New(client.Introspection, "users.get")(e.GetUserInfoEndpoint),
-		DecodeGetUserInfoRequest,
-		encodeResponse,
-		options...
-	))
-	r.Methods("GET").Path("/users/{userID}").Handler(httptransport.NewServer(
-		introspector.New(client.Introspection, "users.get")(e.GetProfileEndpoint),
-		DecodeGetProfileRequest,
-		encodeResponse,
-		options...
-	))
+	// The document is made once every route has been added, as it describes them.
+	openAPI := r.Methods("GET").Path("/openapi.json")
+
+	// handle serves an endpoint to clients with an access token granted scope. The OpenAPI document takes the scope
+	// from here, so that it can't differ from the one enforced.
+	scopes := map[string]string{}
+	handle := func(method, path, scope string, ep endpoint.Endpoint, dec httptransport.DecodeRequestFunc) {
+		scopes[method+" "+path] = scope
+		r.Methods(method).Path(path).Handler(httptransport.NewServer(
+			introspector.New(client.Introspection, scope)(ep),
+			dec,
+			encodeResponse,
+			options...,
+		))
+	}
 
-	r.Methods("GET").Path("/register").Handler(MakeGetRegister())
+	handle("GET", "/userinfo", "users.get", e.GetUserInfoEndpoint, DecodeGetUserInfoRequest)
+	handle("PATCH", "/userinfo", "users.update", e.UpdateUserEndpoint, DecodeUpdateUserRequest)
+	handle("DELETE", "/userinfo", "users.delete", e.DeleteUserEndpoint, DecodeDeleteUserRequest)
+	handle("POST", "/userinfo/restore", "users.delete", e.RestoreUserEndpoint, DecodeRestoreUserRequest)
+	handle("PUT", "/userinfo/avatar", "users.update", e.SetAvatarEndpoint, DecodeSetAvatarRequest)
+	handle("DELETE", "/userinfo/avatar", "users.update", e.DeleteAvatarEndpoint, DecodeDeleteAvatarRequest)
+	handle("POST", "/userinfo/totp", "users.update", e.EnrollTOTPEndpoint, DecodeEnrollTOTPRequest)
+	handle("POST", "/userinfo/totp/enable", "users.update", e.EnableTOTPEndpoint, DecodeTOTPCodeRequest)
+	handle("POST", "/userinfo/totp/disable", "users.update", e.DisableTOTPEndpoint, DecodeTOTPCodeRequest)
+	handle("POST", "/userinfo/totp/recovery-codes", "users.update", e.RegenerateRecoveryCodesEndpoint, DecodeTOTPCodeRequest)
+	handle("POST", "/userinfo/passkeys/options", "users.update", e.BeginPasskeyRegistrationEndpoint, DecodeBeginPasskeyRegistrationRequest)
+	handle("POST", "/userinfo/passkeys", "users.update", e.FinishPasskeyRegistrationEndpoint, DecodeFinishPasskeyRegistrationRequest)
+	handle("GET", "/userinfo/identities", "users.get", e.ListIdentitiesEndpoint, DecodeListIdentitiesRequest)
+	handle("DELETE", "/userinfo/identities/{provider}", "users.update", e.UnlinkIdentityEndpoint, DecodeUnlinkIdentityRequest)
+	handle("GET", "/sessions", "users.get", e.ListSessionsEndpoint, DecodeListSessionsRequest)
+	handle("DELETE", "/sessions/{sessionID}", "users.update", e.RevokeSessionEndpoint, DecodeRevokeSessionRequest)
+	handle("GET", "/apps", "users.get", e.ListAppsEndpoint, DecodeListAppsRequest)
+	handle("DELETE", "/apps/{clientID}", "users.update", e.RevokeAppEndpoint, DecodeRevokeAppRequest)
+	handle("POST", "/userinfo/export", "users.get", e.StartExportEndpoint, DecodeStartExportRequest)
+	handle("GET", "/userinfo/export/{exportID}", "users.get", e.GetExportEndpoint, DecodeGetExportRequest)
+	handle("POST", "/users:batchGet", "users.get", e.GetProfilesEndpoint, DecodeGetProfilesRequest)
+	handle("GET", "/users:search", "users.search", e.SearchUsersEndpoint, DecodeSearchUsersRequest)
+	handle("GET", "/users/{userID}", "users.get", e.GetProfileEndpoint, DecodeGetProfileRequest)
+	handle("GET", "/admin/users", "users.admin", e.ListUsersEndpoint, DecodeListUsersRequest)
+	handle("GET", "/admin/users/{userID}", "users.admin", e.GetUserEndpoint, DecodeAdminUserRequest)
+	handle("POST", "/admin/users/{userID}/reset-password", "users.admin", e.ForcePasswordResetEndpoint, DecodeAdminUserRequest)
+	handle("POST", "/admin/users/{userID}/deactivate", "users.admin", e.SetUserActiveEndpoint, DecodeSetUserActiveRequest(false))
+	handle("POST", "/admin/users/{userID}/reactivate", "users.admin", e.SetUserActiveEndpoint, DecodeSetUserActiveRequest(true))
+	handle("DELETE", "/admin/users/{userID}", "users.admin", e.PurgeUserEndpoint, DecodeAdminUserRequest)
+	handle("GET", "/admin/users/{userID}/state-changes", "users.admin", e.ListStateChangesEndpoint, DecodeAdminUserRequest)
+	handle("GET", "/admin/audit-events", "users.admin", e.ListAuditEventsEndpoint, DecodeListAuditEventsRequest)
+	handle("GET", "/admin/audit-events:verify", "users.admin", e.VerifyAuditLogEndpoint, DecodeVerifyAuditLogRequest)
+	// Pictures are public, so that browsers can load them without a token.
+	r.Methods("GET").Path("/avatars/{userID}/{size:[0-9]+}").Handler(MakeGetAvatar(s))
+	// Export links carry their own token, so that they can be opened in a browser.
+	r.Methods("GET").Path("/exports/{exportID}").Handler(MakeGetExportDownload(s))
+
+	r.Methods("GET").Path("/register").Handler(MakeGetRegister(s))
 	r.Methods("POST").Path("/register").Handler(MakePostRegister(s, logger))
 
-	r.Methods("GET").Path("/login").Handler(MakeGetLogin())
+	r.Methods("GET").Path("/login").Handler(MakeGetLogin(s))
 	r.Methods("POST").Path("/login").Handler(MakePostLogin(s, logger))
 
-	r.Methods("GET").Path("/consent").Handler(MakeGetConsent(client, logger))
-	r.Methods("POST").Path("/consent").Handler(MakePostConsent(client, logger))
+	r.Methods("GET").Path("/login/passkey").Handler(MakeGetPasskeyLogin(s, logger))
+
+	r.Methods("GET").Path("/login/2fa").Handler(MakeGetLogin2FA())
+	r.Methods("POST").Path("/login/2fa").Handler(MakePostLogin2FA(s, logger))
+
+	r.Methods("GET").Path("/oidc/{provider}").Handler(MakeGetFederatedLogin(s, logger))
+	r.Methods("GET").Path("/oidc/{provider}/callback").Handler(MakeGetFederatedCallback(s, logger))
 
-	r.Methods("GET").Path("/logout").Handler(MakeGetLogout())
+	r.Methods("GET").Path("/reset").Handler(MakeGetReset())
+	r.Methods("POST").Path("/reset").Handler(MakePostReset(s, logger))
+
+	r.Methods("GET").Path("/reset/confirm").Handler(MakeGetResetConfirm())
+	r.Methods("POST").Path("/reset/confirm").Handler(MakePostResetConfirm(s, logger))
+
+	r.Methods("GET").Path("/verify").Handler(MakeGetVerify(s, logger))
+
+	r.Methods("GET").Path("/consent").Handler(MakeGetConsent(s, client, logger))
+	r.Methods("POST").Path("/consent").Handler(MakePostConsent(s, client, logger))
+
+	r.Methods("GET").Path("/logout").Handler(MakeGetLogout(s, logger))
+
+	doc, err := describeAPI(r, scopes)
+	if err != nil {
+		// Serving what could be described beats refusing to start; TestOpenAPI catches this before release.
+		logger.Log("msg", "OpenAPI document is incomplete", "error", err)
+	}
+	openAPI.Handler(MakeGetOpenAPI(doc))
 
 	return r
 }
 
-func MakeGetRegister() http.Handler {
+// MakeGetAvatar serves users' pictures.
+func MakeGetAvatar(s Service) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, err := uuid.Parse(mux.Vars(r)["userID"])
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		size, _ := strconv.Atoi(mux.Vars(r)["size"])
+		avatar, err := s.GetAvatar(r.Context(), userID, size)
+		if err == ErrNotFound {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		defer avatar.Close()
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		io.Copy(w, avatar)
+	})
+}
+
+// MakeGetExportDownload serves finished exports to whoever has a link to them.
+func MakeGetExportDownload(s Service) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exportID, err := uuid.Parse(mux.Vars(r)["exportID"])
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		export, err := s.DownloadExport(r.Context(), exportID, r.URL.Query().Get("token"))
+		if err == ErrNotFound || err == ErrInvalidToken {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		defer export.Close()
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", `attachment; filename="studiously-export.zip"`)
+		w.Header().Set("Cache-Control", "private, no-store")
+		io.Copy(w, export)
+	})
+}
+
+func MakeGetRegister(s Service) http.Handler {
 	return CSRF(http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			tmpls.ExecuteTemplate(w, "register.html", map[string]interface{}{
 				csrf.TemplateTag: csrf.TemplateField(r),
 				"challenge":      r.URL.Query().Get("challenge"),
 				"error":          r.URL.Query().Get("error"),
+				"providers":      s.IdentityProviders(r.Context()),
 			})
 		}))
 }
@@ -111,6 +239,7 @@ func MakePostRegister(s Service, logger log.Logger) http.Handler {
 					csrf.TemplateTag: csrf.TemplateField(r),
 					"challenge":      r.URL.Query().Get("challenge"),
 					"error":          err.Error(),
+					"providers":      s.IdentityProviders(r.Context()),
 				})
 				return
 			}
@@ -118,7 +247,7 @@ func MakePostRegister(s Service, logger log.Logger) http.Handler {
 		}))
 }
 
-func MakeGetConsent(client *sdk.Client, logger log.Logger) http.Handler {
+func MakeGetConsent(s Service, client *sdk.Client, logger log.Logger) http.Handler {
 	return CSRF(http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			// First, check if hydra returned an error.
@@ -147,22 +276,44 @@ func MakeGetConsent(client *sdk.Client, logger log.Logger) http.Handler {
 				tmpls.ExecuteTemplate(w, "error.html", nil)
 				return
 			}
-			// Check if the user is authenticated.
-			user := authenticated(r)
+			// Check if the user is authenticated, recently enough for the client.
+			user, authTime, reauth := consentUser(s, r, claims, challenge)
+			if reauth {
+				http.Redirect(w, r, "/login?prompt=login&challenge="+challenge, http.StatusFound)
+				return
+			}
 			if user == nil {
 				// Nope, not authenticated. Redirect the user to the authenticate endpoint.
 				http.Redirect(w, r, "/login?challenge="+challenge, http.StatusFound)
 				return
 			}
 			// Determine if nonconsentual is a requested scope.
-			if sort.SearchStrings(claims.RequestedScopes, "nonconsentual") < len(claims.RequestedScopes) {
-				// Since consent is nonconsentual, we can bypass the consent page and automatically grant all requested scopes. This will only apply to official clients.
+			skip := grantsAll(claims.RequestedScopes, []string{"nonconsentual"})
+			// If not, the user needn't be asked again for scopes they have already granted, unless the client insists.
+			if !skip && !promptsConsent(claims) {
+				remembered, err := s.RememberedScopes(withClient(r), *user, claims.Audience)
+				if err != nil {
+					logger.Log("msg", "cannot look up remembered consent", "error", err)
+					tmpls.ExecuteTemplate(w, "error.html", nil)
+					return
+				}
+				skip = len(remembered) > 0 && grantsAll(remembered, claims.RequestedScopes)
+			}
+			if skip {
+				extra, err := idTokenExtra(s, r, claims, authTime)
+				if err != nil {
+					logger.Log("msg", "cannot record client in session", "error", err)
+					tmpls.ExecuteTemplate(w, "error.html", nil)
+					return
+				}
+				// Since consent is nonconsentual or already given, we can bypass the consent page and automatically grant all requested scopes. Nonconsentual will only apply to official clients.
 				redirectUrl, err := client.Consent.GenerateResponse(&sdk.ResponseRequest{
 					Challenge: challenge,
 					// The subject is a string, usually the user id.
 					Subject: user.String(),
 					// The scopes our user granted.
-					Scopes: claims.RequestedScopes,
+					Scopes:       claims.RequestedScopes,
+					IDTokenExtra: extra,
 				})
 				// If there's a problem, we need to abort and render the error page.
 				if err != nil {
@@ -177,12 +328,12 @@ func MakeGetConsent(client *sdk.Client, logger log.Logger) http.Handler {
 			tmpls.ExecuteTemplate(w, "consent.html", struct {
 				*sdk.ChallengeClaims
 				Challenge string
-				csrfField template.HTML
-			}{ChallengeClaims: claims, Challenge: challenge, csrfField: csrf.TemplateField(r) })
+				CSRFField template.HTML
+			}{ChallengeClaims: claims, Challenge: challenge, CSRFField: csrf.TemplateField(r)})
 		}))
 }
 
-func MakePostConsent(client *sdk.Client, logger log.Logger) http.Handler {
+func MakePostConsent(s Service, client *sdk.Client, logger log.Logger) http.Handler {
 	return CSRF(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		challenge := r.URL.Query().Get("challenge")
 		if challenge == "" {
@@ -191,7 +342,18 @@ func MakePostConsent(client *sdk.Client, logger log.Logger) http.Handler {
 			return
 		}
 
-		user := authenticated(r)
+		// The challenge is checked again, or else posting the form would get around its max_age.
+		claims, err := client.Consent.VerifyChallenge(challenge)
+		if err != nil {
+			logger.Log("msg", "challenge could not be verified", "error", err)
+			tmpls.ExecuteTemplate(w, "error.html", nil)
+			return
+		}
+		user, authTime, reauth := consentUser(s, r, claims, challenge)
+		if reauth {
+			http.Redirect(w, r, "/login?prompt=login&challenge="+challenge, http.StatusFound)
+			return
+		}
 		if user == nil {
 			http.Redirect(w, r, "/login?challenge="+challenge, http.StatusFound)
 			return
@@ -205,8 +367,26 @@ func MakePostConsent(client *sdk.Client, logger log.Logger) http.Handler {
 
 		var grantedScopes = []string{}
 		for key := range r.PostForm {
-			// And add each scope to the list of granted scopes.
-			grantedScopes = append(grantedScopes, key)
+			// And add each scope to the list of granted scopes, if it was asked for. Other fields, such as the CSRF
+			// token, mustn't be granted, let alone remembered.
+			if grantsAll(claims.RequestedScopes, []string{key}) {
+				grantedScopes = append(grantedScopes, key)
+			}
+		}
+		// Users pending deletion are refused here, before anything is remembered.
+		extra, err := idTokenExtra(s, r, claims, authTime)
+		if err != nil {
+			logger.Log("msg", "cannot record client in session", "error", err)
+			tmpls.ExecuteTemplate(w, "error.html", nil)
+			return
+		}
+		// Remember what was granted, so that the user isn't asked for it again.
+		if len(grantedScopes) > 0 {
+			if err := s.RememberConsent(withClient(r), *user, claims.Audience, grantedScopes); err != nil {
+				logger.Log("msg", "cannot remember consent", "error", err)
+				tmpls.ExecuteTemplate(w, "error.html", nil)
+				return
+			}
 		}
 		redirectUrl, err := client.Consent.GenerateResponse(&sdk.ResponseRequest{
 			Challenge: challenge,
@@ -216,6 +396,8 @@ func MakePostConsent(client *sdk.Client, logger log.Logger) http.Handler {
 
 			// The scopes our user granted.
 			Scopes: grantedScopes,
+
+			IDTokenExtra: extra,
 		})
 		if err != nil {
 			logger.Log("msg", "cannot generate response to challenge", "error", err)
@@ -227,13 +409,13 @@ func MakePostConsent(client *sdk.Client, logger log.Logger) http.Handler {
 	}))
 }
 
-func MakeGetLogin() http.Handler {
+func MakeGetLogin(s Service) http.Handler {
 	return CSRF(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		challenge := r.FormValue("challenge")
-		// If the user is already authenticated, redirect to the consent phase.
-		session, _ := store.Get(r, sessionName)
-		_, ok := session.Values["user"]
-		if ok {
+		// If the user is already authenticated, redirect to the consent phase, unless consent sent them back to log
+		// in again.
+		reauth := r.URL.Query().Get("prompt") == "login"
+		if !reauth && authenticated(s, r) != nil {
 			http.Redirect(w, r, "/consent?challenge="+challenge, http.StatusFound)
 			return
 		}
@@ -241,7 +423,9 @@ func MakeGetLogin() http.Handler {
 		// If there is a challenge, we pass it on.
 		tmpls.ExecuteTemplate(w, "login.html", map[string]interface{}{
 			"challenge":      challenge,
+			"reauth":         reauth,
 			"email":          r.URL.Query().Get("email"),
+			"providers":      s.IdentityProviders(r.Context()),
 			csrf.TemplateTag: csrf.TemplateField(r),
 		})
 
@@ -257,7 +441,12 @@ func MakePostLogin(s Service, logger log.Logger) http.Handler {
 				tmpls.ExecuteTemplate(w, "error.html", nil)
 				return
 			}
+			if passkey := r.FormValue("passkey"); passkey != "" {
+				loginWithPasskey(s, logger, w, r, passkey)
+				return
+			}
 			user, err := s.Authenticate(
+				withClient(r),
 				r.FormValue("email"),
 				r.FormValue("password"),
 			)
@@ -268,40 +457,455 @@ func MakePostLogin(s Service, logger log.Logger) http.Handler {
 					tmpls.ExecuteTemplate(w, "error.html", nil)
 					return
 				}
+				msg := err.Error()
+				if err == ErrWrongEmail || err == ErrWrongPassword {
+					// Don't tell the browser which one it was, or the login form becomes a way to look up accounts.
+					msg = "wrong email or password"
+				}
 				tmpls.ExecuteTemplate(w, "login.html", map[string]interface{}{
-					"error":          err.Error(),
+					"error":          msg,
+					"email":          r.FormValue("email"),
+					"remember":       r.FormValue("remember") == "true",
 					"challenge":      r.URL.Query().Get("challenge"),
+					"providers":      s.IdentityProviders(r.Context()),
+					csrf.TemplateTag: csrf.TemplateField(r),
+				})
+				return
+			}
+			completeFirstFactor(s, logger, w, r, user, r.FormValue("challenge"), r.FormValue("remember") == "true")
+		},
+	))
+}
+
+// completeFirstFactor logs in a user who has proven who they are with a password or external account, unless they
+// also need to enter a second factor. remember is whether they asked to stay signed in.
+func completeFirstFactor(s Service, logger log.Logger, w http.ResponseWriter, r *http.Request, user uuid.UUID, challenge string, remember bool) {
+	twoFactor, err := s.TwoFactorEnabled(r.Context(), user)
+	if err != nil {
+		logger.Log("msg", "cannot check two-factor authentication", "error", err)
+		tmpls.ExecuteTemplate(w, "error.html", nil)
+		return
+	}
+	session, _ := store.Get(r, sessionName)
+	if twoFactor {
+		// The first factor alone isn't enough. Remember who got this far, but don't log them in yet.
+		session.Values["pending_user"] = user.String()
+		session.Values["pending_since"] = time.Now().Unix()
+		session.Values["pending_remember"] = remember
+		if err := store.Save(r, w, session); err != nil {
+			logger.Log("msg", "cannot persist session", "error", err)
+			tmpls.ExecuteTemplate(w, "error.html", nil)
+			return
+		}
+		http.Redirect(w, r, "/login/2fa?challenge="+challenge, http.StatusFound)
+		return
+	}
+	if err := logIn(s, w, r, user, challenge, remember); err != nil {
+		logger.Log("msg", "cannot persist session", "error", err)
+		tmpls.ExecuteTemplate(w, "error.html", nil)
+		return
+	}
+	http.Redirect(w, r, "/consent?challenge="+challenge, http.StatusFound)
+}
+
+// MakeGetFederatedLogin sends the user to log in with an external provider. With link=true, the provider's account
+// is linked to the user who is already logged in instead.
+func MakeGetFederatedLogin(s Service, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provider := mux.Vars(r)["provider"]
+		challenge := r.URL.Query().Get("challenge")
+		link := r.URL.Query().Get("link") == "true"
+		if link && authenticated(s, r) == nil {
+			http.Redirect(w, r, "/login?challenge="+challenge, http.StatusFound)
+			return
+		}
+		req, err := s.BeginFederatedLogin(r.Context(), provider)
+		if err != nil {
+			logger.Log("msg", "cannot begin federated login", "provider", provider, "error", err)
+			tmpls.ExecuteTemplate(w, "error.html", nil)
+			return
+		}
+		session, _ := store.Get(r, sessionName)
+		session.Values["oidc_provider"] = provider
+		session.Values["oidc_state"] = req.State
+		session.Values["oidc_nonce"] = req.Nonce
+		session.Values["oidc_verifier"] = req.Verifier
+		session.Values["oidc_challenge"] = challenge
+		session.Values["oidc_link"] = link
+		if err := store.Save(r, w, session); err != nil {
+			logger.Log("msg", "cannot persist session", "error", err)
+			tmpls.ExecuteTemplate(w, "error.html", nil)
+			return
+		}
+		http.Redirect(w, r, req.URL, http.StatusFound)
+	})
+}
+
+// MakeGetFederatedCallback handles the user's return from an external provider.
+func MakeGetFederatedCallback(s Service, logger log.Logger) http.Handler {
+	return CSRF(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provider := mux.Vars(r)["provider"]
+		session, _ := store.Get(r, sessionName)
+		req := oidc.AuthRequest{}
+		req.State, _ = session.Values["oidc_state"].(string)
+		req.Nonce, _ = session.Values["oidc_nonce"].(string)
+		req.Verifier, _ = session.Values["oidc_verifier"].(string)
+		started, _ := session.Values["oidc_provider"].(string)
+		challenge, _ := session.Values["oidc_challenge"].(string)
+		link, _ := session.Values["oidc_link"].(bool)
+		// Each request can only be completed once.
+		for _, key := range []string{"oidc_provider", "oidc_state", "oidc_nonce", "oidc_verifier", "oidc_challenge", "oidc_link"} {
+			delete(session.Values, key)
+		}
+		if err := store.Save(r, w, session); err != nil {
+			logger.Log("msg", "cannot persist session", "error", err)
+			tmpls.ExecuteTemplate(w, "error.html", nil)
+			return
+		}
+		// The state ties the callback to the browser that started the request, so nobody can log a victim into
+		// the attacker's account or link it to the victim's.
+		if req.State == "" || started != provider ||
+			subtle.ConstantTimeCompare([]byte(req.State), []byte(r.URL.Query().Get("state"))) != 1 {
+			logger.Log("msg", "federated login callback with wrong state", "provider", provider)
+			tmpls.ExecuteTemplate(w, "error.html", nil)
+			return
+		}
+		if e := r.URL.Query().Get("error"); e != "" {
+			// Most likely the user declined. Let them pick another way to log in.
+			logger.Log("msg", "provider error", "provider", provider, "error", e,
+				"error_description", r.URL.Query().Get("error_description"))
+			http.Redirect(w, r, "/login?challenge="+challenge, http.StatusFound)
+			return
+		}
+		code := r.URL.Query().Get("code")
+		if link {
+			user := authenticated(s, r)
+			if user == nil {
+				http.Redirect(w, r, "/login?challenge="+challenge, http.StatusFound)
+				return
+			}
+			err := s.LinkIdentity(withClient(r), *user, provider, req, code)
+			if err != nil {
+				if _, ok := err.(svcerror.Error); !ok {
+					logger.Log("msg", "cannot link identity", "provider", provider, "error", err)
+					tmpls.ExecuteTemplate(w, "error.html", nil)
+					return
+				}
+				tmpls.ExecuteTemplate(w, "linked.html", map[string]interface{}{
+					"provider": providerName(s, r.Context(), provider),
+					"error":    err.Error(),
+				})
+				return
+			}
+			tmpls.ExecuteTemplate(w, "linked.html", map[string]interface{}{
+				"provider": providerName(s, r.Context(), provider),
+			})
+			return
+		}
+		user, err := s.FederatedLogin(withClient(r), provider, req, code)
+		if err != nil {
+			if _, ok := err.(svcerror.Error); !ok {
+				logger.Log("msg", "cannot log in with provider", "provider", provider, "error", err)
+				tmpls.ExecuteTemplate(w, "error.html", nil)
+				return
+			}
+			tmpls.ExecuteTemplate(w, "login.html", map[string]interface{}{
+				"error":          err.Error(),
+				"challenge":      challenge,
+				"providers":      s.IdentityProviders(r.Context()),
+				csrf.TemplateTag: csrf.TemplateField(r),
+			})
+			return
+		}
+		completeFirstFactor(s, logger, w, r, user, challenge, false)
+	}))
+}
+
+func providerName(s Service, ctx context.Context, id string) string {
+	for _, p := range s.IdentityProviders(ctx) {
+		if p.ID == id {
+			return p.Name
+		}
+	}
+	return id
+}
+
+// MakeGetPasskeyLogin returns the options login.html passes to navigator.credentials.get.
+func MakeGetPasskeyLogin(s Service, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		opts, err := s.BeginPasskeyLogin(withClient(r))
+		if err != nil {
+			logger.Log("msg", "cannot begin passkey login", "error", err)
+		}
+		encodeResponse(r.Context(), w, beginPasskeyLoginResponse{
+			PublicKey: opts,
+			Error:     err,
+		})
+	})
+}
+
+// loginWithPasskey completes a login for which the browser posted a passkey assertion instead of a password.
+func loginWithPasskey(s Service, logger log.Logger, w http.ResponseWriter, r *http.Request, passkey string) {
+	var response webauthn.AssertionResponse
+	var user uuid.UUID
+	err := json.Unmarshal([]byte(passkey), &response)
+	if err == nil {
+		user, err = s.AuthenticatePasskey(withClient(r), response)
+	} else {
+		err = ErrInvalidCredential
+	}
+	if err != nil {
+		if _, ok := err.(svcerror.Error); !ok {
+			logger.Log("msg", "cannot authenticate user", "error", err)
+			tmpls.ExecuteTemplate(w, "error.html", nil)
+			return
+		}
+		tmpls.ExecuteTemplate(w, "login.html", map[string]interface{}{
+			"error":          err.Error(),
+			"remember":       r.FormValue("remember") == "true",
+			"challenge":      r.URL.Query().Get("challenge"),
+			"providers":      s.IdentityProviders(r.Context()),
+			csrf.TemplateTag: csrf.TemplateField(r),
+		})
+		return
+	}
+	challenge := r.FormValue("challenge")
+	if err := logIn(s, w, r, user, challenge, r.FormValue("remember") == "true"); err != nil {
+		logger.Log("msg", "cannot persist session", "error", err)
+		tmpls.ExecuteTemplate(w, "error.html", nil)
+		return
+	}
+	http.Redirect(w, r, "/consent?challenge="+challenge, http.StatusFound)
+}
+
+func MakeGetLogin2FA() http.Handler {
+	return CSRF(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		challenge := r.URL.Query().Get("challenge")
+		if pendingUser(r) == nil {
+			http.Redirect(w, r, "/login?challenge="+challenge, http.StatusFound)
+			return
+		}
+		tmpls.ExecuteTemplate(w, "login_2fa.html", map[string]interface{}{
+			"challenge":      challenge,
+			csrf.TemplateTag: csrf.TemplateField(r),
+		})
+	}))
+}
+
+func MakePostLogin2FA(s Service, logger log.Logger) http.Handler {
+	return CSRF(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			challenge := r.URL.Query().Get("challenge")
+			user := pendingUser(r)
+			if user == nil {
+				http.Redirect(w, r, "/login?challenge="+challenge, http.StatusFound)
+				return
+			}
+			err := r.ParseForm()
+			if err != nil {
+				logger.Log("msg", "cannot parse form", "error", err)
+				tmpls.ExecuteTemplate(w, "error.html", nil)
+				return
+			}
+			err = s.VerifyTwoFactor(withClient(r), *user, r.FormValue("code"))
+			if err != nil {
+				if _, ok := err.(svcerror.Error); !ok {
+					logger.Log("msg", "cannot verify second factor", "error", err)
+					tmpls.ExecuteTemplate(w, "error.html", nil)
+					return
+				}
+				tmpls.ExecuteTemplate(w, "login_2fa.html", map[string]interface{}{
+					"error":          err.Error(),
+					"challenge":      challenge,
 					csrf.TemplateTag: csrf.TemplateField(r),
 				})
 				return
 			}
 			session, _ := store.Get(r, sessionName)
-			session.Values["user"] = user.String()
-			if err := store.Save(r, w, session); err != nil {
+			remember, _ := session.Values["pending_remember"].(bool)
+			if err := logIn(s, w, r, *user, challenge, remember); err != nil {
 				logger.Log("msg", "cannot persist session", "error", err)
 				tmpls.ExecuteTemplate(w, "error.html", nil)
 				return
 			}
-			http.Redirect(w, r, "/consent?challenge="+r.FormValue("challenge"), http.StatusFound)
+			http.Redirect(w, r, "/consent?challenge="+challenge, http.StatusFound)
 		},
 	))
 }
 
-func MakeGetLogout() http.Handler {
+func MakeGetReset() http.Handler {
+	return CSRF(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			tmpls.ExecuteTemplate(w, "reset.html", map[string]interface{}{
+				csrf.TemplateTag: csrf.TemplateField(r),
+				"challenge":      r.URL.Query().Get("challenge"),
+				"email":          r.URL.Query().Get("email"),
+			})
+		}))
+}
+
+func MakePostReset(s Service, logger log.Logger) http.Handler {
+	return CSRF(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			err := r.ParseForm()
+			if err != nil {
+				logger.Log("msg", "failed to parse form", "error", err)
+				tmpls.ExecuteTemplate(w, "error.html", nil)
+				return
+			}
+			// ResetPassword succeeds for unknown addresses too, so this page looks the same either way.
+			if err := s.ResetPassword(withClient(r), r.FormValue("email")); err != nil {
+				logger.Log("msg", "failed to send password reset", "error", err)
+				tmpls.ExecuteTemplate(w, "error.html", nil)
+				return
+			}
+			tmpls.ExecuteTemplate(w, "reset.html", map[string]interface{}{
+				"challenge": r.URL.Query().Get("challenge"),
+				"email":     r.FormValue("email"),
+				"sent":      true,
+			})
+		}))
+}
+
+func MakeGetResetConfirm() http.Handler {
+	return CSRF(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			tmpls.ExecuteTemplate(w, "reset_confirm.html", map[string]interface{}{
+				csrf.TemplateTag: csrf.TemplateField(r),
+				"token":          r.URL.Query().Get("token"),
+			})
+		}))
+}
+
+func MakePostResetConfirm(s Service, logger log.Logger) http.Handler {
+	return CSRF(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			err := r.ParseForm()
+			if err != nil {
+				logger.Log("msg", "failed to parse form", "error", err)
+				tmpls.ExecuteTemplate(w, "error.html", nil)
+				return
+			}
+			err = s.ConfirmPasswordReset(withClient(r), r.FormValue("token"), r.FormValue("password"))
+			if err != nil {
+				if _, ok := err.(svcerror.Error); !ok {
+					logger.Log("msg", "failed to reset password", "error", err)
+					tmpls.ExecuteTemplate(w, "error.html", nil)
+					return
+				}
+				tmpls.ExecuteTemplate(w, "reset_confirm.html", map[string]interface{}{
+					csrf.TemplateTag: csrf.TemplateField(r),
+					"token":          r.FormValue("token"),
+					"error":          err.Error(),
+				})
+				return
+			}
+			http.Redirect(w, r, "/login", http.StatusFound)
+		}))
+}
+
+func MakeGetVerify(s Service, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := s.VerifyEmail(withClient(r), r.URL.Query().Get("token"))
+		if err != nil {
+			if _, ok := err.(svcerror.Error); !ok {
+				logger.Log("msg", "failed to verify email", "error", err)
+				tmpls.ExecuteTemplate(w, "error.html", nil)
+				return
+			}
+			tmpls.ExecuteTemplate(w, "verify.html", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+		tmpls.ExecuteTemplate(w, "verify.html", nil)
+	})
+}
+
+// MakeGetLogout logs the user out, following OpenID Connect RP-initiated logout. Clients can ask for the user to be
+// sent back to a post_logout_redirect_uri, with the given state, but only to one they registered with Hydra. Any
+// other request to be sent elsewhere is ignored, and the user is shown that they have logged out instead.
+func MakeGetLogout(s Service, logger log.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		session, _ := store.Get(r, sessionName)
-		delete(session.Values, "user")
+		if token, ok := session.Values["session"].(string); ok {
+			if err := s.LogOut(withClient(r), token); err != nil {
+				logger.Log("msg", "cannot log out of session", "error", err)
+			}
+		}
+		delete(session.Values, "session")
 
 		session.Save(r, w)
 
-		if r.URL.Query().Get("redirect") == "" {
+		redirect, err := postLogoutRedirect(s, r)
+		if err != nil {
+			logger.Log("msg", "post-logout redirect refused", "error", err,
+				"redirect_uri", r.URL.Query().Get("post_logout_redirect_uri"))
+		}
+		if redirect == "" {
 			tmpls.ExecuteTemplate(w, "logout.html", nil)
 		} else {
-			http.Redirect(w, r, r.URL.Query().Get("redirect"), http.StatusFound)
+			http.Redirect(w, r, redirect, http.StatusFound)
 		}
 	})
 }
 
+// postLogoutRedirect returns where a logout request asks for the user to be sent afterwards, with its state, or ""
+// if it doesn't ask or the client didn't register the URI. The client is named by client_id, or else by the audience
+// of id_token_hint. The hint is not verified, as it only picks which client's URIs to check against.
+func postLogoutRedirect(s Service, r *http.Request) (string, error) {
+	q := r.URL.Query()
+	uri := q.Get("post_logout_redirect_uri")
+	if uri == "" {
+		return "", nil
+	}
+	clientID := q.Get("client_id")
+	if clientID == "" {
+		clientID = idTokenAudience(q.Get("id_token_hint"))
+	}
+	if err := s.CheckLogoutRedirect(withClient(r), clientID, uri); err != nil {
+		return "", err
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if state := q.Get("state"); state != "" {
+		v := u.Query()
+		v.Set("state", state)
+		u.RawQuery = v.Encode()
+	}
+	return u.String(), nil
+}
+
+// idTokenAudience returns the client an ID token was issued to, or "" if it names none or more than one.
+func idTokenAudience(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Audience interface{} `json:"aud"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	switch aud := claims.Audience.(type) {
+	case string:
+		return aud
+	case []interface{}:
+		if len(aud) == 1 {
+			c, _ := aud[0].(string)
+			return c
+		}
+	}
+	return ""
+}
+
 func DecodeGetUserInfoRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
 	return nil, nil
 }
@@ -320,6 +924,235 @@ func DecodeGetProfileRequest(_ context.Context, r *http.Request) (request interf
 	return
 }
 
+func DecodeGetProfilesRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req getProfilesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, ErrBadRequest
+	}
+	return req, nil
+}
+
+func DecodeSearchUsersRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	q := r.URL.Query()
+	req := searchUsersRequest{Query: q.Get("q"), Cursor: q.Get("cursor")}
+	if v := q.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			return nil, ErrBadRequest
+		}
+	}
+	return req, nil
+}
+
+func DecodeUpdateUserRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req updateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, ErrBadRequest
+	}
+	return req, nil
+}
+
+func DecodeDeleteUserRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+func DecodeRestoreUserRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+// DecodeSetAvatarRequest takes the picture from the "avatar" field of a multipart form. The part is read as the
+// request is served rather than buffered here.
+func DecodeSetAvatarRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, ErrBadRequest
+	}
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			return nil, ErrBadRequest
+		}
+		if part.FormName() == "avatar" {
+			contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			return setAvatarRequest{ContentType: contentType, Image: part}, nil
+		}
+	}
+}
+
+func DecodeDeleteAvatarRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+func DecodeEnrollTOTPRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+func DecodeBeginPasskeyRegistrationRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+func DecodeFinishPasskeyRegistrationRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req finishPasskeyRegistrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, ErrBadRequest
+	}
+	return req, nil
+}
+
+func DecodeListIdentitiesRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+func DecodeUnlinkIdentityRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	provider, ok := mux.Vars(r)["provider"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return unlinkIdentityRequest{Provider: provider}, nil
+}
+
+func DecodeListSessionsRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+func DecodeRevokeSessionRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	sid, ok := mux.Vars(r)["sessionID"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	id, err := uuid.Parse(sid)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return revokeSessionRequest{SessionID: id}, nil
+}
+
+func DecodeListAppsRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+func DecodeRevokeAppRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	clientID, ok := mux.Vars(r)["clientID"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return revokeAppRequest{ClientID: clientID}, nil
+}
+
+func DecodeStartExportRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+func DecodeGetExportRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	sid, ok := mux.Vars(r)["exportID"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	id, err := uuid.Parse(sid)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return getExportRequest{ExportID: id}, nil
+}
+
+func DecodeListUsersRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	q := r.URL.Query()
+	filter := UserFilter{
+		Email:  q.Get("email"),
+		Name:   q.Get("name"),
+		Cursor: q.Get("cursor"),
+	}
+	if v := q.Get("active"); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, ErrBadRequest
+		}
+		filter.Active = &active
+	}
+	if v := q.Get("state"); v != "" {
+		if _, ok := transitions[v]; !ok {
+			return nil, ErrBadRequest
+		}
+		filter.State = v
+	}
+	if v := q.Get("limit"); v != "" {
+		if filter.Limit, err = strconv.Atoi(v); err != nil {
+			return nil, ErrBadRequest
+		}
+	}
+	return filter, nil
+}
+
+func DecodeListAuditEventsRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	q := r.URL.Query()
+	filter := AuditFilter{
+		Type:   q.Get("type"),
+		Cursor: q.Get("cursor"),
+	}
+	if v := q.Get("user"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, ErrBadRequest
+		}
+		filter.UserID = &id
+	}
+	if v := q.Get("since"); v != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, ErrBadRequest
+		}
+	}
+	if v := q.Get("until"); v != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, ErrBadRequest
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		if filter.Limit, err = strconv.Atoi(v); err != nil {
+			return nil, ErrBadRequest
+		}
+	}
+	return filter, nil
+}
+
+func DecodeVerifyAuditLogRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+func DecodeAdminUserRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	sid, ok := mux.Vars(r)["userID"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	id, err := uuid.Parse(sid)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return adminUserRequest{UserID: id}, nil
+}
+
+// DecodeSetUserActiveRequest returns a decoder for requests that set whether a user is active, which is determined by
+// the path rather than the body. The body, which gives the reason, may be left out.
+func DecodeSetUserActiveRequest(active bool) httptransport.DecodeRequestFunc {
+	return func(ctx context.Context, r *http.Request) (request interface{}, err error) {
+		req, err := DecodeAdminUserRequest(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		var body setUserActiveRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+			return nil, ErrBadRequest
+		}
+		return setUserActiveRequest{UserID: req.(adminUserRequest).UserID, Active: active, Reason: body.Reason}, nil
+	}
+}
+
+func DecodeTOTPCodeRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req totpCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, ErrBadRequest
+	}
+	return req, nil
+}
+
 // errorer is implemented by all concrete response types that may contain
 // errors. It allows us to change the HTTP response code without needing to
 // trigger an endpoint (transport-level) error. For more information, read the
@@ -372,15 +1205,184 @@ func httpStatusFrom(code int) int {
 		return http.StatusInternalServerError
 	case codes.UserExists:
 		return http.StatusBadRequest
+	case codes.InvalidToken:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.DeleteOwner:
+		return http.StatusConflict
+	case codes.EmailInUse:
+		return http.StatusConflict
+	case codes.TooManyAttempts:
+		return http.StatusTooManyRequests
+	case codes.WrongCode:
+		return http.StatusBadRequest
+	case codes.TwoFactorEnabled:
+		return http.StatusConflict
+	case codes.InvalidCredential:
+		return http.StatusBadRequest
+	case codes.IdentityInUse:
+		return http.StatusConflict
+	case codes.LastIdentity:
+		return http.StatusConflict
+	case codes.LinkRequired:
+		return http.StatusConflict
+	case codes.AccountInactive:
+		return http.StatusForbidden
+	case codes.InvalidTransition:
+		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
 	}
 }
 
-func authenticated(r *http.Request) *uuid.UUID {
+// withClient returns the request's context carrying the client's IP address and user agent.
+func withClient(r *http.Request) context.Context {
+	return clientToContext(r.Context(), r)
+}
+
+// clientToContext puts the client's IP address and user agent in the context, for use as a ServerBefore option.
+func clientToContext(ctx context.Context, r *http.Request) context.Context {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		ip = host
+	}
+	if trustProxy {
+		// Our proxy appends the address it saw, so the last entry is the only one a client can't forge.
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			hops := strings.Split(fwd, ",")
+			ip = strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+	ctx = context.WithValue(ctx, RemoteAddrContextKey, ip)
+	return context.WithValue(ctx, UserAgentContextKey, r.UserAgent())
+}
+
+// logIn starts a session for a user who has proven who they are, keeping its token in the cookie in place of any
+// earlier session or login still waiting on a second factor. The session satisfies any max_age of the consent
+// challenge it was started for. Unless the user asked to be remembered, the cookie only lasts until the browser
+// closes.
+func logIn(s Service, w http.ResponseWriter, r *http.Request, user uuid.UUID, challenge string, remember bool) error {
+	session, _ := store.Get(r, sessionName)
+	if token, ok := session.Values["session"].(string); ok {
+		if err := s.EndSession(withClient(r), token); err != nil {
+			return err
+		}
+	}
+	token, expiresAt, err := s.CreateSession(withClient(r), user, remember)
+	if err != nil {
+		return err
+	}
+	for _, key := range []string{"pending_user", "pending_since", "pending_remember"} {
+		delete(session.Values, key)
+	}
+	session.Values["session"] = token
+	session.Values["login_challenge"] = challenge
+	options := *store.Options
+	options.MaxAge = 0
+	if remember {
+		options.MaxAge = int(time.Until(expiresAt).Seconds())
+	}
+	session.Options = &options
+	return store.Save(r, w, session)
+}
+
+// authenticated returns the user logged in with the cookie's session, or nil if it has ended, such as by timing out
+// or being revoked.
+func authenticated(s Service, r *http.Request) *uuid.UUID {
+	user, _ := authenticatedSince(s, r)
+	return user
+}
+
+// authenticatedSince is authenticated, but also returns when the user logged in.
+func authenticatedSince(s Service, r *http.Request) (*uuid.UUID, time.Time) {
 	session, _ := store.Get(r, sessionName)
-	u, ok := session.Values["user"].(string)
+	token, ok := session.Values["session"].(string)
 	if !ok {
+		return nil, time.Time{}
+	}
+	user, authTime, err := s.CheckSession(withClient(r), token)
+	if err != nil {
+		return nil, time.Time{}
+	}
+	return &user, authTime
+}
+
+// consentUser returns the user to answer a consent challenge for, and when they logged in. A user who logged in
+// longer ago than the client's max_age allows has to log in again, unless they did so for this very challenge, so
+// that max_age=0 doesn't send them round in circles. reauth reports whether that is why user is nil.
+func consentUser(s Service, r *http.Request, claims *sdk.ChallengeClaims, challenge string) (user *uuid.UUID, authTime time.Time, reauth bool) {
+	user, authTime = authenticatedSince(s, r)
+	if user == nil {
+		return nil, authTime, false
+	}
+	maxAge, ok := requestedMaxAge(claims)
+	if !ok || time.Since(authTime) <= maxAge {
+		return user, authTime, false
+	}
+	session, _ := store.Get(r, sessionName)
+	if c, _ := session.Values["login_challenge"].(string); c != "" && c == challenge {
+		return user, authTime, false
+	}
+	return nil, authTime, true
+}
+
+// idTokenExtra records that the client behind a consent challenge is being given tokens during the user's session,
+// and returns the claims to add to its ID token: auth_time, for clients that ask for a max_age, and sid, which logout
+// tokens name the session by.
+func idTokenExtra(s Service, r *http.Request, claims *sdk.ChallengeClaims, authTime time.Time) (map[string]interface{}, error) {
+	session, _ := store.Get(r, sessionName)
+	token, _ := session.Values["session"].(string)
+	sid, err := s.AddSessionClient(withClient(r), token, claims.Audience, claims.RequestedScopes)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"auth_time": authTime.Unix(), "sid": sid.String()}, nil
+}
+
+// authRequestParam returns a parameter of the authorization request behind a consent challenge. Hydra doesn't put
+// parameters like max_age and prompt in the challenge itself, but redir is the URL of the original request.
+func authRequestParam(claims *sdk.ChallengeClaims, name string) string {
+	u, err := url.Parse(claims.RedirectURL)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get(name)
+}
+
+// promptsConsent reports whether the client asked for the user to be asked for consent even if they already gave it.
+func promptsConsent(claims *sdk.ChallengeClaims) bool {
+	for _, prompt := range strings.Fields(authRequestParam(claims, "prompt")) {
+		if prompt == "consent" {
+			return true
+		}
+	}
+	return false
+}
+
+// requestedMaxAge returns the max_age of the authorization request behind a consent challenge.
+func requestedMaxAge(claims *sdk.ChallengeClaims) (time.Duration, bool) {
+	v := authRequestParam(claims, "max_age")
+	if v == "" {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(v)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// pendingUser returns the user who has entered their password but not yet their second factor, if they did so
+// recently enough.
+func pendingUser(r *http.Request) *uuid.UUID {
+	session, _ := store.Get(r, sessionName)
+	u, ok := session.Values["pending_user"].(string)
+	if !ok {
+		return nil
+	}
+	since, ok := session.Values["pending_since"].(int64)
+	if !ok || time.Since(time.Unix(since, 0)) > pendingTTL {
 		return nil
 	}
 	user, err := uuid.Parse(u)
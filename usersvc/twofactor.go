@@ -0,0 +1,65 @@
+package usersvc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+
+	"github.com/studiously/usersvc/totp"
+)
+
+const (
+	// totpIssuer is the name authenticator apps list codes under.
+	totpIssuer = "Studiously"
+	// totpSkew is how many 30 second steps either side of now are accepted, to allow for clocks that drift.
+	totpSkew = 1
+	// recoveryCodeCount is how many recovery codes are issued at a time.
+	recoveryCodeCount = 10
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCodes returns a set of random recovery codes, formatted for reading off a printout, and their hashes
+// for storage.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(recoveryEncoding.EncodeToString(b))
+		codes = append(codes, code[:4]+"-"+code[4:])
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode hashes a recovery code as typed by the user. Recovery codes are random enough that a fast hash
+// is sufficient.
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeCode strips the separators and case that people add or change when typing codes in.
+func normalizeCode(code string) string {
+	code = strings.Replace(code, "-", "", -1)
+	code = strings.Replace(code, " ", "", -1)
+	return strings.ToLower(code)
+}
+
+// isTOTPCode reports whether code looks like it came from an authenticator app rather than a recovery code.
+func isTOTPCode(code string) bool {
+	code = normalizeCode(code)
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
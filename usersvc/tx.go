@@ -0,0 +1,51 @@
+package usersvc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// transact runs fn as a single unit of work. The transaction is committed if fn returns nil and rolled back
+// otherwise, including when fn panics. Every operation that writes more than one row should go through here, using
+// the *sql.Tx it is given for all of its queries.
+func transact(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	return fn(tx)
+}
+
+// uniqueViolation reports whether err is a Postgres unique_violation, and if so, on which constraint.
+func uniqueViolation(err error) (constraint string, ok bool) {
+	if e, isPQ := err.(*pq.Error); isPQ && e.Code == "23505" {
+		return e.Constraint, true
+	}
+	return "", false
+}
+
+// translateUserErr maps constraint violations on the users table to service errors, leaving other errors as-is.
+func translateUserErr(err error) error {
+	constraint, ok := uniqueViolation(err)
+	switch {
+	case !ok:
+		return err
+	case constraint == "users_email_key":
+		return ErrEmailInUse
+	default:
+		return ErrUserExists
+	}
+}
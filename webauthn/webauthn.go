@@ -0,0 +1,366 @@
+// Package webauthn implements the relying party side of Web Authentication (https://www.w3.org/TR/webauthn-2/),
+// for logging in with passkeys. It deals only in bytes and JSON, leaving storage of challenges and credentials to
+// the caller, so that a software authenticator can drive it end to end.
+//
+// Credentials are registered as discoverable and must verify the user, with a PIN or biometric, so a passkey stands
+// in for both a password and a second factor. Attestation is not requested, so any authenticator is accepted.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Timeout is how long the browser is given to complete a ceremony.
+const Timeout = 5 * time.Minute
+
+var (
+	// ErrInvalidResponse is returned when a response from the browser is malformed, or doesn't match the challenge,
+	// origin or relying party it should.
+	ErrInvalidResponse = errors.New("webauthn: invalid response")
+	// ErrUserNotVerified is returned when the authenticator didn't verify the user.
+	ErrUserNotVerified = errors.New("webauthn: user not verified")
+	// ErrBadSignature is returned when an assertion isn't signed by the credential it claims to be from.
+	ErrBadSignature = errors.New("webauthn: signature verification failed")
+	// ErrCloned is returned when an authenticator's signature counter goes backwards, which suggests the credential
+	// has been copied.
+	ErrCloned = errors.New("webauthn: signature counter did not increase")
+)
+
+// Authenticator data flags.
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+	flagExtensions   = 0x80
+)
+
+// Base64URL is binary data that is encoded in JSON as unpadded base64url, the way WebAuthn's JSON serializations
+// encode it.
+type Base64URL []byte
+
+func (b Base64URL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func (b *Base64URL) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// RelyingParty identifies the site credentials are registered to.
+type RelyingParty struct {
+	// ID is the domain credentials are scoped to. It must be the host of each origin, or a registrable suffix of it.
+	ID string
+	// Name is shown to the user by some authenticators.
+	Name string
+	// Origins lists where ceremonies may take place, such as "https://accounts.example.com".
+	Origins []string
+}
+
+// User identifies the account a credential is registered for.
+type User struct {
+	// ID is an opaque handle, returned by the authenticator when the credential is used.
+	ID          []byte
+	Name        string
+	DisplayName string
+}
+
+// Credential is a registered public key credential.
+type Credential struct {
+	ID []byte
+	// PublicKey is the credential's COSE_Key encoded public key.
+	PublicKey []byte
+	SignCount uint32
+}
+
+// NewChallenge returns a random challenge for a single ceremony.
+func NewChallenge() ([]byte, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// CreationOptions is the JSON form of PublicKeyCredentialCreationOptions, to pass to navigator.credentials.create
+// once its binary fields are decoded.
+type CreationOptions struct {
+	Challenge              Base64URL              `json:"challenge"`
+	RP                     rpEntity               `json:"rp"`
+	User                   userEntity             `json:"user"`
+	PubKeyCredParams       []credentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []credentialDescriptor `json:"excludeCredentials,omitempty"`
+	AuthenticatorSelection authenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions is the JSON form of PublicKeyCredentialRequestOptions, to pass to navigator.credentials.get once its
+// binary fields are decoded.
+type RequestOptions struct {
+	Challenge        Base64URL              `json:"challenge"`
+	RPID             string                 `json:"rpId"`
+	Timeout          int64                  `json:"timeout"`
+	AllowCredentials []credentialDescriptor `json:"allowCredentials,omitempty"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+type rpEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type userEntity struct {
+	ID          Base64URL `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+}
+
+type credentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type credentialDescriptor struct {
+	Type string    `json:"type"`
+	ID   Base64URL `json:"id"`
+}
+
+type authenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+// CreationOptions returns the options for registering a new credential for user. exclude lists the IDs of
+// credentials the user already has, so the same authenticator isn't registered twice.
+func (rp RelyingParty) CreationOptions(user User, challenge []byte, exclude [][]byte) CreationOptions {
+	return CreationOptions{
+		Challenge: challenge,
+		RP:        rpEntity{rp.ID, rp.Name},
+		User:      userEntity{user.ID, user.Name, user.DisplayName},
+		PubKeyCredParams: []credentialParameter{
+			{"public-key", AlgES256},
+			{"public-key", AlgEdDSA},
+			{"public-key", AlgRS256},
+		},
+		Timeout:            int64(Timeout / time.Millisecond),
+		ExcludeCredentials: descriptors(exclude),
+		AuthenticatorSelection: authenticatorSelection{
+			ResidentKey:        "required",
+			RequireResidentKey: true,
+			UserVerification:   "required",
+		},
+		Attestation: "none",
+	}
+}
+
+// RequestOptions returns the options for logging in. If allow is empty, the user picks any discoverable credential
+// they have for this relying party.
+func (rp RelyingParty) RequestOptions(challenge []byte, allow [][]byte) RequestOptions {
+	return RequestOptions{
+		Challenge:        challenge,
+		RPID:             rp.ID,
+		Timeout:          int64(Timeout / time.Millisecond),
+		AllowCredentials: descriptors(allow),
+		UserVerification: "required",
+	}
+}
+
+func descriptors(ids [][]byte) []credentialDescriptor {
+	var d []credentialDescriptor
+	for _, id := range ids {
+		d = append(d, credentialDescriptor{"public-key", id})
+	}
+	return d
+}
+
+// AttestationResponse is the JSON form of the PublicKeyCredential returned by navigator.credentials.create.
+type AttestationResponse struct {
+	RawID    Base64URL `json:"rawId"`
+	Response struct {
+		ClientDataJSON    Base64URL `json:"clientDataJSON"`
+		AttestationObject Base64URL `json:"attestationObject"`
+	} `json:"response"`
+}
+
+// AssertionResponse is the JSON form of the PublicKeyCredential returned by navigator.credentials.get.
+type AssertionResponse struct {
+	RawID    Base64URL `json:"rawId"`
+	Response struct {
+		ClientDataJSON    Base64URL `json:"clientDataJSON"`
+		AuthenticatorData Base64URL `json:"authenticatorData"`
+		Signature         Base64URL `json:"signature"`
+		UserHandle        Base64URL `json:"userHandle,omitempty"`
+	} `json:"response"`
+}
+
+type clientData struct {
+	Type      string    `json:"type"`
+	Challenge Base64URL `json:"challenge"`
+	Origin    string    `json:"origin"`
+}
+
+// Challenge returns the challenge a response to navigator.credentials.create or get claims to answer, so the caller
+// can look up the ceremony it belongs to. It has not been verified.
+func Challenge(clientDataJSON []byte) ([]byte, error) {
+	var cd clientData
+	if err := json.Unmarshal(clientDataJSON, &cd); err != nil {
+		return nil, ErrInvalidResponse
+	}
+	return cd.Challenge, nil
+}
+
+// VerifyRegistration checks a response to CreationOptions built with challenge, and returns the new credential.
+func (rp RelyingParty) VerifyRegistration(resp AttestationResponse, challenge []byte) (*Credential, error) {
+	if err := rp.verifyClientData(resp.Response.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+	v, _, err := decodeCBOR(resp.Response.AttestationObject)
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	obj, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	// The attestation statement is deliberately ignored, since "none" was asked for and browsers are free to strip
+	// it anyway.
+	authData, ok := obj["authData"].([]byte)
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	ad, err := rp.parseAuthData(authData)
+	if err != nil {
+		return nil, err
+	}
+	if ad.credentialID == nil {
+		return nil, ErrInvalidResponse
+	}
+	if len(resp.RawID) > 0 && !bytes.Equal(resp.RawID, ad.credentialID) {
+		return nil, ErrInvalidResponse
+	}
+	return &Credential{
+		ID:        ad.credentialID,
+		PublicKey: ad.publicKey,
+		SignCount: ad.signCount,
+	}, nil
+}
+
+// VerifyAssertion checks a response to RequestOptions built with challenge against the credential it was made with,
+// and returns the credential's new signature count, which the caller should store.
+func (rp RelyingParty) VerifyAssertion(resp AssertionResponse, challenge []byte, cred Credential) (uint32, error) {
+	if !bytes.Equal(resp.RawID, cred.ID) {
+		return 0, ErrInvalidResponse
+	}
+	if err := rp.verifyClientData(resp.Response.ClientDataJSON, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+	ad, err := rp.parseAuthData(resp.Response.AuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+	key, _, err := parsePublicKey(cred.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+	hash := sha256.Sum256(resp.Response.ClientDataJSON)
+	signed := append(append([]byte(nil), resp.Response.AuthenticatorData...), hash[:]...)
+	if !key.verify(signed, resp.Response.Signature) {
+		return 0, ErrBadSignature
+	}
+	// Authenticators that don't keep a counter always report zero.
+	if (ad.signCount != 0 || cred.SignCount != 0) && ad.signCount <= cred.SignCount {
+		return 0, ErrCloned
+	}
+	return ad.signCount, nil
+}
+
+func (rp RelyingParty) verifyClientData(data []byte, typ string, challenge []byte) error {
+	var cd clientData
+	if err := json.Unmarshal(data, &cd); err != nil {
+		return ErrInvalidResponse
+	}
+	if cd.Type != typ || subtle.ConstantTimeCompare(cd.Challenge, challenge) != 1 {
+		return ErrInvalidResponse
+	}
+	for _, origin := range rp.Origins {
+		if cd.Origin == origin {
+			return nil
+		}
+	}
+	return ErrInvalidResponse
+}
+
+type authData struct {
+	signCount    uint32
+	credentialID []byte
+	publicKey    []byte
+}
+
+// parseAuthData decodes authenticator data, checking it is scoped to this relying party and that the user was
+// present and verified.
+func (rp RelyingParty) parseAuthData(b []byte) (*authData, error) {
+	if len(b) < 37 {
+		return nil, ErrInvalidResponse
+	}
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(b[:32], rpIDHash[:]) != 1 {
+		return nil, ErrInvalidResponse
+	}
+	flags := b[32]
+	if flags&flagUserPresent == 0 {
+		return nil, ErrInvalidResponse
+	}
+	if flags&flagUserVerified == 0 {
+		return nil, ErrUserNotVerified
+	}
+	ad := &authData{signCount: binary.BigEndian.Uint32(b[33:37])}
+	rest := b[37:]
+	if flags&flagAttested != 0 {
+		// AAGUID, then the length-prefixed credential ID, then the public key.
+		if len(rest) < 18 {
+			return nil, ErrInvalidResponse
+		}
+		n := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if n == 0 || len(rest) < n {
+			return nil, ErrInvalidResponse
+		}
+		ad.credentialID = append([]byte(nil), rest[:n]...)
+		rest = rest[n:]
+		_, after, err := parsePublicKey(rest)
+		if err != nil {
+			return nil, err
+		}
+		ad.publicKey = append([]byte(nil), rest[:len(rest)-len(after)]...)
+		rest = after
+	}
+	if flags&flagExtensions != 0 {
+		var err error
+		if _, rest, err = decodeCBOR(rest); err != nil {
+			return nil, ErrInvalidResponse
+		}
+	}
+	if len(rest) != 0 {
+		return nil, ErrInvalidResponse
+	}
+	return ad, nil
+}
@@ -0,0 +1,246 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"testing"
+)
+
+const testOrigin = "https://accounts.example.com"
+
+var testRP = RelyingParty{ID: "example.com", Name: "Example", Origins: []string{testOrigin}}
+
+// authenticator is a software authenticator holding a single ES256 credential.
+type authenticator struct {
+	rpID      string
+	key       *ecdsa.PrivateKey
+	id        []byte
+	signCount uint32
+	// flags are the authenticator data flags it reports, other than flagAttested.
+	flags byte
+}
+
+func newAuthenticator(t *testing.T, rpID string) *authenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := make([]byte, 16)
+	rand.Read(id)
+	return &authenticator{rpID: rpID, key: key, id: id, flags: flagUserPresent | flagUserVerified}
+}
+
+func (a *authenticator) coseKey() []byte {
+	x, y := make([]byte, 32), make([]byte, 32)
+	a.key.X.FillBytes(x)
+	a.key.Y.FillBytes(y)
+	return encodeCBOR(map[interface{}]interface{}{
+		int64(coseKty): int64(ktyEC2),
+		int64(coseAlg): int64(AlgES256),
+		int64(coseCrv): int64(crvP256),
+		int64(coseX):   x,
+		int64(coseY):   y,
+	})
+}
+
+func (a *authenticator) authData(attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+	b := append([]byte(nil), rpIDHash[:]...)
+	flags := a.flags
+	if attested {
+		flags |= flagAttested
+	}
+	b = append(b, flags)
+	b = binary.BigEndian.AppendUint32(b, a.signCount)
+	if attested {
+		b = append(b, make([]byte, 16)...)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(a.id)))
+		b = append(b, a.id...)
+		b = append(b, a.coseKey()...)
+	}
+	return b
+}
+
+func clientDataJSON(typ string, challenge []byte, origin string) []byte {
+	b, _ := json.Marshal(clientData{Type: typ, Challenge: challenge, Origin: origin})
+	return b
+}
+
+// create answers navigator.credentials.create.
+func (a *authenticator) create(challenge []byte, origin string) AttestationResponse {
+	var resp AttestationResponse
+	resp.RawID = a.id
+	resp.Response.ClientDataJSON = clientDataJSON("webauthn.create", challenge, origin)
+	resp.Response.AttestationObject = encodeCBOR(map[interface{}]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[interface{}]interface{}{},
+		"authData": a.authData(true),
+	})
+	return resp
+}
+
+// get answers navigator.credentials.get, counting the signature.
+func (a *authenticator) get(t *testing.T, challenge []byte, origin string) AssertionResponse {
+	a.signCount++
+	var resp AssertionResponse
+	resp.RawID = a.id
+	resp.Response.ClientDataJSON = clientDataJSON("webauthn.get", challenge, origin)
+	resp.Response.AuthenticatorData = a.authData(false)
+	hash := sha256.Sum256(resp.Response.ClientDataJSON)
+	sum := sha256.Sum256(append(append([]byte(nil), resp.Response.AuthenticatorData...), hash[:]...))
+	sig, err := ecdsa.SignASN1(rand.Reader, a.key, sum[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Response.Signature = sig
+	return resp
+}
+
+// encodeCBOR encodes the types decodeCBOR returns, with map keys in a stable order.
+func encodeCBOR(v interface{}) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n < 1<<8:
+			return []byte{major<<5 | 24, byte(n)}
+		case n < 1<<16:
+			return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+		default:
+			return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n))
+		}
+	}
+	switch v := v.(type) {
+	case int64:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case map[interface{}]interface{}:
+		var items [][]byte
+		for k, val := range v {
+			items = append(items, append(encodeCBOR(k), encodeCBOR(val)...))
+		}
+		sort.Slice(items, func(i, j int) bool { return bytes.Compare(items[i], items[j]) < 0 })
+		b := head(5, uint64(len(v)))
+		for _, item := range items {
+			b = append(b, item...)
+		}
+		return b
+	}
+	panic("encodeCBOR: unsupported type")
+}
+
+func challenge(t *testing.T) []byte {
+	c, err := NewChallenge()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func register(t *testing.T, a *authenticator) *Credential {
+	c := challenge(t)
+	cred, err := testRP.VerifyRegistration(a.create(c, testOrigin), c)
+	if err != nil {
+		t.Fatalf("VerifyRegistration: %v", err)
+	}
+	return cred
+}
+
+func TestRegistrationAndLogin(t *testing.T) {
+	a := newAuthenticator(t, testRP.ID)
+	cred := register(t, a)
+	if !bytes.Equal(cred.ID, a.id) || !bytes.Equal(cred.PublicKey, a.coseKey()) || cred.SignCount != 0 {
+		t.Fatalf("registered credential = %+v", cred)
+	}
+	for want := uint32(1); want <= 2; want++ {
+		c := challenge(t)
+		count, err := testRP.VerifyAssertion(a.get(t, c, testOrigin), c, *cred)
+		if err != nil {
+			t.Fatalf("VerifyAssertion: %v", err)
+		}
+		if count != want {
+			t.Fatalf("sign count = %d, want %d", count, want)
+		}
+		cred.SignCount = count
+	}
+}
+
+func TestSignCountRegression(t *testing.T) {
+	a := newAuthenticator(t, testRP.ID)
+	cred := register(t, a)
+	// A clone of the authenticator that has fallen behind the original.
+	cred.SignCount = 5
+	a.signCount = 2
+	c := challenge(t)
+	if _, err := testRP.VerifyAssertion(a.get(t, c, testOrigin), c, *cred); err != ErrCloned {
+		t.Fatalf("VerifyAssertion = %v, want ErrCloned", err)
+	}
+}
+
+func TestOriginMismatch(t *testing.T) {
+	a := newAuthenticator(t, testRP.ID)
+	c := challenge(t)
+	if _, err := testRP.VerifyRegistration(a.create(c, "https://evil.example"), c); err != ErrInvalidResponse {
+		t.Fatalf("VerifyRegistration = %v, want ErrInvalidResponse", err)
+	}
+	cred := register(t, a)
+	c = challenge(t)
+	if _, err := testRP.VerifyAssertion(a.get(t, c, "https://evil.example"), c, *cred); err != ErrInvalidResponse {
+		t.Fatalf("VerifyAssertion = %v, want ErrInvalidResponse", err)
+	}
+}
+
+func TestRPIDMismatch(t *testing.T) {
+	a := newAuthenticator(t, "evil.example")
+	c := challenge(t)
+	if _, err := testRP.VerifyRegistration(a.create(c, testOrigin), c); err != ErrInvalidResponse {
+		t.Fatalf("VerifyRegistration = %v, want ErrInvalidResponse", err)
+	}
+	// A credential registered here, but asserted for another relying party.
+	cred := register(t, &authenticator{rpID: testRP.ID, key: a.key, id: a.id, flags: a.flags})
+	c = challenge(t)
+	if _, err := testRP.VerifyAssertion(a.get(t, c, testOrigin), c, *cred); err != ErrInvalidResponse {
+		t.Fatalf("VerifyAssertion = %v, want ErrInvalidResponse", err)
+	}
+}
+
+func TestChallengeMismatch(t *testing.T) {
+	a := newAuthenticator(t, testRP.ID)
+	cred := register(t, a)
+	if _, err := testRP.VerifyAssertion(a.get(t, challenge(t), testOrigin), challenge(t), *cred); err != ErrInvalidResponse {
+		t.Fatalf("VerifyAssertion = %v, want ErrInvalidResponse", err)
+	}
+}
+
+func TestUserNotVerified(t *testing.T) {
+	a := newAuthenticator(t, testRP.ID)
+	a.flags = flagUserPresent
+	c := challenge(t)
+	if _, err := testRP.VerifyRegistration(a.create(c, testOrigin), c); err != ErrUserNotVerified {
+		t.Fatalf("VerifyRegistration = %v, want ErrUserNotVerified", err)
+	}
+}
+
+func TestBadSignature(t *testing.T) {
+	a := newAuthenticator(t, testRP.ID)
+	cred := register(t, a)
+	// Another authenticator claiming to hold the same credential.
+	impostor := newAuthenticator(t, testRP.ID)
+	impostor.id = a.id
+	c := challenge(t)
+	if _, err := testRP.VerifyAssertion(impostor.get(t, c, testOrigin), c, *cred); err != ErrBadSignature {
+		t.Fatalf("VerifyAssertion = %v, want ErrBadSignature", err)
+	}
+}